@@ -59,6 +59,9 @@ var featureScenarios = []scenario{
 		overlays: []harness.Overlay{harness.Transient},
 		skips: map[string]string{
 			"bash": "bash only supports a transient prompt inside a ble.sh session; not supported by this harness",
+			"osh":  "osh has no transient prompt",
+			"ysh":  "ysh has no transient prompt",
+			"tcsh": "tcsh has no transient prompt",
 		},
 		run: func(t *testing.T, sh harness.ShellDef, s *harness.Session) {
 			const echoedText = "transient-check"
@@ -92,15 +95,11 @@ var featureScenarios = []scenario{
 		// rprompt boots with the rprompt overlay and asserts the fixed "RMARK"
 		// marker renders on the same screen row as the primary "E2E:0>" prompt,
 		// right-aligned near the pty's 120th column.
-		//
-		// bash only renders a right prompt inside a ble.sh session (gated on the
-		// BLE_SESSION_ID environment variable, see src/shell/bash.go), which this
-		// harness's plain `bash --noprofile --rcfile ... -i` session does not
-		// provide, so bash is skipped explicitly rather than asserted against.
 		name:     "rprompt",
 		overlays: []harness.Overlay{harness.RPrompt},
 		skips: map[string]string{
-			"bash": "bash only renders a right prompt inside a ble.sh session; not supported by this harness",
+			"osh": "osh has no right prompt",
+			"ysh": "ysh has no right prompt",
 		},
 		run: func(t *testing.T, sh harness.ShellDef, s *harness.Session) {
 			var promptLine string
@@ -149,15 +148,10 @@ var featureScenarios = []scenario{
 		// (Final Term Control Sequence) marks land in the raw byte stream: prompt
 		// start (133;A) and command start (133;B) around the first prompt, then
 		// pre-execution (133;C) and command-finished (133;D) around a typed command.
-		//
-		// nu's init script intentionally emits none of the FTCS marks: Features().Nu()'s
-		// switch does list a case for FTCSMarks (grouped with several other features), but
-		// that case deliberately returns an empty Code (see src/shell/nu.go), so the
-		// generated script never gets the hook that prints them.
 		name:     "ftcs",
 		overlays: []harness.Overlay{harness.ShellIntegration},
 		skips: map[string]string{
-			"nu": "nu's FTCSMarks case in src/shell/nu.go deliberately emits nothing, so shell_integration marks never appear for nu",
+			"ysh": "ysh has no hook running before a command, so there is no pre-execution mark",
 		},
 		run: func(t *testing.T, sh harness.ShellDef, s *harness.Session) {
 			raw := s.Raw()
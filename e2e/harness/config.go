@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -80,6 +81,44 @@ func Tooltips(cfg map[string]any) {
 	}
 }
 
+// Streaming enables streaming with a 100ms timeout and appends a language segment to the
+// primary prompt block whose version takes a second to fetch, so the prompt first renders
+// it pending ("...") and repaints with " SLOW:1.2.3" once the version is in.
+func Streaming(cfg map[string]any) {
+	cfg["streaming"] = 100
+
+	// the language segment's tools only need an executable that sleeps before printing
+	// a version: sh everywhere but Windows, where pwsh is the one shell CI always has
+	slow := map[string]any{
+		"name":       "slow",
+		"executable": "sh",
+		"args":       []any{"-c", "sleep 1; echo 1.2.3"},
+		"regex":      `(?P<version>[0-9]+\.[0-9]+\.[0-9]+)`,
+	}
+
+	if runtime.GOOS == "windows" {
+		slow["executable"] = "pwsh"
+		slow["args"] = []any{"-NoProfile", "-Command", "Start-Sleep 1; '1.2.3'"}
+	}
+
+	blocks, _ := cfg["blocks"].([]any)
+	block, _ := blocks[0].(map[string]any)
+	segments, _ := block["segments"].([]any)
+
+	segments = append(segments, map[string]any{
+		"type":     "language",
+		"style":    "plain",
+		"template": " SLOW:{{ .Full }}",
+		"options": map[string]any{
+			"name":         "e2e-slow",
+			"display_mode": "always",
+			"tools":        []any{slow},
+		},
+	})
+
+	block["segments"] = segments
+}
+
 // Full combines Transient, RPrompt and Tooltips.
 func Full(cfg map[string]any) {
 	Transient(cfg)
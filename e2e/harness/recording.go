@@ -0,0 +1,216 @@
+package harness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordEnvVar names the environment variable that switches TestReplay from diffing the
+// recorded screens to capturing them. See RecordMode.
+const recordEnvVar = "OMP_E2E_RECORD"
+
+// settleQuiet is how long a screen must stay unchanged before a replayed step snapshots it.
+// It has to outlast the gap between a prompt and the repaints that follow it (transient
+// prompt, streaming updates), without making every step of every recording slow.
+const settleQuiet = 500 * time.Millisecond
+
+// overlaysByName maps the overlay names used in recordings to their Overlay.
+var overlaysByName = map[string]Overlay{
+	"transient":         Transient,
+	"rprompt":           RPrompt,
+	"tooltips":          Tooltips,
+	"full":              Full,
+	"colored":           Colored,
+	"shell_integration": ShellIntegration,
+	"streaming":         Streaming,
+}
+
+// Placeholders a step's keys and wait expression can use for what differs per shell,
+// expanded by Step.For. FailPlaceholder is the shell's Fail command, FailCodePlaceholder the
+// exit code it reports.
+const (
+	FailPlaceholder     = "{fail}"
+	FailCodePlaceholder = "{fail_code}"
+)
+
+// Recording is a shell session stored as a fixture file: the keystrokes to type, when to
+// type them, and the screen every shell rendered after each step. Replaying it against a
+// shell and diffing the screens catches rendering regressions without a dedicated test.
+//
+// The steps are written once for every shell, using the placeholders for what differs; the
+// screens are captured by replaying the steps with OMP_E2E_RECORD set, per shell, on a host
+// that has that shell installed.
+type Recording struct {
+	// Description explains what the session covers.
+	Description string `json:"description"`
+
+	// Overlays names the config overlays to apply to BaseConfig, see overlaysByName.
+	Overlays []string `json:"overlays,omitempty"`
+
+	// Skips lists the shells the session doesn't apply to, with the reason.
+	Skips map[string]string `json:"skips,omitempty"`
+
+	Steps []Step `json:"steps"`
+
+	// Screens holds, per ScreensKey, one Snapshot per step.
+	Screens map[string][]Snapshot `json:"screens,omitempty"`
+}
+
+// ScreensKey returns the key of sh's screens in Recording.Screens: the shell name, with a
+// "-windows" suffix on Windows, where pwsh and nu render some steps differently, like the
+// exit code of their Fail command.
+func ScreensKey(sh ShellDef) string {
+	if runtime.GOOS == "windows" {
+		return sh.Name + "-windows"
+	}
+
+	return sh.Name
+}
+
+// Step is one batch of keystrokes in a Recording.
+type Step struct {
+	// Keys is written to the pty as-is, after expanding the placeholders; end it with "\r"
+	// to accept the command line.
+	Keys string `json:"keys"`
+
+	// Wait is a regular expression the screen must match before the step's snapshot is
+	// taken, e.g. the prompt following a command's output. It can use the placeholders
+	// too, FailPlaceholder is quoted.
+	Wait string `json:"wait,omitempty"`
+
+	// Pause is how long to wait before typing Keys, in milliseconds, for sessions that
+	// depend on timing such as a streaming prompt that's still loading.
+	Pause int `json:"pause,omitempty"`
+}
+
+// For returns the step with its placeholders expanded for sh.
+func (step Step) For(sh ShellDef) Step {
+	code := strconv.Itoa(sh.Fail.Code)
+
+	step.Keys = strings.NewReplacer(FailPlaceholder, sh.Fail.Command, FailCodePlaceholder, code).Replace(step.Keys)
+	step.Wait = strings.NewReplacer(FailPlaceholder, regexp.QuoteMeta(sh.Fail.Command), FailCodePlaceholder, code).Replace(step.Wait)
+
+	return step
+}
+
+// Snapshot is a rendered screen, one entry per row, with trailing spaces and trailing
+// empty rows removed so recordings stay readable and diffs point at the actual change.
+type Snapshot []string
+
+// NewSnapshot normalizes a screen as returned by Session.Screen into a Snapshot.
+func NewSnapshot(screen string) Snapshot {
+	var snapshot Snapshot
+
+	for line := range strings.SplitSeq(screen, "\n") {
+		snapshot = append(snapshot, strings.TrimRight(line, " "))
+	}
+
+	for len(snapshot) > 0 && snapshot[len(snapshot)-1] == "" {
+		snapshot = snapshot[:len(snapshot)-1]
+	}
+
+	return snapshot
+}
+
+// String joins the rows back into a screen, for diffing.
+func (s Snapshot) String() string {
+	return strings.Join(s, "\n")
+}
+
+// LoadRecording reads and validates the recording at path.
+func LoadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rec Recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if len(rec.Steps) == 0 {
+		return nil, fmt.Errorf("%s has no steps", path)
+	}
+
+	for _, name := range rec.Overlays {
+		if _, ok := overlaysByName[name]; !ok {
+			return nil, fmt.Errorf("%s uses unknown overlay %q", path, name)
+		}
+	}
+
+	for i, step := range rec.Steps {
+		if _, err := regexp.Compile(step.For(Shells[0]).Wait); err != nil {
+			return nil, fmt.Errorf("%s step %d: invalid wait expression: %w", path, i, err)
+		}
+	}
+
+	for sh, screens := range rec.Screens {
+		if len(screens) != len(rec.Steps) {
+			return nil, fmt.Errorf("%s has %d screens for %s but %d steps, record it again", path, len(screens), sh, len(rec.Steps))
+		}
+	}
+
+	return &rec, nil
+}
+
+// Save writes the recording to path.
+func (rec *Recording) Save(path string) error {
+	var buf bytes.Buffer
+
+	// keep prompt characters such as > readable instead of \u003e
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(rec); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// RecordMode reports whether OMP_E2E_RECORD asks to capture screens instead of diffing them.
+func RecordMode() bool {
+	return os.Getenv(recordEnvVar) != ""
+}
+
+// Replay starts sh with the recording's overlays applied, types every step once the first
+// prompt is up, and returns the settled screen after each one.
+func (rec *Recording) Replay(t *testing.T, sh ShellDef) []Snapshot {
+	t.Helper()
+
+	overlays := make([]Overlay, 0, len(rec.Overlays))
+	for _, name := range rec.Overlays {
+		overlays = append(overlays, overlaysByName[name])
+	}
+
+	s := Start(t, sh, WriteConfig(t, overlays...))
+	s.WaitForPrompt()
+
+	snapshots := make([]Snapshot, 0, len(rec.Steps))
+
+	for _, step := range rec.Steps {
+		step = step.For(sh)
+
+		time.Sleep(time.Duration(step.Pause) * time.Millisecond)
+
+		s.Send(step.Keys)
+
+		if step.Wait != "" {
+			s.WaitFor(regexp.MustCompile(step.Wait))
+		}
+
+		snapshots = append(snapshots, NewSnapshot(s.Settle(settleQuiet)))
+	}
+
+	return snapshots
+}
@@ -0,0 +1,64 @@
+package harness
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Screen   string
+		Expected Snapshot
+	}{
+		{Case: "empty screen", Expected: Snapshot{}},
+		{Case: "trailing spaces", Screen: "E2E:0>   \nok  ", Expected: Snapshot{"E2E:0>", "ok"}},
+		{Case: "trailing empty rows", Screen: "E2E:0>\n   \n\n", Expected: Snapshot{"E2E:0>"}},
+		{Case: "empty rows in between", Screen: "one\n\ntwo\n", Expected: Snapshot{"one", "", "two"}},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.Expected, NewSnapshot(tc.Screen), tc.Case)
+	}
+}
+
+func TestLoadRecording(t *testing.T) {
+	cases := []struct {
+		Case          string
+		Data          string
+		ExpectedError string
+	}{
+		{Case: "valid", Data: `{"overlays": ["rprompt"], "steps": [{"keys": "true\r", "wait": "E2E:0>"}], "screens": {"bash": [["E2E:0>"]]}}`},
+		{Case: "invalid JSON", Data: `{`, ExpectedError: "parsing"},
+		{Case: "no steps", Data: `{"steps": []}`, ExpectedError: "has no steps"},
+		{Case: "unknown overlay", Data: `{"overlays": ["nope"], "steps": [{"keys": "x"}]}`, ExpectedError: `unknown overlay "nope"`},
+		{Case: "invalid wait", Data: `{"steps": [{"keys": "x", "wait": "("}]}`, ExpectedError: "invalid wait expression"},
+		{Case: "screens out of date", Data: `{"steps": [{"keys": "x"}, {"keys": "y"}], "screens": {"bash": [["E2E:0>"]]}}`, ExpectedError: "record it again"},
+	}
+
+	for _, tc := range cases {
+		path := filepath.Join(t.TempDir(), "recording.json")
+		if err := os.WriteFile(path, []byte(tc.Data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := LoadRecording(path)
+		if tc.ExpectedError == "" {
+			assert.NoError(t, err, tc.Case)
+			continue
+		}
+
+		assert.ErrorContains(t, err, tc.ExpectedError, tc.Case)
+	}
+}
+
+func TestStepFor(t *testing.T) {
+	sh := ShellDef{Name: "pwsh", Fail: ExitCommand{Command: `cmd /c "exit 42"`, Code: 42}}
+
+	step := Step{Keys: "{fail}\r", Wait: "(?s){fail}.*E2E:{fail_code}>", Pause: 100}.For(sh)
+
+	assert.Equal(t, Step{Keys: "cmd /c \"exit 42\"\r", Wait: `(?s)cmd /c "exit 42".*E2E:42>`, Pause: 100}, step)
+}
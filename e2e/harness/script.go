@@ -15,6 +15,9 @@ var scriptExtensions = map[string]string{
 	"fish": ".fish",
 	"pwsh": ".ps1",
 	"nu":   ".nu",
+	"osh":  ".sh",
+	"ysh":  ".ysh",
+	"tcsh": ".tcsh",
 }
 
 // InitScript runs `<omp> init <shellName> --config <cfgPath> --print` with OMP_CACHE_DIR
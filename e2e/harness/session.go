@@ -319,10 +319,49 @@ func (s *Session) MarkerColor(marker string) (fg, bg vt10x.Color, found bool) {
 
 // SendLine writes cmd followed by a carriage return to the pty, as if typed interactively.
 func (s *Session) SendLine(cmd string) {
+	s.Send(cmd + "\r")
+}
+
+// Send writes keys to the pty as-is, as if typed interactively. Unlike SendLine it does not
+// accept the command line, so it can also send control characters and escape sequences.
+func (s *Session) Send(keys string) {
 	s.writeMu.Lock()
 	defer s.writeMu.Unlock()
 
-	_, _ = s.pty.Write([]byte(cmd + "\r"))
+	_, _ = s.pty.Write([]byte(keys))
+}
+
+// Settle polls Screen every 50ms until it stays unchanged for quiet, up to a 30s deadline,
+// and returns that screen. Use it after WaitFor when repaints may still follow, e.g. a
+// transient prompt replacing the primary one or a streaming prompt filling in. It fails the
+// test with the last screen if the screen never settles.
+func (s *Session) Settle(quiet time.Duration) string {
+	s.t.Helper()
+
+	deadline := time.Now().Add(waitForDeadline)
+
+	screen := s.Screen()
+	stableSince := time.Now()
+
+	for time.Now().Before(deadline) {
+		time.Sleep(waitForPollInterval)
+
+		current := s.Screen()
+		if current != screen {
+			screen = current
+			stableSince = time.Now()
+
+			continue
+		}
+
+		if time.Since(stableSince) >= quiet {
+			return screen
+		}
+	}
+
+	s.t.Fatalf("screen did not settle for %s\n--- screen ---\n%s", quiet, screen)
+
+	return ""
 }
 
 // ExpectExit sends "exit\r" and waits up to 15s for the process to terminate. It fails the
@@ -55,7 +55,7 @@ func platformExitCommand(windows, unix ExitCommand) ExitCommand {
 	return unix
 }
 
-// Shells is the per-shell definition table for the big five shells the e2e suite targets.
+// Shells is the per-shell definition table for the shells the e2e suite targets.
 var Shells = []ShellDef{
 	{
 		Name:   "bash",
@@ -157,6 +157,51 @@ var Shells = []ShellDef{
 			ExitCommand{Command: "^false", Code: 1},
 		),
 	},
+	{
+		Name:   "osh",
+		Binary: "osh",
+		SyntaxCheck: func(scriptPath string) *exec.Cmd {
+			return exec.Command("osh", "-n", scriptPath)
+		},
+		Launch: func(t *testing.T, scriptPath, workDir string) (string, []string, []string) {
+			rcPath := filepath.Join(workDir, "oshrc")
+			writeLaunchFile(t, rcPath, fmt.Sprintf("source '%s'\n", scriptPath))
+
+			return "osh", []string{"--rcfile", rcPath, "-i"}, os.Environ()
+		},
+		Fail: ExitCommand{Command: "false", Code: 1},
+	},
+	{
+		Name:   "ysh",
+		Binary: "ysh",
+		SyntaxCheck: func(scriptPath string) *exec.Cmd {
+			return exec.Command("ysh", "-n", scriptPath)
+		},
+		Launch: func(t *testing.T, scriptPath, workDir string) (string, []string, []string) {
+			rcPath := filepath.Join(workDir, "yshrc")
+			writeLaunchFile(t, rcPath, fmt.Sprintf("source '%s'\n", scriptPath))
+
+			return "ysh", []string{"--rcfile", rcPath, "-i"}, os.Environ()
+		},
+		Fail: ExitCommand{Command: "false", Code: 1},
+	},
+	{
+		Name:   "tcsh",
+		Binary: "tcsh",
+		SyntaxCheck: func(scriptPath string) *exec.Cmd {
+			return exec.Command("tcsh", "-f", "-n", scriptPath)
+		},
+		Launch: func(t *testing.T, scriptPath, workDir string) (string, []string, []string) {
+			// tcsh has no flag or variable pointing it at another rc file, it only
+			// reads ~/.tcshrc, so the session gets the work directory as its home.
+			writeLaunchFile(t, filepath.Join(workDir, ".tcshrc"), fmt.Sprintf("source '%s'\n", scriptPath))
+
+			env := append(os.Environ(), "HOME="+workDir)
+
+			return "tcsh", []string{"-i"}, env
+		},
+		Fail: ExitCommand{Command: "false", Code: 1},
+	},
 }
 
 // writeLaunchFile writes content to path, creating any missing parent directories, and
@@ -174,13 +219,13 @@ func writeLaunchFile(t *testing.T, path, content string) {
 }
 
 // SupportedOnHost reports whether sh can be launched interactively on the current
-// platform. bash, zsh and fish depend on POSIX rc-file semantics and process/job-control
-// behavior that Windows does not provide faithfully (msys bash and WSL shells under
-// ConPTY are not representative of a real Linux/macOS session), so they are only
-// exercised there. pwsh and nu run natively everywhere the e2e suite targets.
+// platform. bash, zsh, fish, osh, ysh and tcsh depend on POSIX rc-file semantics and
+// process/job-control behavior that Windows does not provide faithfully (msys bash and WSL
+// shells under ConPTY are not representative of a real Linux/macOS session), so they are
+// only exercised there. pwsh and nu run natively everywhere the e2e suite targets.
 func (sh ShellDef) SupportedOnHost() bool {
 	switch sh.Name {
-	case "bash", "zsh", "fish":
+	case "bash", "zsh", "fish", "osh", "ysh", "tcsh":
 		return runtime.GOOS == "linux" || runtime.GOOS == "darwin"
 	default:
 		return true
@@ -0,0 +1,72 @@
+package e2e
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/e2e/harness"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingsGlob matches the recorded sessions TestReplay runs.
+const recordingsGlob = "testdata/recordings/*.json"
+
+// TestReplay replays every recorded session against every harness.Shells entry, as
+// "TestReplay/<recording>/<shell>", and diffs the screen after each step with the one
+// recorded for that shell. A shell without recorded screens skips, even when
+// OMP_E2E_REQUIRE lists it, as it can only be recorded on a host that has it installed.
+//
+// With OMP_E2E_RECORD set, the screens are captured instead and written back to the
+// recording, replacing the ones of every shell that ran.
+func TestReplay(t *testing.T) {
+	paths, err := filepath.Glob(recordingsGlob)
+	require.NoError(t, err)
+	require.NotEmpty(t, paths, "no recordings match %s", recordingsGlob)
+
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+		t.Run(name, func(t *testing.T) {
+			rec, err := harness.LoadRecording(path)
+			require.NoError(t, err)
+
+			for _, sh := range harness.Shells {
+				t.Run(sh.Name, func(t *testing.T) {
+					if reason, skip := rec.Skips[sh.Name]; skip {
+						t.Skip(reason)
+					}
+
+					key := harness.ScreensKey(sh)
+
+					expected, recorded := rec.Screens[key]
+					if !recorded && !harness.RecordMode() {
+						t.Skipf("no screens recorded for %s, run with OMP_E2E_RECORD=1 to record them", key)
+					}
+
+					got := rec.Replay(t, sh)
+
+					if harness.RecordMode() {
+						if rec.Screens == nil {
+							rec.Screens = make(map[string][]harness.Snapshot)
+						}
+
+						rec.Screens[key] = got
+
+						return
+					}
+
+					for i, snapshot := range got {
+						assert.Equal(t, expected[i].String(), snapshot.String(),
+							"%s: screen after step %d (%q) differs from the recording", sh.Name, i, rec.Steps[i].Keys)
+					}
+				})
+			}
+
+			if harness.RecordMode() {
+				require.NoError(t, rec.Save(path))
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Package animation turns a streaming prompt into a timeline of frames for an
+// animated export: the prompt as StreamPrimary draws it, pending segments
+// resolving one update at a time, then a command typed at the cursor and the
+// prompt collapsing into the transient prompt once it's accepted. The frames
+// carry both the captured Run stream, for svg.EncodeAnimated, and the ANSI a
+// terminal is sent to reach each one, for an asciinema cast (see Cast).
+package animation
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+)
+
+const (
+	// typingStart is how long the resolved prompt shows before the command
+	// starts being typed.
+	typingStart = 800 * time.Millisecond
+	// keystroke is the delay between two typed characters.
+	keystroke = 90 * time.Millisecond
+	// enter is how long the typed command shows before it's accepted.
+	enter = 600 * time.Millisecond
+)
+
+// Frame is one state of the screen.
+type Frame struct {
+	svg.Frame
+	// Output is what a terminal showing the previous frame is sent to show this one.
+	Output string
+}
+
+// Record renders eng's primary prompt through StreamPrimary and returns its
+// frames, followed by command typed at the cursor and accepted. eng must have
+// been built with terminal.CaptureRuns and runtime.Flags.Streaming set (see
+// render.Engine). When command is empty, the frames end with the resolved
+// prompt.
+//
+// The stream's frames keep the times they were actually rendered at, so a
+// segment that takes 400ms to resolve also takes 400ms in the animation.
+func Record(eng *prompt.Engine, command string) []Frame {
+	start := time.Now()
+
+	var (
+		records   []string
+		times     []time.Duration
+		transient string
+	)
+
+	for record := range eng.StreamPrimary() {
+		if text, ok := strings.CutPrefix(record, prompt.TransientMarker); ok {
+			transient = text
+			continue
+		}
+
+		records = append(records, record)
+		times = append(times, time.Since(start))
+	}
+
+	captured := eng.CapturedFrames()
+	count := min(len(records), len(captured))
+	frames := make([]Frame, 0, count+len(command)+1)
+	previous := ""
+
+	for i := range count {
+		frames = append(frames, Frame{
+			Frame: svg.Frame{
+				Rows:   captured[i].Rows,
+				Cursor: cursor(&captured[i]),
+				At:     times[i],
+			},
+			Output: redraw(previous) + records[i],
+		})
+
+		previous = records[i]
+	}
+
+	if len(frames) == 0 || len(command) == 0 {
+		return frames
+	}
+
+	last := frames[len(frames)-1].Frame
+	at := last.At + typingStart
+	typed := ""
+
+	for _, r := range command {
+		typed += string(r)
+		rows, cur := typeAt(last.Rows, last.Cursor, typed)
+
+		frames = append(frames, Frame{
+			Frame:  svg.Frame{Rows: rows, Cursor: cur, At: at},
+			Output: string(r),
+		})
+
+		at += keystroke
+	}
+
+	frames = append(frames, accept(eng, &last, previous, transient, command, at+enter))
+
+	return frames
+}
+
+// accept is the frame after command is accepted: the prompt it was typed at,
+// collapsed into the transient prompt when there's one, and a fresh prompt
+// below it.
+func accept(eng *prompt.Engine, last *svg.Frame, previous, transient, command string, at time.Duration) Frame {
+	var (
+		accepted [][]terminal.Run
+		output   string
+	)
+
+	if eng.Config.TransientPrompt != nil {
+		runs := append(trimNewlines(eng.CapturedTransientRuns()), typedRun(command))
+		accepted = [][]terminal.Run{runs}
+		output = redraw(previous) + transient + command
+	} else {
+		accepted, _ = typeAt(last.Rows, last.Cursor, command)
+	}
+
+	rows := append(accepted, last.Rows...)
+
+	var cur *svg.Cursor
+	if last.Cursor != nil {
+		cur = &svg.Cursor{Row: last.Cursor.Row + len(accepted), Run: last.Cursor.Run}
+	}
+
+	return Frame{
+		Frame:  svg.Frame{Rows: rows, Cursor: cur, At: at},
+		Output: output + "\n" + previous,
+	}
+}
+
+func cursor(frame *prompt.CapturedFrame) *svg.Cursor {
+	if !frame.Cursor {
+		return nil
+	}
+
+	return &svg.Cursor{Row: frame.CursorRow, Run: frame.CursorRun}
+}
+
+// redraw moves from the end of the previous prompt back to where it started
+// and clears it, so the next one is drawn in its place.
+func redraw(previous string) string {
+	if len(previous) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString("\r")
+
+	if lines := strings.Count(previous, "\n"); lines > 0 {
+		b.WriteString("\x1b[")
+		b.WriteString(strconv.Itoa(lines))
+		b.WriteString("A")
+	}
+
+	b.WriteString("\x1b[J")
+
+	return b.String()
+}
+
+// typeAt returns rows with text typed at the cursor, and the cursor after it.
+// Text typed in front of an alignment gap takes its cells out of that gap,
+// the same way svg's own cursor does, so a right-aligned block stays put.
+func typeAt(rows [][]terminal.Run, cur *svg.Cursor, text string) ([][]terminal.Run, *svg.Cursor) {
+	if len(rows) == 0 {
+		rows = [][]terminal.Run{{}}
+	}
+
+	row, at := len(rows)-1, len(rows[len(rows)-1])
+	if cur != nil && cur.Row >= 0 && cur.Row < len(rows) && cur.Run >= 0 && cur.Run <= len(rows[cur.Row]) {
+		row, at = cur.Row, cur.Run
+	}
+
+	typed := typedRun(text)
+
+	line := make([]terminal.Run, 0, len(rows[row])+1)
+	line = append(line, rows[row][:at]...)
+	line = append(line, typed)
+
+	tail := rows[row][at:]
+	if len(tail) > 0 && isGap(&tail[0]) && tail[0].Cells > typed.Cells {
+		gap := tail[0]
+		gap.Text = gap.Text[typed.Cells:]
+		gap.Cells -= typed.Cells
+		line = append(line, gap)
+		tail = tail[1:]
+	}
+
+	line = append(line, tail...)
+
+	out := append([][]terminal.Run{}, rows...)
+	out[row] = line
+
+	return out, &svg.Cursor{Row: row, Run: at + 1}
+}
+
+// typedRun is a command line as the shell echoes it, in the default colors.
+func typedRun(text string) terminal.Run {
+	return terminal.Run{
+		Text:             text,
+		Cells:            terminal.VisibleCells(text),
+		ForegroundSource: color.Ansi("default"),
+		BackgroundSource: color.Transparent,
+	}
+}
+
+// isGap reports whether run is blank alignment padding the engine inserted,
+// spaces only and no colors.
+func isGap(run *terminal.Run) bool {
+	return run.ForegroundSource.IsEmpty() && run.BackgroundSource.IsEmpty() &&
+		len(run.Text) == run.Cells && strings.Trim(run.Text, " ") == ""
+}
+
+// trimNewlines drops the newline a transient prompt can start with: the
+// accepted row is its own row already.
+func trimNewlines(runs []terminal.Run) []terminal.Run {
+	out := make([]terminal.Run, 0, len(runs))
+
+	for _, run := range runs {
+		run.Text = strings.ReplaceAll(run.Text, "\n", "")
+		if len(run.Text) == 0 && run.Cells == 0 {
+			continue
+		}
+
+		out = append(out, run)
+	}
+
+	return out
+}
@@ -0,0 +1,107 @@
+package animation
+
+import (
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedraw(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Previous string
+		Expected string
+	}{
+		{Case: "first frame", Previous: "", Expected: ""},
+		{Case: "single line", Previous: "~ > ", Expected: "\r\x1b[J"},
+		{Case: "two lines", Previous: "~\n> ", Expected: "\r\x1b[1A\x1b[J"},
+		{Case: "three lines", Previous: "\n~\n> ", Expected: "\r\x1b[2A\x1b[J"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, redraw(tc.Previous))
+		})
+	}
+}
+
+func TestTypeAt(t *testing.T) {
+	prompt := terminal.Run{Text: "~ ", Cells: 2, ForegroundSource: "blue"}
+	gap := terminal.Run{Text: "      ", Cells: 6}
+	right := terminal.Run{Text: "12:00", Cells: 5, ForegroundSource: "red"}
+
+	cases := []struct {
+		Case           string
+		Rows           [][]terminal.Run
+		Cursor         *svg.Cursor
+		Text           string
+		ExpectedRow    []string
+		ExpectedCursor svg.Cursor
+	}{
+		{
+			Case:           "no cursor types at the end",
+			Rows:           [][]terminal.Run{{prompt}},
+			Text:           "ls",
+			ExpectedRow:    []string{"~ ", "ls"},
+			ExpectedCursor: svg.Cursor{Row: 0, Run: 1},
+		},
+		{
+			Case:           "typing shrinks the gap",
+			Rows:           [][]terminal.Run{{prompt, gap, right}},
+			Cursor:         &svg.Cursor{Row: 0, Run: 1},
+			Text:           "ls",
+			ExpectedRow:    []string{"~ ", "ls", "    ", "12:00"},
+			ExpectedCursor: svg.Cursor{Row: 0, Run: 1},
+		},
+		{
+			Case:           "text wider than the gap pushes it",
+			Rows:           [][]terminal.Run{{prompt, gap, right}},
+			Cursor:         &svg.Cursor{Row: 0, Run: 1},
+			Text:           "git status",
+			ExpectedRow:    []string{"~ ", "git status", "      ", "12:00"},
+			ExpectedCursor: svg.Cursor{Row: 0, Run: 1},
+		},
+		{
+			Case:           "cursor on the first of two rows",
+			Rows:           [][]terminal.Run{{prompt}, {right}},
+			Cursor:         &svg.Cursor{Row: 0, Run: 1},
+			Text:           "ls",
+			ExpectedRow:    []string{"~ ", "ls"},
+			ExpectedCursor: svg.Cursor{Row: 0, Run: 1},
+		},
+		{
+			Case:           "no rows",
+			Text:           "ls",
+			ExpectedRow:    []string{"ls"},
+			ExpectedCursor: svg.Cursor{Row: 0, Run: 0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			rows, cur := typeAt(tc.Rows, tc.Cursor, tc.Text)
+
+			var texts []string
+			for _, run := range rows[tc.ExpectedCursor.Row] {
+				texts = append(texts, run.Text)
+			}
+
+			assert.Equal(t, tc.ExpectedRow, texts)
+			assert.Equal(t, tc.ExpectedCursor.Row, cur.Row)
+			// the cursor sits right after the typed run
+			assert.Equal(t, tc.ExpectedCursor.Run+1, cur.Run)
+		})
+	}
+}
+
+func TestTypeAtLeavesRowsUntouched(t *testing.T) {
+	rows := [][]terminal.Run{{{Text: "~ ", Cells: 2}, {Text: "    ", Cells: 4}}}
+
+	_, _ = typeAt(rows, &svg.Cursor{Row: 0, Run: 1}, "ls")
+
+	assert.Len(t, rows[0], 2)
+	assert.Equal(t, "    ", rows[0][1].Text)
+}
@@ -0,0 +1,60 @@
+package animation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// castHeader is the first line of an asciinema v2 cast, see
+// https://docs.asciinema.org/manual/asciicast/v2/.
+type castHeader struct {
+	Env     map[string]string `json:"env"`
+	Version int               `json:"version"`
+	Width   int               `json:"width"`
+	Height  int               `json:"height"`
+}
+
+// Cast writes frames as an asciinema v2 cast for a terminal of width columns,
+// one output event per frame at the frame's time. The prompt's newlines are
+// sent as CR LF, which is what a terminal's line discipline would have made of
+// them.
+func Cast(frames []Frame, width int) (string, error) {
+	height := 1
+	for i := range frames {
+		height = max(height, len(frames[i].Rows))
+	}
+
+	var buf bytes.Buffer
+
+	// keep the prompt characters such as > readable instead of \u003e
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	header := castHeader{
+		Version: 2,
+		Width:   width,
+		Height:  height,
+		Env:     map[string]string{"TERM": "xterm-256color"},
+	}
+
+	if err := encoder.Encode(header); err != nil {
+		return "", err
+	}
+
+	for i := range frames {
+		event := []any{seconds(frames[i].At), "o", crlf.Replace(frames[i].Output)}
+		if err := encoder.Encode(event); err != nil {
+			return "", err
+		}
+	}
+
+	return buf.String(), nil
+}
+
+var crlf = strings.NewReplacer("\r\n", "\r\n", "\n", "\r\n")
+
+func seconds(d time.Duration) float64 {
+	return float64(d.Milliseconds()) / 1000
+}
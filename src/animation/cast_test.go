@@ -0,0 +1,45 @@
+package animation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCast(t *testing.T) {
+	row := []terminal.Run{{Text: "~", Cells: 1}}
+
+	cases := []struct {
+		Case     string
+		Frames   []Frame
+		Expected string
+	}{
+		{
+			Case:     "no frames",
+			Expected: `{"env":{"TERM":"xterm-256color"},"version":2,"width":80,"height":1}` + "\n",
+		},
+		{
+			Case: "height from the tallest frame, newlines as CR LF",
+			Frames: []Frame{
+				{Frame: svg.Frame{Rows: [][]terminal.Run{row}}, Output: "~ > "},
+				{Frame: svg.Frame{Rows: [][]terminal.Run{row, row}, At: 1500 * time.Millisecond}, Output: "\r\x1b[J~\n> "},
+			},
+			Expected: `{"env":{"TERM":"xterm-256color"},"version":2,"width":80,"height":2}` + "\n" +
+				`[0,"o","~ > "]` + "\n" +
+				`[1.5,"o","\r\u001b[J~\r\n> "]` + "\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			got, err := Cast(tc.Frames, 80)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, got)
+		})
+	}
+}
@@ -26,13 +26,14 @@ const (
 )
 
 const (
-	TEMPLATECACHE    = "template_cache"
-	TOGGLECACHE      = "toggle_cache"
-	PROMPTCOUNTCACHE = "prompt_count_cache"
-	ENGINECACHE      = "engine_cache"
-	FONTLISTCACHE    = "font_list_cache"
-	CLAUDECACHE      = "claude_cache"
-	COPILOTCLICACHE  = "copilot_cli_cache"
+	TEMPLATECACHE           = "template_cache"
+	TOGGLECACHE             = "toggle_cache"
+	PROMPTCOUNTCACHE        = "prompt_count_cache"
+	ENGINECACHE             = "engine_cache"
+	FONTLISTCACHE           = "font_list_cache"
+	CLAUDECACHE             = "claude_cache"
+	COPILOTCLICACHE         = "copilot_cli_cache"
+	TERMINALBACKGROUNDCACHE = "terminal_background_cache"
 )
 
 type Entry[T any] struct {
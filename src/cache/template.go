@@ -22,14 +22,20 @@ type SimpleTemplate struct {
 	AbsolutePWD   string
 	OS            string
 	Version       string
-	PromptCount   int
-	SHLVL         int
-	Jobs          int
-	Code          int
-	WSL           bool
-	Root          bool
-	Interrupted   bool
-	Executed      bool
+	// TerminalBackground is the terminal's background color as #rrggbb,
+	// empty when the terminal didn't tell.
+	TerminalBackground string
+	PromptCount        int
+	SHLVL              int
+	Jobs               int
+	Code               int
+	WSL                bool
+	Root               bool
+	Interrupted        bool
+	Executed           bool
+	// IsDarkBackground derives from TerminalBackground, and is true when
+	// that is unknown: most terminals are dark, and so are most themes.
+	IsDarkBackground bool
 }
 
 func (t *Template) AddSegmentData(key string, value any) {
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"os"
+	"slices"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/cli/font"
+	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+)
+
+// completeThemes completes --config with the bundled theme names. A path
+// matches none of them, which leaves it to the shell's file completion.
+func completeThemes(_ *cmdtree.Command, _ []string, _ string) []string {
+	return config.Themes()
+}
+
+// completeSegments completes toggle with the segments of the session's
+// config, by alias when they have one, skipping those already on the line.
+func completeSegments(_ *cmdtree.Command, args []string, _ string) []string {
+	if configFlag == "" {
+		configFlag = os.Getenv("POSH_CONFIG")
+	}
+
+	cache.Init(os.Getenv("POSH_SHELL"))
+
+	defer cache.Close()
+
+	cfg := config.Get(configFlag, false)
+
+	var segments []string
+
+	for _, block := range cfg.Blocks {
+		for _, segment := range block.Segments {
+			key := segment.DataKey()
+			if slices.Contains(args, key) || slices.Contains(segments, key) {
+				continue
+			}
+
+			segments = append(segments, key)
+		}
+	}
+
+	return segments
+}
+
+// completeFonts completes font install with the Nerd Fonts from font list, see font.Suggest.
+func completeFonts(_ *cmdtree.Command, args []string, _ string) []string {
+	if len(args) != 0 {
+		return nil
+	}
+
+	cache.Init(os.Getenv("POSH_SHELL"), cache.Persist)
+
+	defer cache.Close()
+
+	fonts, err := font.Suggest()
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(fonts))
+	for _, f := range fonts {
+		names = append(names, f.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"os"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/animation"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
+	"github.com/jandedobbeleer/oh-my-posh/src/render"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
+)
+
+const (
+	// animationStreaming is the streaming timeout, in milliseconds, an animation renders a config
+	// that doesn't set one with: without it, every segment renders in the first frame and there's
+	// nothing to animate.
+	animationStreaming = 100
+
+	// animationHold is how long an animated svg shows its last frame before it loops.
+	animationHold = 3 * time.Second
+)
+
+// exportAnimatedSVG records eng's stream (see animation.Record) and writes it as an animated SVG,
+// laid out with the same options a static export uses.
+//
+//nolint:gocritic
+func exportAnimatedSVG(eng *prompt.Engine, cfg *config.Config, output string, opts svg.Options, command string) error {
+	frames := animation.Record(eng, command)
+
+	svgFrames := make([]svg.Frame, 0, len(frames))
+	for i := range frames {
+		svgFrames = append(svgFrames, frames[i].Frame)
+	}
+
+	doc := svg.EncodeAnimated(svgFrames, opts, animationHold)
+
+	path := imageOutputPath(cfg.Source, output, ".svg")
+
+	return os.WriteFile(path, []byte(doc), 0o644) //nolint:gosec
+}
+
+// exportSessionSVG exports the recorded session in --data as an animated SVG, every frame rendered
+// from its own snapshot and shown at the time it was recorded. It reports false, without writing
+// anything, when --data isn't a recorded session.
+func exportSessionSVG(cmd *cmdtree.Command, cfg *config.Config, output, fontFamily string, columns int, backgroundColor string) (bool, error) {
+	data, err := loadDataFile()
+	if err != nil || data == nil || len(data.Frames) == 0 {
+		return false, err
+	}
+
+	engines, err := render.Timeline(cfg, columns, data, func(flags *runtime.Flags, frame *config.Data) error {
+		return applyDataFrame(flags, frame, cmd.Flags().Changed)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	timeline := data.Timeline()
+	frames := make([]svg.Frame, 0, len(engines))
+
+	for i, eng := range engines {
+		frame := svg.Frame{Rows: eng.CapturedRuns(), At: timeline[i].At}
+
+		if row, run, ok := eng.CursorAnchor(); ok {
+			frame.Cursor = &svg.Cursor{Row: row, Run: run}
+		}
+
+		frames = append(frames, frame)
+	}
+
+	metrics := render.FontMetrics{
+		CellWidth:   svgCellWidth,
+		LineHeight:  svgLineHeight,
+		FillAscent:  svgFillAscent,
+		FillDescent: svgFillDescent,
+	}
+
+	// the colors resolve from the terminal state the last frame left behind, the same way a
+	// static export resolves them after its one render
+	doc := svg.EncodeAnimated(frames, imageOptions(fontFamily, columns, metrics, backgroundColor), animationHold)
+
+	path := imageOutputPath(cfg.Source, output, ".svg")
+
+	return true, os.WriteFile(path, []byte(doc), 0o644) //nolint:gosec
+}
+
+// exportCast records eng's stream and writes it as an asciinema v2 cast, columns wide.
+func exportCast(eng *prompt.Engine, cfg *config.Config, output string, columns int, command string) error {
+	cast, err := animation.Cast(animation.Record(eng, command), columns)
+	if err != nil {
+		return err
+	}
+
+	path := imageOutputPath(cfg.Source, output, ".cast")
+
+	return os.WriteFile(path, []byte(cast), 0o644) //nolint:gosec
+}
@@ -2,14 +2,19 @@ package cli
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cache"
 	"github.com/jandedobbeleer/oh-my-posh/src/config"
 	"github.com/jandedobbeleer/oh-my-posh/src/render"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 	"github.com/jandedobbeleer/oh-my-posh/src/template"
+	"github.com/jandedobbeleer/oh-my-posh/src/text"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
 )
@@ -18,6 +23,8 @@ var (
 	outputData string
 	sanitize   bool
 	themesDir  string
+	appendData bool
+	redact     string
 )
 
 var dataCmd = &cmdtree.Command{
@@ -40,6 +47,20 @@ Exports the recorded data to ~/myconfig.data.json.
 
 Prints the recorded data to stdout.
 
+> oh-my-posh config export data --redact default --output ~/bug.data.json
+
+Records the data with every user name, host name, path, git remote and cloud
+account replaced by a pseudonym, so it can be attached to a bug report. The
+same value always gets the same pseudonym, the replayed prompt still looks
+like a real one. Pass strict for more, or a profile file of your own.
+
+> oh-my-posh config export data --append --output ~/session.data.json --status $?
+
+Adds what the prompt looks like right now as the next frame of the recorded
+session in ~/session.data.json. Run it after every step of a bug report, such
+as cd-ing into a repository and running the failing command, then replay the
+whole session with print --data.
+
 > oh-my-posh config export data --sanitize --themes ../themes --output prompt/testdata/fixtures/prompt.data.json
 
 Records every theme found in ../themes against the real environment, merges
@@ -51,8 +72,18 @@ src/prompt/testdata/fixtures - run from src/.`,
 	Run: func(cmd *cmdtree.Command, _ []string) {
 		cache.Init(os.Getenv("POSH_SHELL"))
 
+		if sanitize && redact != "" {
+			exitcode = 666
+			fmt.Println("--sanitize is short for --redact sanitize, pass only one of them")
+			return
+		}
+
+		if sanitize {
+			redact = sanitizeProfileName
+		}
+
 		if themesDir != "" {
-			if !sanitize {
+			if redact != sanitizeProfileName {
 				exitcode = 666
 				fmt.Println("--themes requires --sanitize: a merged multi-theme fixture is only meant to be committed sanitized")
 				return
@@ -83,6 +114,7 @@ src/prompt/testdata/fixtures - run from src/.`,
 		// real environment and populates both the template cache and each segment's
 		// writer, which is what we record below.
 		if _, err := render.Config(cfg, 120, false, func(flags *runtime.Flags) error {
+			flags.ErrorCode = status
 			return applyDataFile(flags, cmd.Flags().Changed)
 		}); err != nil {
 			exitcode = 666
@@ -102,8 +134,30 @@ src/prompt/testdata/fixtures - run from src/.`,
 			return
 		}
 
-		if sanitize {
-			doc, err = sanitizeDataDocument(doc, cfg)
+		if redact != "" {
+			profile, err := loadRedactProfile(redact)
+			if err != nil {
+				exitcode = 666
+				fmt.Println(err.Error())
+				return
+			}
+
+			doc, err = redactDataDocument(doc, cfg, profile)
+			if err != nil {
+				exitcode = 666
+				fmt.Println(err.Error())
+				return
+			}
+		}
+
+		if appendData {
+			if outputData == "" {
+				exitcode = 666
+				fmt.Println("--append requires --output: the recorded session to add a frame to")
+				return
+			}
+
+			doc, err = appendDataFrame(cleanOutputPath(outputData), doc, time.Now())
 			if err != nil {
 				exitcode = 666
 				fmt.Println(err.Error())
@@ -129,6 +183,64 @@ func writeDataOutput(doc []byte) {
 	}
 }
 
+// appendDataFrame adds doc, a single snapshot, as the next frame of the recorded session in path and
+// returns the whole session. A frame's at is its distance from the session's started time, in
+// milliseconds; a path that doesn't exist yet starts a new session now.
+func appendDataFrame(path string, doc []byte, now time.Time) ([]byte, error) {
+	var frame map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &frame); err != nil {
+		return nil, fmt.Errorf("failed to parse recorded frame: %w", err)
+	}
+
+	// the session carries the version, every frame was recorded by the same recorder
+	delete(frame, config.DataVersionKey)
+
+	session := map[string]json.RawMessage{}
+
+	existing, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		session[config.DataVersionKey] = json.RawMessage(strconv.Itoa(config.DataVersion))
+		session[config.DataStartedKey], _ = json.Marshal(now.UTC().Format(time.RFC3339Nano))
+		session[config.DataFramesKey] = json.RawMessage("[]")
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal([]byte(text.StripJSONComments(string(existing))), &session); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+
+	var started string
+	if err := json.Unmarshal(session[config.DataStartedKey], &started); err != nil {
+		return nil, fmt.Errorf("%s is not a recorded session, it has no %s time", path, config.DataStartedKey)
+	}
+
+	start, err := time.Parse(time.RFC3339Nano, started)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s in %s: %w", config.DataStartedKey, path, err)
+	}
+
+	var frames []json.RawMessage
+	if err := json.Unmarshal(session[config.DataFramesKey], &frames); err != nil {
+		return nil, fmt.Errorf("failed to parse %s in %s: %w", config.DataFramesKey, path, err)
+	}
+
+	frame[config.DataAtKey] = json.RawMessage(strconv.FormatInt(now.Sub(start).Milliseconds(), 10))
+
+	raw, err := json.Marshal(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	session[config.DataFramesKey], err = json.Marshal(append(frames, raw))
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(session, "", "  ")
+}
+
 // Extracted from dataCmd's Run so it can be unit tested without a real environment.
 func buildDataDocument(cfg *config.Config) ([]byte, error) {
 	envRaw, err := json.Marshal(template.Cache.SimpleTemplate)
@@ -189,12 +301,17 @@ func buildDataDocument(cfg *config.Config) ([]byte, error) {
 func init() {
 	dataCmd.Flags().StringVarP(&outputData, "output", "o", "", "data file to export to")
 	dataCmd.Flags().BoolVar(&sanitize, "sanitize", false,
-		"scrub identity (username, hostname, paths, git/cloud identity, sysinfo, battery) from the recorded data, for sharing or committing fixtures")
+		"scrub identity (username, hostname, paths, git/cloud identity, sysinfo, battery) from the recorded data, for sharing or committing fixtures (short for --redact sanitize)")
 	// The same flag the image and print commands carry, for the same reason plus one: a fixture
 	// recorded once can be re-recorded through the writers to pick up whatever the data format
 	// has since gained - method results, say - without giving up the values it was curated with.
 	dataCmd.Flags().StringVar(&dataPath, "data", "",
 		"path to a template data file to seed the recording with, instead of the live environment")
+	dataCmd.Flags().StringVar(&redact, "redact", "",
+		"replace identity with consistent pseudonyms: a built-in profile (default, strict, sanitize) or a profile file, for attaching the data to a bug report")
+	dataCmd.Flags().BoolVar(&appendData, "append", false,
+		"add the recording to --output as the next frame of a recorded session, starting the session when --output doesn't exist yet")
+	dataCmd.Flags().IntVar(&status, "status", 0, "last known status code, to record a failing command")
 	dataCmd.Flags().StringVar(&themesDir, "themes", "",
 		"record every theme in this directory and merge them into one sanitized fixture, ignoring --config (requires --sanitize)")
 
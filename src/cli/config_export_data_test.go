@@ -3,7 +3,9 @@ package cli
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cache"
 	"github.com/jandedobbeleer/oh-my-posh/src/config"
@@ -172,3 +174,38 @@ func TestDataCmd_Flags(t *testing.T) {
 	require.NotNil(t, flag)
 	assert.Equal(t, "o", flag.Shorthand)
 }
+
+func TestAppendDataFrame(t *testing.T) {
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	record := func(pwd string, at time.Time) {
+		doc := `{"version": 1, "env": {"PWD": "` + pwd + `"}, "segments": {}}`
+
+		session, err := appendDataFrame(path, []byte(doc), at)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, session, 0o644))
+	}
+
+	record("~", started)
+	record("~/repo", started.Add(1500*time.Millisecond))
+
+	data, err := config.LoadData(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, config.DataVersion, data.Version)
+	require.Len(t, data.Frames, 2)
+
+	assert.Equal(t, time.Duration(0), data.Frames[0].At)
+	assert.Equal(t, 1500*time.Millisecond, data.Frames[1].At)
+	assert.JSONEq(t, `{"PWD": "~/repo"}`, string(data.Frames[1].Env))
+	assert.Equal(t, config.DataVersion, data.Frames[1].Version, "a frame carries the session's version")
+}
+
+func TestAppendDataFrameToSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 1, "env": {}, "segments": {}}`), 0o644))
+
+	_, err := appendDataFrame(path, []byte(`{"version": 1, "env": {}}`), time.Now())
+	assert.ErrorContains(t, err, "not a recorded session")
+}
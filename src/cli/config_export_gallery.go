@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/render"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	omptemplate "github.com/jandedobbeleer/oh-my-posh/src/template"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
+)
+
+var (
+	galleryOutput  string
+	galleryThemes  string
+	galleryColumns int
+)
+
+var galleryCmd = &cmdtree.Command{
+	Use:   "gallery",
+	Short: "Export an HTML gallery of a directory of themes",
+	Long: `Export an HTML gallery of a directory of themes.
+
+Renders every theme in --themes to an SVG, the same one config export image
+writes, and an index.html that shows them all. Pass a recorded data file with
+--data so every theme renders against the same, reproducible state instead of
+this machine's: together with --data-only, the gallery doesn't depend on the
+machine it's built on at all.
+
+Example usage:
+
+> oh-my-posh config export gallery --themes ~/themes --data ~/prompt.data.json --out ~/gallery
+
+Writes ~/gallery/index.html and one SVG per theme found in ~/themes.`,
+	Args: cmdtree.NoArgs,
+	Run: func(cmd *cmdtree.Command, _ []string) {
+		if galleryThemes == "" {
+			// usage error
+			fmt.Println("--themes is required: the directory of themes to render")
+			exitcode = 2
+			return
+		}
+
+		cache.Init(os.Getenv("POSH_SHELL"))
+
+		defer func() {
+			omptemplate.SaveCache()
+			cache.Close()
+		}()
+
+		opts := galleryOptions{
+			themesDir:  galleryThemes,
+			outDir:     galleryOutput,
+			columns:    galleryColumns,
+			fontFamily: svgFontFamily,
+			changed:    cmd.Flags().Changed,
+		}
+
+		entries, err := exportGallery(&opts)
+		if err != nil {
+			exitcode = 666
+			fmt.Println(err.Error())
+			return
+		}
+
+		fmt.Printf("exported %d themes to %s\n", len(entries), filepath.Join(galleryOutput, galleryIndex))
+	},
+}
+
+func init() {
+	galleryCmd.Flags().StringVar(&galleryThemes, "themes", "", "directory of themes to render")
+	galleryCmd.Flags().StringVar(&galleryOutput, "out", "gallery", "directory to write index.html and the theme SVGs to")
+	galleryCmd.Flags().StringVar(&dataPath, "data", "", "path to a template data file (json/yaml/toml) to render every theme with")
+	galleryCmd.Flags().BoolVar(&dataOnly, "data-only", false, "render segments exclusively from --data, a segment the file does not cover renders as absent")
+	galleryCmd.Flags().IntVar(&galleryColumns, "terminal-width", 120, "number of columns to render every prompt and image at")
+	galleryCmd.Flags().StringVar(&svgFontFamily, "font-family", "", "CSS font-family for the exported svgs")
+
+	exportCmd.AddCommand(galleryCmd)
+}
+
+// galleryIndex is the gallery's entry page, next to the theme SVGs.
+const galleryIndex = "index.html"
+
+type galleryOptions struct {
+	changed    func(name string) bool
+	themesDir  string
+	outDir     string
+	fontFamily string
+	columns    int
+}
+
+// galleryEntry is one theme on the index page.
+type galleryEntry struct {
+	Name  string
+	Image string
+}
+
+// exportGallery renders every theme in opts.themesDir to its own SVG in opts.outDir and writes
+// the index page linking them. A theme that doesn't parse is reported and left out rather than
+// failing the whole gallery; the gallery only fails when none of them render.
+func exportGallery(opts *galleryOptions) ([]galleryEntry, error) {
+	themePaths, err := config.ThemeFiles(opts.themesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(themePaths) == 0 {
+		return nil, fmt.Errorf("no theme files found in %s", opts.themesDir)
+	}
+
+	outDir := cleanOutputPath(opts.outDir)
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	// every theme's render below reads its Run stream back via eng.CapturedRuns()
+	terminal.CaptureRuns = true
+
+	entries := make([]galleryEntry, 0, len(themePaths))
+
+	for _, themePath := range themePaths {
+		doc, err := renderGalleryTheme(themePath, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: skipping %s: %s\n", themePath, err)
+			continue
+		}
+
+		image := imageOutputPath(themePath, "", ".svg")
+
+		if err := os.WriteFile(filepath.Join(outDir, image), []byte(doc), 0o644); err != nil { //nolint:gosec
+			return nil, err
+		}
+
+		entries = append(entries, galleryEntry{
+			Name:  strings.TrimSuffix(image, ".svg"),
+			Image: image,
+		})
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("none of the themes in %s could be rendered", opts.themesDir)
+	}
+
+	index, err := os.Create(filepath.Join(outDir, galleryIndex))
+	if err != nil {
+		return nil, err
+	}
+
+	defer index.Close()
+
+	if err := galleryTemplate.Execute(index, entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// renderGalleryTheme renders one theme the way config export image renders it, with a fresh
+// template cache so one theme's Var/Maps never leak into the next.
+func renderGalleryTheme(themePath string, opts *galleryOptions) (string, error) {
+	cfg, err := config.Parse(themePath)
+	if err != nil {
+		return "", err
+	}
+
+	eng, err := render.Config(cfg, opts.columns, true, func(flags *runtime.Flags) error {
+		return applyDataFile(flags, opts.changed)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	svgOpts := imageOptions(opts.fontFamily, opts.columns, render.FontMetrics{}, "")
+
+	return render.SVG(eng, svgOpts), nil
+}
+
+// galleryTemplate lays the themes out as a grid of cards, each image linking to the full size
+// SVG. Everything it needs is inline, so the gallery works straight from disk.
+var galleryTemplate = template.Must(template.New(galleryIndex).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Themes</title>
+<style>
+body { margin: 0; padding: 2rem; background: #1e1e1e; color: #e0e0e0; font-family: system-ui, sans-serif; }
+main { display: grid; grid-template-columns: repeat(auto-fill, minmax(36rem, 1fr)); gap: 2rem; }
+figure { margin: 0; }
+figcaption { margin-top: 0.5rem; font-family: ui-monospace, monospace; }
+img { width: 100%; height: auto; }
+</style>
+</head>
+<body>
+<h1>Themes</h1>
+<main>
+{{- range . }}
+<figure id="{{ .Name }}">
+<a href="{{ .Image }}"><img src="{{ .Image }}" alt="{{ .Name }}" loading="lazy"></a>
+<figcaption>{{ .Name }}</figcaption>
+</figure>
+{{- end }}
+</main>
+</body>
+</html>
+`))
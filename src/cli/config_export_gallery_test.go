@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportGallery(t *testing.T) {
+	theme := func(text string) string {
+		return `{"version": 3, "blocks": [{"type": "prompt", "alignment": "left", "segments": [{"type": "text", "style": "plain", "template": "` + text + `"}]}]}`
+	}
+
+	cases := []struct {
+		Case          string
+		Themes        map[string]string
+		Expected      []string
+		ExpectedError bool
+	}{
+		{
+			Case: "every theme",
+			Themes: map[string]string{
+				"beta.omp.json":  theme("beta"),
+				"alpha.omp.json": theme("alpha"),
+				"notes.txt":      "not a theme",
+			},
+			Expected: []string{"alpha", "beta"},
+		},
+		{
+			Case: "broken theme is skipped",
+			Themes: map[string]string{
+				"alpha.omp.json":  theme("alpha"),
+				"broken.omp.json": `{"version": 3, "blocks": [`,
+			},
+			Expected: []string{"alpha"},
+		},
+		{
+			Case:          "no themes",
+			Themes:        map[string]string{"notes.txt": "not a theme"},
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			themesDir := t.TempDir()
+			outDir := filepath.Join(t.TempDir(), "gallery")
+
+			for name, content := range tc.Themes {
+				require.NoError(t, os.WriteFile(filepath.Join(themesDir, name), []byte(content), 0o644))
+			}
+
+			entries, err := exportGallery(&galleryOptions{
+				themesDir: themesDir,
+				outDir:    outDir,
+				columns:   80,
+				changed:   func(string) bool { return false },
+			})
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name)
+
+				doc, err := os.ReadFile(filepath.Join(outDir, entry.Image))
+				require.NoError(t, err)
+				assert.Contains(t, string(doc), entry.Name, "the svg renders the theme's own text")
+			}
+
+			assert.Equal(t, tc.Expected, names)
+
+			index, err := os.ReadFile(filepath.Join(outDir, galleryIndex))
+			require.NoError(t, err)
+
+			for _, name := range tc.Expected {
+				assert.Contains(t, string(index), `<img src="`+name+`.svg"`)
+			}
+		})
+	}
+}
@@ -23,16 +23,23 @@ var (
 	svgFillAscent      float64
 	svgFillDescent     float64
 	svgBackgroundColor string
+	imageFormat        string
+	pngOpts            pngOptions
+	animateImage       bool
+	animationCommand   string
 )
 
 var imageCmd = &cmdtree.Command{
 	Use:   "image",
-	Short: "Export your config to an SVG image",
-	Long: `Export your config to an SVG image.
+	Short: "Export your config to an SVG or PNG image, an HTML block, or an animation",
+	Long: `Export your config to an SVG or PNG image, an HTML block, or an animation.
 
-The image renders straight from the prompt's own Run stream, so it stays crisp at any zoom level
+The image renders straight from the prompt's own Run stream. An SVG stays crisp at any zoom level
 and, unlike a rasterized image, faithfully reproduces every color and style the prompt itself can
-render.
+render. A PNG is drawn with a font file you supply, so it shows the same glyphs wherever it's
+pasted, including places that won't render an SVG or don't have a Nerd Font installed. An HTML
+block is a <pre> element styled inline, to embed in a page: its text can be selected and copied,
+and every hyperlink the prompt prints stays clickable.
 
 You can tweak the output by using additional flags:
 
@@ -60,6 +67,23 @@ You can tweak the output by using additional flags:
   overriding the theme's own background where none is set; a theme that
   sets its own terminal background always wins over this flag, matching how
   the theme itself would actually look in a real terminal
+- format: svg (default), png, html or cast. A cast is an asciinema v2
+  recording and is always animated
+- animate: export an animated svg of the prompt as a streaming shell draws
+  it: pending segments resolving into their content, then a command typed
+  at the cursor and the prompt collapsing into the transient prompt once it's
+  accepted. A config without a streaming timeout is streamed with 100ms.
+  With a recorded session in --data, it animates the session's frames
+  instead, each shown at the time it was recorded
+- command: the command typed in an animation (default "git status"); leave
+  it empty to end on the resolved prompt
+- font: path to the TTF/OTF font a png is drawn with, required for png. Use
+  a Nerd Font Mono variant to get the icons. The cell width, line height and
+  background box default to this font's own metrics rather than Hack's
+- bold-font: path to the TTF/OTF font bold text is drawn with in a png;
+  without it, bold text is emboldened from font
+- scale: image pixels per unit of the layout, 2 by default for a png that
+  stays sharp on a high density display
 
 Example usage:
 
@@ -69,7 +93,15 @@ Exports the config to an image file called myconfig.svg in the current working d
 
 > oh-my-posh config export image --config ~/myconfig.omp.json --output ~/mytheme.svg
 
-Exports the config to an image file ~/mytheme.svg.`,
+Exports the config to an image file ~/mytheme.svg.
+
+> oh-my-posh config export image --config ~/myconfig.omp.json --format png --font ~/fonts/HackNerdFontMono-Regular.ttf
+
+Exports the config to an image file called myconfig.png in the current working directory.
+
+> oh-my-posh config export image --config ~/myconfig.omp.json --animate
+
+Exports the config to an animated image file called myconfig.svg in the current working directory.`,
 	Args: cmdtree.NoArgs,
 	Run: func(cmd *cmdtree.Command, _ []string) {
 		cache.Init(os.Getenv("POSH_SHELL"))
@@ -79,12 +111,45 @@ Exports the config to an image file ~/mytheme.svg.`,
 		cfg := config.Load(configFlag)
 
 		// CaptureRuns must be set before render.Config's eng.Primary() call so
-		// exportSVG can read the structured Run stream back via eng.CapturedRuns(); it
+		// exportSVG/exportPNG can read the structured Run stream back via eng.CapturedRuns(); it
 		// stays here rather than in the helper because it's specific to this command,
 		// not part of the shared render setup.
 		terminal.CaptureRuns = true
 
-		eng, err := render.Config(cfg, imageTerminalWidth, false, func(flags *runtime.Flags) error {
+		animated := animateImage || imageFormat == "cast"
+		if animated && (imageFormat == "png" || imageFormat == "html") {
+			exitcode = 666
+			fmt.Printf("a %s can't be animated, use svg or cast\n", imageFormat)
+			return
+		}
+
+		// A recorded session animates its own frames, one render each, rather than a stream.
+		if animated && imageFormat == "svg" && dataFrame == defaultFrame {
+			replayed, err := exportSessionSVG(cmd, cfg, outputImage, svgFontFamily, imageTerminalWidth, svgBackgroundColor)
+			if err != nil {
+				exitcode = 666
+				fmt.Println(err.Error())
+				return
+			}
+
+			if replayed {
+				return
+			}
+		}
+
+		// An animation renders through StreamPrimary instead, which needs the streaming flag set
+		// before the environment is initialized.
+		newEngine := render.Config
+		if animated {
+			newEngine = render.Engine
+
+			if cfg.Streaming == 0 {
+				cfg.Streaming = animationStreaming
+			}
+		}
+
+		eng, err := newEngine(cfg, imageTerminalWidth, false, func(flags *runtime.Flags) error {
+			flags.Streaming = animated
 			return applyDataFile(flags, cmd.Flags().Changed)
 		})
 		if err != nil {
@@ -105,7 +170,22 @@ Exports the config to an image file ~/mytheme.svg.`,
 			FillDescent: svgFillDescent,
 		}
 
-		if err := exportSVG(eng, cfg, outputImage, svgFontFamily, imageTerminalWidth, metrics, svgBackgroundColor); err != nil {
+		switch {
+		case imageFormat == "cast":
+			err = exportCast(eng, cfg, outputImage, imageTerminalWidth, animationCommand)
+		case imageFormat == "svg" && animated:
+			err = exportAnimatedSVG(eng, cfg, outputImage, imageOptions(svgFontFamily, imageTerminalWidth, metrics, svgBackgroundColor), animationCommand)
+		case imageFormat == "svg":
+			err = exportSVG(eng, cfg, outputImage, svgFontFamily, imageTerminalWidth, metrics, svgBackgroundColor)
+		case imageFormat == "png":
+			err = exportPNG(eng, cfg, outputImage, imageTerminalWidth, metrics, svgBackgroundColor, pngOpts)
+		case imageFormat == "html":
+			err = exportHTML(eng, cfg, outputImage, svgFontFamily, imageTerminalWidth, metrics, svgBackgroundColor)
+		default:
+			err = fmt.Errorf("unsupported image format %s, use svg, png, html or cast", imageFormat)
+		}
+
+		if err != nil {
 			exitcode = 666
 			fmt.Println(err.Error())
 		}
@@ -113,7 +193,13 @@ Exports the config to an image file ~/mytheme.svg.`,
 }
 
 func init() {
-	imageCmd.Flags().StringVarP(&outputImage, "output", "o", "", "image file (.svg) to export to")
+	imageCmd.Flags().StringVarP(&outputImage, "output", "o", "", "file (.svg, .png, .html or .cast) to export to")
+	imageCmd.Flags().StringVarP(&imageFormat, "format", "f", "svg", "format to export to: svg, png, html or cast")
+	imageCmd.Flags().BoolVar(&animateImage, "animate", false, "animate the streaming prompt and a command being accepted")
+	imageCmd.Flags().StringVar(&animationCommand, "command", "git status", "command typed at the prompt in an animation, empty to stop at the resolved prompt")
+	imageCmd.Flags().StringVar(&pngOpts.font, "font", "", "TTF/OTF font file to draw a png with")
+	imageCmd.Flags().StringVar(&pngOpts.boldFont, "bold-font", "", "TTF/OTF font file to draw bold text in a png with")
+	imageCmd.Flags().Float64Var(&pngOpts.scale, "scale", 0, "image pixels per unit of the layout for a png (default 2)")
 	imageCmd.Flags().StringVar(&svgFontFamily, "font-family", "", "CSS font-family for the exported svg")
 	imageCmd.Flags().StringVar(&dataPath, "data", "", "path to a template data file (json/yaml/toml) to render with")
 	imageCmd.Flags().IntVar(&imageTerminalWidth, "terminal-width", 120, "number of columns to render the prompt and image at")
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
+	"github.com/jandedobbeleer/oh-my-posh/src/raster"
+	"github.com/jandedobbeleer/oh-my-posh/src/render"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/path"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// exportPNG rasterizes eng's already-captured Run stream to a PNG file, laid out exactly like
+// exportSVG lays out its SVG. pngOpts.font is the --font flag and is required, a PNG has no font
+// stack to fall back on.
+func exportPNG(eng *prompt.Engine, cfg *config.Config, output string, columns int, metrics render.FontMetrics, backgroundColor string, pngOpts pngOptions) error {
+	if pngOpts.font == "" {
+		return errors.New("exporting a png requires a TTF or OTF font, set one with --font")
+	}
+
+	regular, err := loadFont(pngOpts.font)
+	if err != nil {
+		return err
+	}
+
+	opts := raster.Options{
+		Options: imageOptions("", columns, metrics, backgroundColor),
+		Font:    regular,
+		Scale:   pngOpts.scale,
+	}
+
+	if pngOpts.boldFont != "" {
+		if opts.BoldFont, err = loadFont(pngOpts.boldFont); err != nil {
+			return err
+		}
+	}
+
+	data, err := render.PNG(eng, opts)
+	if err != nil {
+		return err
+	}
+
+	path := imageOutputPath(cfg.Source, output, ".png")
+
+	return os.WriteFile(path, data, 0o644) //nolint:gosec
+}
+
+// pngOptions groups the --font, --bold-font and --scale flags.
+type pngOptions struct {
+	font     string
+	boldFont string
+	scale    float64
+}
+
+func loadFont(fontPath string) (*sfnt.Font, error) {
+	data, err := os.ReadFile(path.ReplaceTildePrefixWithHomeDir(fontPath))
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := raster.ParseFont(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse font %s: %w", fontPath, err)
+	}
+
+	return f, nil
+}
@@ -27,6 +27,29 @@ import (
 // what stays genuinely CLI-shaped - resolving the output path and writing
 // the file.
 func exportSVG(eng *prompt.Engine, cfg *config.Config, output, fontFamily string, columns int, metrics render.FontMetrics, backgroundColor string) error {
+	opts := imageOptions(fontFamily, columns, metrics, backgroundColor)
+
+	doc := render.SVG(eng, opts)
+
+	path := imageOutputPath(cfg.Source, output, ".svg")
+
+	return os.WriteFile(path, []byte(doc), 0o644) //nolint:gosec
+}
+
+// exportHTML writes eng's prompt as a <pre> block, laid out with the same options as exportSVG.
+func exportHTML(eng *prompt.Engine, cfg *config.Config, output, fontFamily string, columns int, metrics render.FontMetrics, backgroundColor string) error {
+	opts := imageOptions(fontFamily, columns, metrics, backgroundColor)
+
+	doc := render.HTML(eng, opts)
+
+	path := imageOutputPath(cfg.Source, output, ".html")
+
+	return os.WriteFile(path, []byte(doc), 0o644) //nolint:gosec
+}
+
+// imageOptions builds the layout options every image format shares: render.SVGOptions, plus the
+// --background-color flag's raw #RRGGBB value, or empty when unset.
+func imageOptions(fontFamily string, columns int, metrics render.FontMetrics, backgroundColor string) svg.Options {
 	opts := render.SVGOptions(fontFamily, columns, metrics)
 
 	// A caller-supplied canvas background only ever fills in for a theme that leaves its own
@@ -39,23 +62,18 @@ func exportSVG(eng *prompt.Engine, cfg *config.Config, output, fontFamily string
 		}
 	}
 
-	doc := render.SVG(eng, opts)
-
-	path := svgOutputPath(cfg.Source, output)
-
-	return os.WriteFile(path, []byte(doc), 0o644) //nolint:gosec
+	return opts
 }
 
-// svgOutputPath derives the export path: an explicit --output wins (with its
-// extension swapped to .svg, so -o mytheme.png still produces mytheme.svg),
-// otherwise the config's own basename does, falling back to "prompt.svg"
-// when neither yields a usable name.
-func svgOutputPath(configPath, output string) string {
-	const svgExt = ".svg"
-
+// imageOutputPath derives the export path for an image with extension ext:
+// an explicit --output wins (with its extension swapped to ext, so -o
+// mytheme.png still produces mytheme.svg when exporting an svg), otherwise
+// the config's own basename does, falling back to "prompt" when neither
+// yields a usable name.
+func imageOutputPath(configPath, output, ext string) string {
 	if output != "" {
 		path := cleanOutputPath(output)
-		return strings.TrimSuffix(path, filepath.Ext(path)) + svgExt
+		return strings.TrimSuffix(path, filepath.Ext(path)) + ext
 	}
 
 	base := filepath.Base(configPath)
@@ -73,5 +91,5 @@ func svgOutputPath(configPath, output string) string {
 		name = "prompt"
 	}
 
-	return name + svgExt
+	return name + ext
 }
@@ -7,7 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-// TestSVGOutputPath pins how an export path is derived when --output is
+// TestImageOutputPath pins how an export path is derived when --output is
 // absent: the config's own basename, with the `.omp` marker and the config
 // extension both stripped.
 //
@@ -17,11 +17,12 @@ import (
 // suffix — every trailing '.', 'o', 'm' and 'p' came off, so demo.json
 // exported as de.svg and promo.json as pr.svg. The bug survived the port to
 // this package.
-func TestSVGOutputPath(t *testing.T) {
+func TestImageOutputPath(t *testing.T) {
 	cases := []struct {
 		Case       string
 		ConfigPath string
 		Output     string
+		Ext        string
 		Expected   string
 	}{
 		{Case: "omp marker", ConfigPath: "jandedobbeleer.omp.json", Expected: "jandedobbeleer.svg"},
@@ -35,14 +36,20 @@ func TestSVGOutputPath(t *testing.T) {
 		{Case: "unrecognized name falls back", ConfigPath: "notaconfig", Expected: "prompt.svg"},
 		{Case: "output wins, extension swapped", ConfigPath: "theme.omp.json", Output: "mytheme.png", Expected: "mytheme.svg"},
 		{Case: "output already svg", ConfigPath: "theme.omp.json", Output: "mytheme.svg", Expected: "mytheme.svg"},
+		{Case: "png", ConfigPath: "theme.omp.json", Ext: ".png", Expected: "theme.png"},
+		{Case: "png, output extension swapped", ConfigPath: "theme.omp.json", Output: "mytheme.svg", Ext: ".png", Expected: "mytheme.png"},
 	}
 
 	// Compared by base name: an explicit --output is resolved to an absolute
 	// path by cleanOutputPath, and what matters here is the name and
 	// extension it lands on, not the working directory the test ran from.
 	for _, tc := range cases {
+		if tc.Ext == "" {
+			tc.Ext = ".svg"
+		}
+
 		t.Run(tc.Case, func(t *testing.T) {
-			assert.Equal(t, tc.Expected, filepath.Base(svgOutputPath(tc.ConfigPath, tc.Output)))
+			assert.Equal(t, tc.Expected, filepath.Base(imageOutputPath(tc.ConfigPath, tc.Output, tc.Ext)))
 		})
 	}
 }
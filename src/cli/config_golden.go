@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/golden"
+	"github.com/jandedobbeleer/oh-my-posh/src/render"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+)
+
+var (
+	goldenDataPaths     []string
+	goldenDir           string
+	updateGoldens       bool
+	goldenTerminalWidth int
+)
+
+var testCmd = &cmdtree.Command{
+	Use:   "test",
+	Short: "Test your config against golden renders of recorded data files",
+	Long: `Test your config against golden renders of recorded data files.
+
+Renders the config once for every --data file and compares the result to the golden stored for
+that file in --golden, named after it: prompt.data.json is compared to prompt.data.golden.json.
+The comparison is cell by cell, on what a terminal would show rather than on the printed bytes,
+and every difference is reported with the row and columns it is at. Run with --update to write
+the goldens, after creating the data files or after an intentional change to the config.
+
+The data files render hermetically: a segment a file doesn't cover renders as absent, and dates
+are shown in UTC, so a golden matches on every machine. A recorded session renders the frame it
+ended on. Record a data file with "oh-my-posh config export data".
+
+Example usage:
+
+> oh-my-posh config test --config ~/myconfig.omp.json --data ~/posh/git.data.json --data ~/posh/home.data.json --golden ~/posh/goldens --update
+
+Writes a golden for each data file to ~/posh/goldens.
+
+> oh-my-posh config test --config ~/myconfig.omp.json --data ~/posh/git.data.json --data ~/posh/home.data.json --golden ~/posh/goldens
+
+Tests the config against those goldens, exiting with 1 when one of them doesn't match.`,
+	Args: cmdtree.NoArgs,
+	Run: func(_ *cmdtree.Command, _ []string) {
+		if len(goldenDataPaths) == 0 || goldenDir == "" {
+			fmt.Println("both --data and --golden are required")
+			exitcode = 2
+			return
+		}
+
+		cache.Init(os.Getenv("POSH_SHELL"))
+		defer cache.Close()
+
+		setConfigFlag()
+
+		// A recorded date renders in the local zone, which would tie a golden to the zone of the
+		// machine that wrote it.
+		time.Local = time.UTC
+
+		terminal.CaptureRuns = true
+
+		failed := false
+
+		for _, dataFile := range goldenDataPaths {
+			passed, err := testGolden(dataFile)
+			if err != nil {
+				fmt.Printf("FAIL %s: %s\n", dataFile, err)
+			}
+
+			if err != nil || !passed {
+				failed = true
+			}
+		}
+
+		if failed {
+			exitcode = 1
+		}
+	},
+}
+
+func init() {
+	testCmd.Flags().StringArrayVar(&goldenDataPaths, "data", nil, "path to a recorded data file to render with, repeat it for more")
+	testCmd.Flags().StringVar(&goldenDir, "golden", "", "folder holding the golden of every data file")
+	testCmd.Flags().BoolVar(&updateGoldens, "update", false, "write the goldens instead of comparing against them")
+	testCmd.Flags().IntVar(&goldenTerminalWidth, "terminal-width", 120, "number of columns to render the prompt at")
+	configCmd.AddCommand(testCmd)
+}
+
+// testGolden renders the config with dataFile and compares the result to its golden, or writes
+// the golden with --update, printing the outcome.
+func testGolden(dataFile string) (bool, error) {
+	data, err := config.LoadData(dataFile)
+	if err != nil {
+		return false, err
+	}
+
+	frame, err := data.Frame(len(data.Timeline()) - 1)
+	if err != nil {
+		return false, err
+	}
+
+	// a fresh config every render, rendering writes to it
+	cfg := config.Load(configFlag)
+
+	eng, err := render.Config(cfg, goldenTerminalWidth, true, func(flags *runtime.Flags) error {
+		flags.DataOnly = true
+		return frame.ApplyFlags(flags, nil)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	actual := golden.New(eng.CapturedRuns())
+
+	base := strings.TrimSuffix(filepath.Base(dataFile), filepath.Ext(dataFile))
+	path := filepath.Join(cleanOutputPath(goldenDir), base+".golden.json")
+
+	if updateGoldens {
+		content, err := actual.Bytes()
+		if err != nil {
+			return false, err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return false, err
+		}
+
+		if err := os.WriteFile(path, content, 0o644); err != nil { //nolint:gosec
+			return false, err
+		}
+
+		fmt.Printf("updated %s\n", path)
+
+		return true, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, fmt.Errorf("no golden at %s, run with --update to write it", path)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	expected, err := golden.Parse(content)
+	if err != nil {
+		return false, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	differences := expected.Diff(actual)
+	if len(differences) == 0 {
+		fmt.Printf("ok   %s\n", dataFile)
+		return true, nil
+	}
+
+	fmt.Printf("FAIL %s: %d difference(s) with %s\n", dataFile, len(differences), path)
+
+	for i := range differences {
+		fmt.Printf("     %s\n", differences[i].String())
+	}
+
+	return false, nil
+}
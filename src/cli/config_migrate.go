@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
+)
+
+var (
+	migrateWrite  bool
+	migrateFormat string
+)
+
+var migrateCmd = &cmdtree.Command{
+	Use:   "migrate",
+	Short: "Migrate your config",
+	Long: `Migrate your config.
+
+Brings the config up to the current version (for example renaming a segment's legacy
+"properties" to "options") and, optionally, converts it to another format.
+
+Unlike "config export", which writes out the config as loaded, migrate edits the file
+itself: comments, key order and every value it does not have to change are kept as they are,
+also when converting between json, jsonc, yaml and toml. Converting to json drops comments,
+use jsonc to keep them.
+
+Example usage:
+
+> oh-my-posh config migrate --config ~/myconfig.omp.json
+
+Migrates the config file "~/myconfig.omp.json" and prints the result to stdout.
+
+> oh-my-posh config migrate --config ~/myconfig.omp.json --format yaml --write
+
+Migrates and converts the config, writing it to "~/myconfig.omp.yaml".
+
+A backup of the current config is written next to it with a .bak extension when the config file
+itself gets overwritten.`,
+	Args: cmdtree.NoArgs,
+	Run: func(_ *cmdtree.Command, _ []string) {
+		runMigrate(func(doc *config.Document) error {
+			doc.Migrate()
+			return nil
+		})
+	},
+}
+
+var migrateGlyphsCmd = &cmdtree.Command{
+	Use:   "glyphs",
+	Short: "Migrate the Nerd Font glyphs in your config",
+	Long: `Migrate the Nerd Font glyphs in your config.
+
+Nerd Fonts v3 moved a large set of glyphs to new code points. This updates every glyph in the
+config to its new location, leaving the rest of the file untouched. Do know they might look
+different, as Nerd Fonts also updated the icons themselves.
+
+Example usage:
+
+> oh-my-posh config migrate glyphs --config ~/myconfig.omp.json --write
+
+Migrates the glyphs in "~/myconfig.omp.json" in place.`,
+	Args: cmdtree.NoArgs,
+	Run: func(_ *cmdtree.Command, _ []string) {
+		runMigrate(func(doc *config.Document) error {
+			_, err := doc.MigrateGlyphs()
+			return err
+		})
+	},
+}
+
+func runMigrate(migrate func(doc *config.Document) error) {
+	cache.Init(os.Getenv("POSH_SHELL"))
+
+	setConfigFlag()
+
+	doc, err := config.ReadDocument(configFlag)
+	if err != nil {
+		fmt.Println("unable to read config:", strings.TrimSpace(err.Error()))
+		exitcode = 2
+		return
+	}
+
+	if err := migrate(doc); err != nil {
+		fmt.Println("unable to migrate config:", err)
+		exitcode = 1
+		return
+	}
+
+	content, err := doc.Encode(migrateFormat)
+	if err != nil {
+		formats := []string{config.JSON, config.JSONC, config.TOML, config.TML, config.YAML, config.YML}
+		// usage error
+		fmt.Printf("migrate format must be one of these: %s\n", strings.Join(formats, ", "))
+		exitcode = 2
+		return
+	}
+
+	if !migrateWrite {
+		fmt.Print(string(content))
+		return
+	}
+
+	target, err := doc.Write(migrateFormat)
+	if err != nil {
+		fmt.Println("unable to write config:", err)
+		exitcode = 1
+		return
+	}
+
+	fmt.Println("config migrated to", target)
+}
+
+func init() {
+	for _, cmd := range []*cmdtree.Command{migrateCmd, migrateGlyphsCmd} {
+		cmd.Flags().BoolVarP(&migrateWrite, "write", "w", false, "write the migrated config instead of printing it")
+		cmd.Flags().StringVarP(&migrateFormat, "format", "f", "", "config format to convert to (json, jsonc, yaml or toml)")
+	}
+
+	migrateCmd.AddCommand(migrateGlyphsCmd)
+	configCmd.AddCommand(migrateCmd)
+}
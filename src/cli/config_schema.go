@@ -0,0 +1,59 @@
+package cli
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
+)
+
+// configSchema is generated from config.Config and the options every segment
+// reads, see config_schema_test.go. Like the dsc schemas it is embedded
+// rather than reflected at runtime, which would link the reflection and
+// go/ast machinery into the binary for output that never changes between
+// builds.
+//
+//go:embed config.schema.json
+var configSchema string
+
+var schemaOutput string
+
+var schemaCmd = &cmdtree.Command{
+	Use:   "schema",
+	Short: "Print the JSON schema of the config",
+	Long: `Print the JSON schema of the config.
+
+The schema is generated from the same definitions this binary loads a config with,
+including every option each segment supports, their types and defaults. Point your
+editor to it to get completion and validation that match the version you run.
+
+Example usage:
+
+> oh-my-posh config schema --output ~/.config/oh-my-posh/schema.json
+
+Writes the schema to "~/.config/oh-my-posh/schema.json", reference it from your config
+using "$schema" (json), "# yaml-language-server: $schema=" (yaml) or "#:schema" (toml).`,
+	Args: cmdtree.NoArgs,
+	Run: func(_ *cmdtree.Command, _ []string) {
+		if schemaOutput == "" {
+			fmt.Print(configSchema)
+			return
+		}
+
+		schemaOutput = cleanOutputPath(schemaOutput)
+
+		if err := os.WriteFile(schemaOutput, []byte(configSchema), 0o644); err != nil {
+			fmt.Println("unable to write schema:", err)
+			exitcode = 1
+			return
+		}
+
+		fmt.Println("schema written to", schemaOutput)
+	},
+}
+
+func init() {
+	schemaCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "file to write the schema to")
+	configCmd.AddCommand(schemaCmd)
+}
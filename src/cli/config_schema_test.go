@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/invopop/jsonschema"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The embedded config.schema.json is a golden copy of what reflecting
+// config.Config and the segments' option declarations produces. This
+// test regenerates it and fails on drift; run with UPDATE_SCHEMAS=1 to
+// rewrite the file after changing the config or a segment's options.
+
+func TestConfigSchemaUpToDate(t *testing.T) {
+	generated, err := generateConfigSchema()
+	require.NoError(t, err)
+
+	if os.Getenv("UPDATE_SCHEMAS") != "" {
+		assert.NoError(t, os.WriteFile("config.schema.json", []byte(generated), 0644))
+		return
+	}
+
+	assert.Equal(t, generated, configSchema, "run UPDATE_SCHEMAS=1 go test ./cli -run TestConfigSchemaUpToDate to regenerate config.schema.json")
+}
+
+func TestConfigSchemaContent(t *testing.T) {
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal([]byte(configSchema), &schema))
+
+	segment := schemaPath(schema, "$defs", "Segment", "properties", "type").(map[string]any)
+	types := segment["enum"].([]any)
+
+	// every theme only uses segment types the schema knows about
+	themes, err := filepath.Glob(filepath.Join("..", "..", "themes", "*.omp.json"))
+	require.NoError(t, err)
+
+	for _, theme := range themes {
+		cfg := config.Load(theme)
+
+		for _, block := range cfg.Blocks {
+			for _, segment := range block.Segments {
+				assert.Contains(t, types, string(segment.Type), theme)
+			}
+		}
+	}
+
+	// and the well known options are typed
+	git := segmentOptionsSchema(t, schema, "git")
+	assert.Equal(t, "boolean", schemaPath(git, "fetch_status", "type"))
+	assert.Equal(t, false, schemaPath(git, "fetch_status", "default"))
+
+	path := segmentOptionsSchema(t, schema, "path")
+	assert.Equal(t, "string", schemaPath(path, "style", "type"))
+	assert.Equal(t, "integer", schemaPath(path, "max_depth", "type"))
+
+	node := segmentOptionsSchema(t, schema, "node")
+	assert.Equal(t, "array", schemaPath(node, "extensions", "type"), "language options are inherited")
+}
+
+func segmentOptionsSchema(t *testing.T, schema map[string]any, segmentType string) map[string]any {
+	t.Helper()
+
+	for _, condition := range schemaPath(schema, "$defs", "Segment", "allOf").([]any) {
+		condition := condition.(map[string]any)
+		if schemaPath(condition, "if", "properties", "type", "const") != segmentType {
+			continue
+		}
+
+		return schemaPath(condition, "then", "properties", "options", "properties").(map[string]any)
+	}
+
+	t.Fatalf("no options for %s", segmentType)
+
+	return nil
+}
+
+func schemaPath(schema map[string]any, keys ...string) any {
+	var value any = schema
+
+	for _, key := range keys {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = object[key]
+	}
+
+	return value
+}
+
+func generateConfigSchema() (string, error) {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties: true,
+		// several packages have a Config, qualify every type outside of config
+		Namer: func(t reflect.Type) string {
+			if pkg := path.Base(t.PkgPath()); pkg != "config" && pkg != "." {
+				return pkg + "." + t.Name()
+			}
+
+			return t.Name()
+		},
+	}
+
+	reflected, err := json.Marshal(reflector.Reflect(&config.Config{}))
+	if err != nil {
+		return "", err
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(reflected, &schema); err != nil {
+		return "", err
+	}
+
+	delete(schema, "$id")
+
+	schema["title"] = "Oh My Posh configuration"
+	schema["description"] = "Generated by oh-my-posh config schema, matching the version that generated it."
+
+	defs := schema["$defs"].(map[string]any)
+
+	configSchema := defs["Config"].(map[string]any)
+	configSchema["properties"].(map[string]any)["$schema"] = map[string]any{"type": "string"}
+
+	block := defs["Block"].(map[string]any)
+	setEnum(block, "type", config.Prompt, config.RPrompt)
+	setEnum(block, "alignment", config.Left, config.Right)
+	setEnum(block, "overflow", config.Break, config.Hide)
+
+	segmentTypes := make([]string, 0, len(config.Segments))
+	for segmentType := range config.Segments {
+		segmentTypes = append(segmentTypes, string(segmentType))
+	}
+
+	slices.Sort(segmentTypes)
+
+	segment := defs["Segment"].(map[string]any)
+	setEnum(segment, "type", segmentTypes...)
+	setEnum(segment, "style", config.Plain, config.Powerline, config.Accordion, config.Diamond)
+	setEnum(segment, "templates_logic", template.FirstMatch, template.Join)
+
+	conditions := make([]any, 0, len(segmentTypes))
+
+	for _, segmentType := range segmentTypes {
+		declarations, _ := config.SegmentOptions(config.SegmentType(segmentType))
+
+		optionsSchema := declarationsSchema(declarations)
+		if optionsSchema == nil {
+			continue
+		}
+
+		conditions = append(conditions, map[string]any{
+			"if": map[string]any{
+				"properties": map[string]any{
+					"type": map[string]any{"const": segmentType},
+				},
+			},
+			"then": map[string]any{
+				"properties": map[string]any{
+					"options": optionsSchema,
+				},
+			},
+		})
+	}
+
+	segment["allOf"] = conditions
+
+	generated, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(generated) + "\n", nil
+}
+
+func setEnum[T ~string](schema map[string]any, property string, values ...T) {
+	enum := make([]any, 0, len(values))
+	for _, value := range values {
+		enum = append(enum, string(value))
+	}
+
+	schema["properties"].(map[string]any)[property].(map[string]any)["enum"] = enum
+}
+
+// optionTypes maps the option types to the JSON schema of their value.
+var optionTypes = map[options.Type]map[string]any{
+	options.BoolType:        {"type": "boolean"},
+	options.StringType:      {"type": "string"},
+	options.TemplateType:    {"type": "string"},
+	options.ColorType:       {"type": "string"},
+	options.IntType:         {"type": "integer"},
+	options.Float64Type:     {"type": "number"},
+	options.KeyValueMapType: {"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+	options.StringArrayType: {"type": "array", "items": map[string]any{"type": "string"}},
+	options.AnyType:         {},
+}
+
+// declarationsSchema describes the options object of a segment, rejecting
+// undeclared options like loading the config does.
+func declarationsSchema(declarations []options.Declaration) map[string]any {
+	if len(declarations) == 0 {
+		return nil
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"additionalProperties": false,
+	}
+
+	properties := map[string]any{}
+
+	for _, declaration := range declarations {
+		if declaration.Name == options.Additional {
+			schema["additionalProperties"] = declarationSchema(declaration)
+			continue
+		}
+
+		properties[string(declaration.Name)] = declarationSchema(declaration)
+	}
+
+	if len(properties) != 0 {
+		schema["properties"] = properties
+	}
+
+	return schema
+}
+
+func declarationSchema(declaration options.Declaration) map[string]any {
+	schema := map[string]any{}
+	for key, value := range optionTypes[declaration.Type] {
+		schema[key] = value
+	}
+
+	if declaration.Description != "" {
+		schema["description"] = declaration.Description
+	}
+
+	if declaration.Default != nil {
+		schema["default"] = declaration.Default
+	}
+
+	if len(declaration.Values) != 0 {
+		schema["enum"] = declaration.Values
+	}
+
+	return schema
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
+)
+
+var validateCmd = &cmdtree.Command{
+	Use:   "validate",
+	Short: "Validate your config",
+	Long: `Validate your config.
+
+Reports what loading the config would only log, or silently work around: parse errors, an
+outdated version, unknown block and segment types, options a segment doesn't support or can't
+read, and templates that don't parse. The config file itself is never changed.
+
+Example usage:
+
+> oh-my-posh config validate --config ~/myconfig.omp.json
+
+Exits with 1 when the config has errors, warnings alone keep it at 0.`,
+	Args: cmdtree.NoArgs,
+	Run: func(_ *cmdtree.Command, _ []string) {
+		cache.Init(os.Getenv("POSH_SHELL"))
+
+		setConfigFlag()
+
+		diagnostics, err := config.ValidateFile(configFlag)
+		if err != nil {
+			fmt.Println("unable to read config:", strings.TrimSpace(err.Error()))
+			exitcode = 2
+			return
+		}
+
+		if len(diagnostics) == 0 {
+			fmt.Println("config is valid")
+			return
+		}
+
+		for _, diagnostic := range diagnostics {
+			fmt.Println(formatDiagnostic(diagnostic))
+
+			if diagnostic.Severity == config.SeverityError {
+				exitcode = 1
+			}
+		}
+	},
+}
+
+// formatDiagnostic prints a diagnostic on one line, pointing at the path in
+// the config or, for a parse error, at the line and column.
+func formatDiagnostic(diagnostic config.Diagnostic) string {
+	location := diagnostic.Path
+
+	if diagnostic.Line != 0 {
+		location = fmt.Sprintf("%d:%d", diagnostic.Line, diagnostic.Column)
+	}
+
+	if location == "" {
+		return fmt.Sprintf("%s: %s", diagnostic.Severity, diagnostic.Message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", diagnostic.Severity, location, diagnostic.Message)
+}
+
+func init() {
+	configCmd.AddCommand(validateCmd)
+}
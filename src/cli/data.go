@@ -15,6 +15,7 @@ var (
 	dataPath   string
 	dataDerive bool
 	dataOnly   bool
+	dataFrame  int
 )
 
 func init() {
@@ -28,20 +29,58 @@ func init() {
 
 	printCmd.Flags().BoolVar(&dataOnly, "data-only", false, onlyUsage)
 	imageCmd.Flags().BoolVar(&dataOnly, "data-only", false, onlyUsage)
+
+	printCmd.Flags().IntVar(&dataFrame, "frame", defaultFrame,
+		"frame of a recorded session in --data to render, starting at 0 (default every frame, in order)")
+	imageCmd.Flags().IntVar(&dataFrame, "frame", defaultFrame,
+		"frame of a recorded session in --data to render, starting at 0 (default the last frame)")
 }
 
+// defaultFrame is --frame's default, no frame selected: print replays every frame of a recorded
+// session, image renders the one the session ended on.
+const defaultFrame = -1
+
 // No-op when dataPath is empty. The env/segment routing onto flags itself - with
 // precedence explicit CLI flag > data file > live environment - lives in
 // config.Data.ApplyFlags, shared with prompt/golden_test.go's fixture harness,
 // which cannot import this package (cli imports prompt).
+//
+// A recorded session renders the frame --frame selects, its last one by default.
 func applyDataFile(flags *runtime.Flags, changed func(name string) bool) error {
+	data, err := loadDataFile()
+	if err != nil || data == nil {
+		return err
+	}
+
+	index := dataFrame
+	if index == defaultFrame {
+		index = len(data.Timeline()) - 1
+	}
+
+	frame, err := data.Frame(index)
+	if err != nil {
+		return err
+	}
+
+	return applyDataFrame(flags, frame, changed)
+}
+
+// applyDataFrame applies one snapshot of a data file loaded by loadDataFile onto flags.
+func applyDataFrame(flags *runtime.Flags, data *config.Data, changed func(name string) bool) error {
+	flags.DataOnly = dataOnly
+
+	return data.ApplyFlags(flags, changed)
+}
+
+// loadDataFile reads --data, or returns nil when it's not set.
+func loadDataFile() (*config.Data, error) {
 	if dataPath == "" {
-		return nil
+		return nil, nil
 	}
 
 	data, err := config.LoadData(dataPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if data.Version < config.DataVersion {
@@ -53,6 +92,10 @@ func applyDataFile(flags *runtime.Flags, changed func(name string) bool) error {
 
 	if dataDerive && data.Version >= config.DataVersion {
 		data.Segments = deriveRecordedSegments(data.Segments)
+
+		for i := range data.Frames {
+			data.Frames[i].Segments = deriveRecordedSegments(data.Frames[i].Segments)
+		}
 	}
 
 	// --data-only and --data-derive are opposites: one forbids probing
@@ -60,12 +103,10 @@ func applyDataFile(flags *runtime.Flags, changed func(name string) bool) error {
 	// is a contradiction rather than a combination, so say so instead of
 	// silently letting one win.
 	if dataOnly && dataDerive {
-		return errors.New("--data-only and --data-derive contradict each other: one forbids probing the environment, the other forces it")
+		return nil, errors.New("--data-only and --data-derive contradict each other: one forbids probing the environment, the other forces it")
 	}
 
-	flags.DataOnly = dataOnly
-
-	return data.ApplyFlags(flags, changed)
+	return data, nil
 }
 
 // deriveRecordedSegments strips the RecordedSegment envelope back down to each
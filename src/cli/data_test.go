@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
@@ -314,3 +315,65 @@ func TestApplyDataFile_MissingEnvKeysLeaveFlagsUntouched(t *testing.T) {
 	assert.True(t, flags.Interrupted)
 	assert.True(t, flags.NoExitCode)
 }
+
+func TestPrintFrames(t *testing.T) {
+	session := `{"version": 1, "frames": [{"env": {"PWD": "~"}}, {"env": {"PWD": "~/repo"}}]}`
+
+	cases := []struct {
+		Case          string
+		Data          string
+		Frame         int
+		Expected      []string
+		ExpectedError bool
+	}{
+		{Case: "no data file", Frame: defaultFrame, Expected: []string{""}},
+		{Case: "snapshot", Data: `{"version": 1, "env": {"PWD": "~"}}`, Frame: defaultFrame, Expected: []string{"~"}},
+		{Case: "every frame", Data: session, Frame: defaultFrame, Expected: []string{"~", "~/repo"}},
+		{Case: "selected frame", Data: session, Frame: 1, Expected: []string{"~/repo"}},
+		{Case: "frame out of range", Data: session, Frame: 2, ExpectedError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			previous := dataFrame
+			dataFrame = tc.Frame
+			t.Cleanup(func() { dataFrame = previous })
+
+			var data *config.Data
+			if tc.Data != "" {
+				var err error
+				data, err = config.ParseData([]byte(tc.Data))
+				require.NoError(t, err)
+			}
+
+			frames, err := printFrames(data)
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var pwds []string
+			for _, frame := range frames {
+				flags := &runtime.Flags{}
+				if frame != nil {
+					require.NoError(t, applyDataFrame(flags, frame, noneChanged))
+				}
+
+				pwds = append(pwds, flags.PWD)
+			}
+
+			assert.Equal(t, tc.Expected, pwds)
+		})
+	}
+}
+
+func TestApplyDataFile_SessionRendersTheLastFrame(t *testing.T) {
+	withDataPath(t, writeDataFile(t, `{"version": 1, "frames": [{"env": {"PWD": "~"}}, {"env": {"PWD": "~/repo"}}]}`))
+
+	flags := &runtime.Flags{}
+	require.NoError(t, applyDataFile(flags, noneChanged))
+
+	assert.Equal(t, "~/repo", flags.PWD)
+}
@@ -52,6 +52,7 @@ func createDebugCmd() *cmdtree.Command {
 			cfg := getDebugConfig(configFlag)
 
 			template.Init(env, cfg.Var, cfg.Maps)
+			template.Extend(cfg.TemplateExtensions())
 
 			defer func() {
 				template.SaveCache()
@@ -117,6 +117,12 @@ func (s *Shell) getShellConfigPath() (string, error) {
 		return filepath.Join(home, ".xonshrc"), nil
 	case shell.YASH:
 		return filepath.Join(home, ".yashrc"), nil
+	case shell.OSH:
+		return filepath.Join(home, ".config", "oils", "oshrc"), nil
+	case shell.YSH:
+		return filepath.Join(home, ".config", "oils", "yshrc"), nil
+	case shell.TCSH:
+		return filepath.Join(home, ".tcshrc"), nil
 	default:
 		return "", fmt.Errorf("unsupported shell type: %s", s.Name)
 	}
@@ -204,8 +210,10 @@ func (s *Shell) getLastInitLinePosition(lines []string) int {
 
 func (s *Shell) shellCommand() string {
 	switch s.Name {
-	case shell.BASH, shell.ZSH, shell.YASH:
+	case shell.BASH, shell.ZSH, shell.YASH, shell.OSH, shell.YSH:
 		return fmt.Sprintf(`eval "$(%s)"`, s.Command)
+	case shell.TCSH:
+		return fmt.Sprintf("eval \"`%s`\"", s.Command)
 	case shell.FISH:
 		return s.Command + " | source"
 	case shell.PWSH:
@@ -435,6 +435,22 @@ func TestShellCommand(t *testing.T) {
 			},
 			expected: `execx($(oh-my-posh init xonsh))`,
 		},
+		{
+			name: "ysh shell command",
+			shell: &Shell{
+				Name:    "ysh",
+				Command: "oh-my-posh init ysh",
+			},
+			expected: `eval "$(oh-my-posh init ysh)"`,
+		},
+		{
+			name: "tcsh shell command",
+			shell: &Shell{
+				Name:    "tcsh",
+				Command: "oh-my-posh init tcsh",
+			},
+			expected: "eval \"`oh-my-posh init tcsh`\"",
+		},
 		{
 			name: "unknown shell command",
 			shell: &Shell{
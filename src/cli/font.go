@@ -63,7 +63,8 @@ Takes a font name from ` + "`oh-my-posh font list`" + `, a URL, or the path to a
   oh-my-posh font install Meslo
   oh-my-posh font install https://example.com/font.zip
   oh-my-posh font install ./CascadiaCode.zip`,
-		Args: cmdtree.ExactArgs(1),
+		Args:              cmdtree.ExactArgs(1),
+		ValidArgsFunction: completeFonts,
 		Run: func(_ *cmdtree.Command, args []string) {
 			env := &runtime.Terminal{}
 			env.Init(&runtime.Flags{})
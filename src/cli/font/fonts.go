@@ -16,6 +16,11 @@ import (
 
 const (
 	CascadiaCodeMS = "CascadiaCode (MS)"
+
+	// listTimeout bounds fetching the font list for a command, suggestTimeout
+	// for a completion the user waits on after pressing TAB.
+	listTimeout    = 20 * time.Second
+	suggestTimeout = time.Second
 )
 
 type release struct {
@@ -40,7 +45,7 @@ func ResolveFontAsset(font string) (*Asset, error) {
 		return &Asset{URL: font}, nil
 	}
 
-	fonts, err := fonts()
+	fonts, err := fonts(listTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -62,17 +67,20 @@ func ResolveFontAsset(font string) (*Asset, error) {
 	return asset, nil
 }
 
-func fonts() ([]*Asset, error) {
+func fonts(timeout time.Duration) ([]*Asset, error) {
 	if assets, err := getCachedFontData(); err == nil {
 		return assets, nil
 	}
 
-	assets, err := fetchFontAssets("ryanoasis/nerd-fonts")
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+
+	assets, err := fetchFontAssets(ctx, "ryanoasis/nerd-fonts")
 	if err != nil {
 		return nil, err
 	}
 
-	cascadiaCode, err := CascadiaCode()
+	cascadiaCode, err := CascadiaCode(ctx)
 	if err == nil {
 		assets = append(assets, cascadiaCode)
 	}
@@ -93,8 +101,8 @@ func getCachedFontData() ([]*Asset, error) {
 	return list, nil
 }
 
-func CascadiaCode() (*Asset, error) {
-	assets, err := fetchFontAssets("microsoft/cascadia-code")
+func CascadiaCode(ctx context.Context) (*Asset, error) {
+	assets, err := fetchFontAssets(ctx, "microsoft/cascadia-code")
 	if err != nil || len(assets) != 1 {
 		return nil, errors.New("no assets found")
 	}
@@ -106,10 +114,7 @@ func CascadiaCode() (*Asset, error) {
 	}, nil
 }
 
-func fetchFontAssets(repo string) ([]*Asset, error) {
-	ctx, cancelF := context.WithTimeout(context.Background(), time.Second*time.Duration(20))
-	defer cancelF()
-
+func fetchFontAssets(ctx context.Context, repo string) ([]*Asset, error) {
 	repoURL := "https://api.github.com/repos/" + repo + "/releases/latest"
 	req, err := httplib.NewRequestWithContext(ctx, "GET", repoURL, nil)
 	if err != nil {
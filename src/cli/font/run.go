@@ -10,7 +10,13 @@ import (
 
 // List returns the installable Nerd Fonts, newest release first, for `oh-my-posh font list`.
 func List() ([]*Asset, error) {
-	return fonts()
+	return fonts(listTimeout)
+}
+
+// Suggest returns the same fonts as List for shell completion, giving up after a second when
+// the list isn't cached yet, so pressing TAB never hangs on a slow or missing network.
+func Suggest() ([]*Asset, error) {
+	return fonts(suggestTimeout)
 }
 
 // Install downloads and installs one font by name, URL, or local zip path, reporting each step on
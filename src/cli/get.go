@@ -14,7 +14,7 @@ import (
 )
 
 var getCmd = &cmdtree.Command{
-	Use:   "get [shell|millis|accent|toggles|width]",
+	Use:   "get [shell|millis|accent|toggles|width|background]",
 	Short: "Get a value from oh-my-posh",
 	Long: `Get a value from oh-my-posh.
 
@@ -24,13 +24,15 @@ This command is used to get the value of the following variables:
 - millis
 - accent
 - toggles
-- width`,
+- width
+- background`,
 	ValidArgs: []string{
 		"millis",
 		"shell",
 		"accent",
 		"toggles",
 		"width",
+		"background",
 		cache.TTL,
 	},
 	Args: NoArgsOrOneValidArg,
@@ -46,7 +48,8 @@ This command is used to get the value of the following variables:
 		}
 
 		flags := &runtime.Flags{
-			Shell: os.Getenv("POSH_SHELL"),
+			Shell:         os.Getenv("POSH_SHELL"),
+			QueryTerminal: args[0] == "background",
 		}
 
 		env := &runtime.Terminal{}
@@ -94,6 +97,9 @@ This command is used to get the value of the following variables:
 			for toggle := range togglesMap {
 				fmt.Println("- " + toggle)
 			}
+		case "background":
+			// asked once per session, the answer is cached for every prompt after
+			fmt.Print(env.TerminalBackground())
 		case cache.TTL:
 			fmt.Print(cache.GetTTL())
 		default:
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
+)
+
+var getOptionsCmd = &cmdtree.Command{
+	Use:   "options [segment]",
+	Short: "List the options a segment supports",
+	Long: `List the options a segment supports.
+
+Prints every option the segment accepts with its type, default value, description and,
+when restricted, the values it allows. Options not listed are reported as unknown when
+loading the config.
+
+Example usage:
+
+> oh-my-posh get options path`,
+	Args: cmdtree.ExactArgs(1),
+	Run: func(_ *cmdtree.Command, args []string) {
+		declarations, ok := config.SegmentOptions(config.SegmentType(args[0]))
+		if !ok {
+			fmt.Println("unknown segment:", args[0])
+			exitcode = 1
+			return
+		}
+
+		fmt.Print(formatOptions(declarations))
+	},
+}
+
+func formatOptions(declarations []options.Declaration) string {
+	if len(declarations) == 0 {
+		return "This segment has no options\n"
+	}
+
+	var builder strings.Builder
+
+	for i, declaration := range declarations {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+
+		name := string(declaration.Name)
+		if declaration.Name == options.Additional {
+			name = "<any other option>"
+		}
+
+		fmt.Fprintf(&builder, "%s (%s", name, declaration.Type)
+
+		if declaration.Default != nil {
+			fmt.Fprintf(&builder, ", default: %s", formatDefault(declaration.Default))
+		}
+
+		builder.WriteString(")\n")
+
+		if declaration.Description != "" {
+			fmt.Fprintf(&builder, "  %s\n", declaration.Description)
+		}
+
+		if len(declaration.Values) != 0 {
+			fmt.Fprintf(&builder, "  values: %s\n", strings.Join(declaration.Values, ", "))
+		}
+	}
+
+	return builder.String()
+}
+
+// formatDefault prints the default the way it's written in a json config,
+// keeping icons readable instead of escaping them.
+func formatDefault(value any) string {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+func init() {
+	getCmd.AddCommand(getOptionsCmd)
+}
@@ -22,6 +22,7 @@ var (
 	printOutput bool
 	strict      bool
 	debug       bool
+	protocol    bool
 
 	supportedShells = []string{
 		"bash",
@@ -34,6 +35,10 @@ var (
 		"elvish",
 		"xonsh",
 		"yash",
+		"osh",
+		"ysh",
+		"tcsh",
+		"shell",
 	}
 
 	initCmd = createInitCmd()
@@ -45,11 +50,14 @@ func init() {
 
 func createInitCmd() *cmdtree.Command {
 	initCmd := &cmdtree.Command{
-		Use:   "init [bash|zsh|fish|powershell|pwsh|cmd|nu|elvish|xonsh|yash]",
+		Use:   "init [bash|zsh|fish|powershell|pwsh|cmd|nu|elvish|xonsh|yash|osh|ysh|tcsh|shell]",
 		Short: "Initialize your shell and config",
 		Long: `Initialize your shell and config.
 
-See the documentation to initialize your shell: https://ohmyposh.dev/docs/installation/prompt.`,
+See the documentation to initialize your shell: https://ohmyposh.dev/docs/installation/prompt.
+
+Any other tool can embed Oh My Posh through the generic integration protocol, use "init shell --protocol"
+to print its manifest: https://ohmyposh.dev/docs/installation/protocol.`,
 		ValidArgs: supportedShells,
 		Args:      NoArgsOrOneValidArg,
 		Run: func(cmd *cmdtree.Command, args []string) {
@@ -66,6 +74,7 @@ See the documentation to initialize your shell: https://ohmyposh.dev/docs/instal
 	initCmd.Flags().BoolVarP(&strict, "strict", "s", false, "resolve the executable through PATH")
 	initCmd.Flags().BoolVar(&debug, "debug", false, "enable/disable debug mode")
 	initCmd.Flags().BoolVar(&eval, "eval", false, "output the full init script for eval")
+	initCmd.Flags().BoolVar(&protocol, "protocol", false, "print the generic integration protocol manifest")
 
 	_ = initCmd.MarkPersistentFlagRequired("config")
 
@@ -105,6 +114,7 @@ func runInit(sh, command string) {
 	env.Init(flags)
 
 	template.Init(env, cfg.Var, cfg.Maps)
+	template.Extend(cfg.TemplateExtensions())
 
 	defer func() {
 		cfg.Store()
@@ -120,6 +130,8 @@ func runInit(sh, command string) {
 	var output string
 
 	switch {
+	case protocol, sh == shell.GENERIC:
+		output = shell.Protocol(env, feats)
 	case debug:
 		output = shell.Debug(env, feats, &startTime)
 	case printOutput:
@@ -128,13 +140,16 @@ func runInit(sh, command string) {
 		output = shell.Init(env, feats)
 	}
 
-	shellDSC := dsc.ShellDSC()
-	shellDSC.Load()
-	shellDSC.Add(&dsc.Shell{
-		Command: command,
-		Name:    sh,
-	})
-	shellDSC.Save()
+	// a protocol client is a tool embedding the prompt, not a shell the user configured
+	if !protocol && sh != shell.GENERIC {
+		shellDSC := dsc.ShellDSC()
+		shellDSC.Load()
+		shellDSC.Add(&dsc.Shell{
+			Command: command,
+			Name:    sh,
+		})
+		shellDSC.Save()
+	}
 
 	if silent {
 		return
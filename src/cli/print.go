@@ -2,12 +2,16 @@ package cli
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
 	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/screen"
 	"github.com/jandedobbeleer/oh-my-posh/src/shell"
 	"github.com/jandedobbeleer/oh-my-posh/src/template"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cmdtree"
 )
@@ -18,6 +22,7 @@ var (
 	status        int
 	pipestatus    string
 	timing        float64
+	commandStart  float64
 	stackCount    int
 	terminalWidth int
 	eval          bool
@@ -32,6 +37,19 @@ var (
 	column       int
 	escape       bool
 	interrupted  bool
+
+	previewScreen bool
+	screenHeight  int
+
+	viModes []string
+)
+
+const (
+	// screenWidth is the width preview --screen draws at when neither --terminal-width
+	// nor the terminal itself says otherwise.
+	screenWidth = 80
+	// screenCommand is what preview --screen types at the prompt without --command.
+	screenCommand = "git status"
 )
 
 var printCmd = createPrintCmd()
@@ -69,6 +87,19 @@ func createPrintCmd() *cmdtree.Command {
 				shellName = shell.GENERIC
 			}
 
+			// The screen is drawn the way a shell without a native rprompt draws it, the rprompt
+			// written on the prompt's own line, as that's the one typing can run into.
+			if previewScreen && args[0] == prompt.PREVIEW {
+				shellName = shell.GENERIC
+				terminal.CaptureRuns = true
+			}
+
+			// a shell without a clock of its own only records when the
+			// command started, the render is when it finished
+			if commandStart > 0 {
+				timing = float64(time.Now().UnixMilli()) - commandStart
+			}
+
 			flags := &runtime.Flags{
 				ConfigPath:    configFlag,
 				PWD:           pwd,
@@ -93,7 +124,15 @@ func createPrintCmd() *cmdtree.Command {
 				Interrupted:   interrupted,
 			}
 
-			if err := applyDataFile(flags, cmd.Flags().Changed); err != nil {
+			data, err := loadDataFile()
+			if err != nil {
+				exitcode = 666
+				fmt.Println(err.Error())
+				return
+			}
+
+			frames, err := printFrames(data)
+			if err != nil {
 				exitcode = 666
 				fmt.Println(err.Error())
 				return
@@ -106,38 +145,31 @@ func createPrintCmd() *cmdtree.Command {
 
 			cache.Init(shellName, options...)
 
-			eng := prompt.New(flags)
-
 			defer func() {
 				template.SaveCache()
 				cache.Close()
 			}()
 
-			switch args[0] {
-			case prompt.DEBUG:
-				fmt.Print(eng.ExtraPrompt(prompt.Debug))
-			case prompt.PRIMARY:
-				fmt.Print(eng.Primary())
-			case prompt.SECONDARY:
-				fmt.Print(eng.ExtraPrompt(prompt.Secondary))
-			case prompt.TRANSIENT:
-				fmt.Print(eng.ExtraPrompt(prompt.Transient))
-			case prompt.TRANSIENT_RIGHT:
-				fmt.Print(eng.TransientRPrompt())
-			case prompt.RIGHT:
-				fmt.Print(eng.RPrompt())
-			case prompt.TOOLTIP:
-				fmt.Print(eng.Tooltip(command))
-			case prompt.VALID:
-				fmt.Print(eng.ExtraPrompt(prompt.Valid))
-			case prompt.ERROR:
-				fmt.Print(eng.ExtraPrompt(prompt.Error))
-			case prompt.PREVIEW:
-				fmt.Print(eng.Preview())
-			case prompt.CURSOR:
-				fmt.Print(eng.CursorStyle())
-			default:
-				_ = cmd.Help()
+			// Without --data there's a single frame to print, rendered from the flags alone. A
+			// recorded session prints every frame it holds, one below the other, each from a fresh
+			// environment and template cache so one frame's state never leaks into the next.
+			for i, frame := range frames {
+				frameFlags := *flags
+
+				if frame != nil {
+					if err := applyDataFrame(&frameFlags, frame, cmd.Flags().Changed); err != nil {
+						exitcode = 666
+						fmt.Println(err.Error())
+						return
+					}
+				}
+
+				if i > 0 {
+					template.ResetCache()
+					fmt.Println()
+				}
+
+				printPrompt(cmd, prompt.New(&frameFlags), args[0])
 			}
 		},
 	}
@@ -150,9 +182,10 @@ func createPrintCmd() *cmdtree.Command {
 	printCmd.Flags().BoolVar(&noStatus, "no-status", false, "no valid status code (cancelled or no command yet)")
 	printCmd.Flags().StringVar(&pipestatus, "pipestatus", "", "the PIPESTATUS array")
 	printCmd.Flags().Float64Var(&timing, "execution-time", 0, "timing of the last command")
+	printCmd.Flags().Float64Var(&commandStart, "start-time", 0, "start of the last command in milliseconds since the epoch, timing it up to now")
 	printCmd.Flags().IntVarP(&stackCount, "stack-count", "s", 0, "number of locations on the stack")
 	printCmd.Flags().IntVarP(&terminalWidth, "terminal-width", "w", 0, "width of the terminal")
-	printCmd.Flags().StringVar(&command, "command", "", "tooltip command")
+	printCmd.Flags().StringVar(&command, "command", "", "tooltip command, or the command preview --screen types at the prompt")
 	printCmd.Flags().BoolVar(&cleared, "cleared", false, "do we have a clear terminal or not")
 	printCmd.Flags().BoolVar(&eval, "eval", false, "output the prompt for eval")
 	printCmd.Flags().IntVar(&column, "column", 0, "the column position of the cursor")
@@ -162,9 +195,93 @@ func createPrintCmd() *cmdtree.Command {
 	printCmd.Flags().BoolVarP(&force, "force", "f", false, "force rendering the segments")
 	printCmd.Flags().StringVar(&dataPath, "data", "", "path to a template data file (json/yaml/toml) to render with")
 	printCmd.Flags().BoolVar(&interrupted, "interrupted", false, "the command was interrupted")
+	printCmd.Flags().BoolVar(&previewScreen, "screen", false, "draw preview onto a virtual terminal: the prompt, a typed command and the transient prompt")
+	printCmd.Flags().IntVar(&screenHeight, "screen-height", 0, "rows of the virtual terminal preview --screen draws on (default as many as it takes)")
+	printCmd.Flags().StringArrayVar(&viModes, "vi-mode", nil, "also print the primary prompt for this vi mode")
 
 	// Hide flags that are for internal use only.
 	_ = printCmd.Flags().MarkHidden("save-cache")
+	_ = printCmd.Flags().MarkHidden("vi-mode")
 
 	return printCmd
 }
+
+// printFrames returns the data file snapshots print renders: the one --frame selects, every frame
+// in order when it's unset, or a single nil frame when there's no data file at all.
+func printFrames(data *config.Data) ([]*config.Data, error) {
+	if data == nil {
+		return []*config.Data{nil}, nil
+	}
+
+	if dataFrame != defaultFrame {
+		frame, err := data.Frame(dataFrame)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*config.Data{frame}, nil
+	}
+
+	timeline := data.Timeline()
+	frames := make([]*config.Data, 0, len(timeline))
+
+	for i := range timeline {
+		frames = append(frames, &timeline[i].Data)
+	}
+
+	return frames, nil
+}
+
+func printPrompt(cmd *cmdtree.Command, eng *prompt.Engine, promptType string) {
+	switch promptType {
+	case prompt.DEBUG:
+		fmt.Print(eng.ExtraPrompt(prompt.Debug))
+	case prompt.PRIMARY:
+		fmt.Print(eng.Primary())
+
+		for _, mode := range viModes {
+			fmt.Print(renderVIMode(eng, mode))
+		}
+	case prompt.SECONDARY:
+		fmt.Print(eng.ExtraPrompt(prompt.Secondary))
+	case prompt.TRANSIENT:
+		fmt.Print(eng.ExtraPrompt(prompt.Transient))
+	case prompt.TRANSIENT_RIGHT:
+		fmt.Print(eng.TransientRPrompt())
+	case prompt.RIGHT:
+		fmt.Print(eng.RPrompt())
+	case prompt.TOOLTIP:
+		fmt.Print(eng.Tooltip(command))
+	case prompt.VALID:
+		fmt.Print(eng.ExtraPrompt(prompt.Valid))
+	case prompt.ERROR:
+		fmt.Print(eng.ExtraPrompt(prompt.Error))
+	case prompt.PREVIEW:
+		if previewScreen {
+			fmt.Print(printScreen(eng))
+			return
+		}
+
+		fmt.Print(eng.Preview())
+	case prompt.CURSOR:
+		fmt.Print(eng.CursorStyle())
+	default:
+		_ = cmd.Help()
+	}
+}
+
+// printScreen draws eng's session onto a virtual terminal as wide as the real one, see
+// screen.Preview.
+func printScreen(eng *prompt.Engine) *screen.Screen {
+	width, err := eng.Env.TerminalWidth()
+	if err != nil || width <= 0 {
+		width = screenWidth
+	}
+
+	typed := command
+	if typed == "" {
+		typed = screenCommand
+	}
+
+	return screen.Preview(eng, width, screenHeight, typed)
+}
@@ -0,0 +1,859 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/path"
+	"github.com/jandedobbeleer/oh-my-posh/src/text"
+
+	toml "github.com/pelletier/go-toml/v2"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// redactKind says what a redacted value is, and so what it's replaced with.
+type redactKind string
+
+const (
+	// redactUser replaces a user name with a person's first name.
+	redactUser redactKind = "user"
+	// redactHost replaces a machine name with a made up one.
+	redactHost redactKind = "host"
+	// redactName replaces any other name - a cluster, a profile, a project - with a word.
+	redactName redactKind = "name"
+	// redactPath replaces every component of a path, keeping its separators and the well-known
+	// system directories, so ~/dev/secret stays a path three levels deep under the home folder.
+	redactPath redactKind = "path"
+	// redactEmail replaces an email address with a user at an example domain.
+	redactEmail redactKind = "email"
+	// redactURL replaces the path, and the host unless it's a public forge, of a URL or an scp
+	// style git remote. A public host stays, the git segment picks its upstream icon from it.
+	redactURL redactKind = "url"
+	// redactID replaces every letter and digit of an identifier, keeping its shape: an account
+	// number stays twelve digits, a GUID stays a GUID.
+	redactID redactKind = "id"
+	// redactClear replaces a value with the zero value of its type.
+	redactClear redactKind = "clear"
+	// redactDrop removes a value altogether.
+	redactDrop redactKind = "drop"
+	// redactKeep leaves a value alone, for a user profile to switch off a rule it extends.
+	redactKeep redactKind = "keep"
+	// redactFixedPrefix starts a kind that replaces a value with the same fixed one, always: the
+	// JSON after it, "=70" or "=\"alice\"".
+	redactFixedPrefix = "="
+)
+
+// redactFixed is the kind that replaces a value with value.
+func redactFixed(value any) redactKind {
+	raw, _ := json.Marshal(value)
+	return redactKind(redactFixedPrefix + string(raw))
+}
+
+// redactAnySegment keys the rules every segment gets, whatever its type.
+const redactAnySegment = "*"
+
+// redactProfile is a set of redaction rules: for the env section and for every segment type, the
+// fields to redact and what they hold. A field is a dot separated path into the recorded data,
+// where * matches every key of a map or every element of a list: Folders.*.Path.
+type redactProfile struct {
+	Env      map[string]redactKind            `json:"env,omitempty"`
+	Segments map[string]map[string]redactKind `json:"segments,omitempty"`
+	// Extends names the built-in profile a user profile adds its rules to.
+	Extends string `json:"extends,omitempty"`
+}
+
+// redactProfiles are the built-in profiles --redact takes by name. default covers the identity a
+// prompt shows: who and where you are, your repositories and your cloud accounts. strict also
+// covers what only shows up in a template that goes looking for it. sanitize is --sanitize, see
+// sanitizeProfile.
+var redactProfiles = map[string]*redactProfile{
+	sanitizeProfileName: sanitizeProfile,
+	"default": {
+		Env: map[string]redactKind{
+			"UserName":    redactUser,
+			"HostName":    redactHost,
+			"PWD":         redactPath,
+			"PSWD":        redactPath,
+			"AbsolutePWD": redactPath,
+			"Folder":      redactPath,
+		},
+		Segments: map[string]map[string]redactKind{
+			redactAnySegment: {
+				// the rendered text embeds whatever the template showed, and is rendered again on replay
+				"Segment.Text": redactClear,
+				"Text":         redactClear,
+			},
+			string(config.GIT): {
+				"Dir":            redactPath,
+				"RelativeDir":    redactPath,
+				"MainWorktree":   redactPath,
+				"RepoName":       redactPath,
+				"UpstreamURL":    redactURL,
+				"RawUpstreamURL": redactURL,
+				"Kraken":         redactURL,
+				"Remotes.*":      redactURL,
+				"User.Name":      redactUser,
+				"User.Email":     redactEmail,
+			},
+			string(config.PATH): {
+				"Path":           redactPath,
+				"Location":       redactPath,
+				"Parent":         redactPath,
+				"Folders.*.Path": redactPath,
+				"Folders.*.Name": redactPath,
+			},
+			string(config.AWS): {
+				"AccountID":   redactID,
+				"AccessKeyID": redactClear,
+				"Profile":     redactName,
+				"Settings":    redactClear,
+				"SSOSession":  redactClear,
+			},
+			string(config.AZ): {
+				"id":                redactID,
+				"tenantId":          redactID,
+				"homeTenantId":      redactID,
+				"name":              redactName,
+				"tenantDisplayName": redactName,
+				"user.name":         redactEmail,
+			},
+			string(config.KUBECTL): {
+				"Context":   redactName,
+				"Cluster":   redactName,
+				"Namespace": redactName,
+				"User":      redactUser,
+			},
+			string(config.GCP): {
+				"Account":      redactEmail,
+				"Project":      redactName,
+				"ActiveConfig": redactName,
+			},
+			string(config.DOCKER): {
+				"Context": redactName,
+			},
+			string(config.PYTHON): {
+				"Venv": redactName,
+			},
+		},
+	},
+	"strict": {
+		Extends: "default",
+		Segments: map[string]map[string]redactKind{
+			redactAnySegment: {
+				"Executable": redactPath,
+			},
+			string(config.GIT): {
+				"HEAD":      redactClear,
+				"Ref":       redactName,
+				"Upstream":  redactName,
+				"LatestTag": redactName,
+			},
+			string(config.SYSTEMINFO): {
+				"Disks": redactClear,
+			},
+			string(config.IPIFY): {
+				"IP": redactID,
+			},
+		},
+	},
+}
+
+// loadRedactProfile resolves --redact: the name of a built-in profile, or the path to a JSON, YAML
+// or TOML file with a profile of its own. A profile file that extends a built-in one gets its
+// rules, with its own winning for the same field.
+func loadRedactProfile(name string) (*redactProfile, error) {
+	if profile, ok := redactProfiles[name]; ok {
+		return resolveRedactProfile(profile)
+	}
+
+	raw, err := os.ReadFile(path.ReplaceTildePrefixWithHomeDir(name))
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither a built-in redaction profile (%s) nor a readable profile file: %w",
+			name, strings.Join(redactProfileNames(), ", "), err)
+	}
+
+	profile, err := parseRedactProfile(strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), "."), raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redaction profile %s: %w", name, err)
+	}
+
+	if _, ok := redactProfiles[profile.Extends]; profile.Extends != "" && !ok {
+		return nil, fmt.Errorf("redaction profile %s extends %s, which is not a built-in profile (%s)",
+			name, profile.Extends, strings.Join(redactProfileNames(), ", "))
+	}
+
+	return resolveRedactProfile(profile)
+}
+
+func parseRedactProfile(ext string, raw []byte) (*redactProfile, error) {
+	var generic any
+
+	switch ext {
+	case config.JSON, config.JSONC:
+		if err := json.Unmarshal([]byte(text.StripJSONComments(string(raw))), &generic); err != nil {
+			return nil, err
+		}
+	case config.YAML, config.YML:
+		if err := yaml.Unmarshal(raw, &generic); err != nil {
+			return nil, err
+		}
+	case config.TOML, config.TML:
+		if err := toml.Unmarshal(raw, &generic); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported profile file extension: %s", ext)
+	}
+
+	// every format decodes to the same generic shape, which JSON maps onto the profile
+	normalized, err := json.Marshal(generic)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile redactProfile
+	if err := json.Unmarshal(normalized, &profile); err != nil {
+		return nil, err
+	}
+
+	for field, kind := range profile.Env {
+		if err := kind.validate("env", field); err != nil {
+			return nil, err
+		}
+	}
+
+	for segment, fields := range profile.Segments {
+		for field, kind := range fields {
+			if err := kind.validate(segment, field); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &profile, nil
+}
+
+func (k redactKind) validate(section, field string) error {
+	switch k {
+	case redactUser, redactHost, redactName, redactPath, redactEmail, redactURL, redactID, redactClear, redactDrop, redactKeep:
+		return nil
+	}
+
+	if _, ok := k.fixedValue(); ok {
+		return nil
+	}
+
+	return fmt.Errorf("unknown redaction %q for %s.%s", k, section, field)
+}
+
+// fixedValue is the value a kind made by redactFixed replaces every value with.
+func (k redactKind) fixedValue() (any, bool) {
+	raw, ok := strings.CutPrefix(string(k), redactFixedPrefix)
+	if !ok {
+		return nil, false
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (k redactKind) isIdentity() bool {
+	return k == redactUser || k == redactHost
+}
+
+// resolveRedactProfile merges profile over the built-in profile it extends, recursively.
+func resolveRedactProfile(profile *redactProfile) (*redactProfile, error) {
+	resolved := &redactProfile{
+		Env:      map[string]redactKind{},
+		Segments: map[string]map[string]redactKind{},
+	}
+
+	if profile.Extends != "" {
+		base, ok := redactProfiles[profile.Extends]
+		if !ok {
+			return nil, fmt.Errorf("unknown redaction profile %s", profile.Extends)
+		}
+
+		parent, err := resolveRedactProfile(base)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved = parent
+	}
+
+	for field, kind := range profile.Env {
+		resolved.Env[field] = kind
+	}
+
+	for segment, fields := range profile.Segments {
+		if resolved.Segments[segment] == nil {
+			resolved.Segments[segment] = map[string]redactKind{}
+		}
+
+		for field, kind := range fields {
+			resolved.Segments[segment][field] = kind
+		}
+	}
+
+	return resolved, nil
+}
+
+func redactProfileNames() []string {
+	names := make([]string, 0, len(redactProfiles))
+	for name := range redactProfiles {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// redactDataDocument re-parses a data document built by buildDataDocument and replaces every value
+// profile covers with a pseudonym, returning the re-marshaled bytes. Pseudonyms are consistent
+// across the whole document: the same user name becomes the same pseudonym in env.UserName, in a
+// path under the home folder and in the kubectl user, so the replayed prompt still hangs together.
+// cfg supplies each segment's configured type, keyed by DataKey, so the rules survive an alias.
+func redactDataDocument(doc []byte, cfg *config.Config, profile *redactProfile) ([]byte, error) {
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return nil, err
+	}
+
+	typeByKey := make(map[string]config.SegmentType)
+
+	for _, block := range cfg.Blocks {
+		for _, segment := range block.Segments {
+			typeByKey[segment.DataKey()] = segment.Type
+		}
+	}
+
+	p := newPseudonyms()
+
+	// env first: it holds the user and host name, which paths and URLs in the segments reuse
+	if envRaw, ok := root[config.DataEnvKey]; ok {
+		var env map[string]any
+		if err := json.Unmarshal(envRaw, &env); err != nil {
+			return nil, err
+		}
+
+		p.redactFields(env, profile.Env)
+
+		raw, err := json.Marshal(env)
+		if err != nil {
+			return nil, err
+		}
+
+		root[config.DataEnvKey] = raw
+	}
+
+	if segmentsRaw, ok := root[config.DataSegmentsKey]; ok {
+		var segments map[string]json.RawMessage
+		if err := json.Unmarshal(segmentsRaw, &segments); err != nil {
+			return nil, err
+		}
+
+		// sorted, so the same recording always gets the same pseudonyms
+		keys := make([]string, 0, len(segments))
+		for key := range segments {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			rules := segmentRedactRules(profile, typeByKey[key])
+
+			redacted, err := redactRecordedSegment(segments[key], rules, p)
+			if err != nil {
+				return nil, fmt.Errorf("failed to redact segment %s: %w", key, err)
+			}
+
+			segments[key] = redacted
+		}
+
+		raw, err := json.Marshal(segments)
+		if err != nil {
+			return nil, err
+		}
+
+		root[config.DataSegmentsKey] = raw
+	}
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// segmentRedactRules is every rule for segType, the ones for any segment included.
+func segmentRedactRules(profile *redactProfile, segType config.SegmentType) map[string]redactKind {
+	rules := make(map[string]redactKind)
+
+	for field, kind := range profile.Segments[redactAnySegment] {
+		rules[field] = kind
+	}
+
+	for field, kind := range profile.Segments[string(segType)] {
+		rules[field] = kind
+	}
+
+	return rules
+}
+
+// redactRecordedSegment redacts a RecordedSegment envelope's data and its method results alike:
+// both are shaped like the writer, so the same field paths find the same values in either.
+func redactRecordedSegment(raw json.RawMessage, rules map[string]redactKind, p *pseudonyms) (json.RawMessage, error) {
+	var envelope config.RecordedSegment
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, err
+	}
+
+	redact := func(section json.RawMessage) (json.RawMessage, error) {
+		var data map[string]any
+		if len(section) == 0 || json.Unmarshal(section, &data) != nil || data == nil {
+			// a scalar writer has no fields for a rule to name
+			return section, nil
+		}
+
+		p.redactFields(data, rules)
+
+		return json.Marshal(data)
+	}
+
+	var err error
+
+	if envelope.Data, err = redact(envelope.Data); err != nil {
+		return nil, err
+	}
+
+	if envelope.Methods, err = redact(envelope.Methods); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(envelope)
+}
+
+// pseudonyms hands out consistent replacements: every kind of value keeps its own table of the
+// originals it has seen, so the same original always gets the same pseudonym, and no two
+// originals of a kind ever share one.
+type pseudonyms struct {
+	seen  map[redactKind]map[string]string
+	taken map[redactKind]map[string]bool
+}
+
+func newPseudonyms() *pseudonyms {
+	return &pseudonyms{
+		seen:  map[redactKind]map[string]string{},
+		taken: map[redactKind]map[string]bool{},
+	}
+}
+
+// The words pseudonyms are picked from, looking like the values they replace.
+var (
+	pseudonymUsers = []string{
+		"alice", "bob", "carol", "dave", "erin", "frank", "grace", "heidi",
+		"ivan", "judy", "mallory", "niaj", "olivia", "peggy", "rupert", "sybil",
+	}
+	pseudonymHosts = []string{
+		"contoso-devbox", "fabrikam-laptop", "northwind-ws", "tailspin-vm",
+		"wingtip-build", "adatum-server", "litware-mac", "proseware-pc",
+	}
+	pseudonymNames = []string{
+		"aurora", "borealis", "cascade", "delta", "ember", "falcon", "glacier", "harbor",
+		"island", "juniper", "kestrel", "lagoon", "meadow", "nebula", "orchard", "prairie",
+	}
+	pseudonymFolders = []string{
+		"projects", "workspace", "app", "service", "website", "backend", "frontend", "tools",
+		"library", "api", "client", "server", "docs", "scripts", "infra", "sandbox",
+	}
+)
+
+// systemPathComponents are kept as they are in a path: they're the same on every machine, and
+// what makes a redacted path still read like a home folder or a system one.
+var systemPathComponents = map[string]bool{
+	"": true, "~": true, ".": true, "..": true,
+	"home": true, "users": true, "root": true, "usr": true, "local": true, "bin": true, "sbin": true,
+	"opt": true, "var": true, "tmp": true, "etc": true, "lib": true, "mnt": true, "dev": true,
+	"src": true, "program files": true, "program files (x86)": true, "windows": true, "appdata": true,
+	"documents": true, "desktop": true, "downloads": true, ".config": true, ".local": true,
+}
+
+// publicHosts are the forges a URL keeps its host for.
+var publicHosts = map[string]bool{
+	"github.com": true, "gitlab.com": true, "bitbucket.org": true, "dev.azure.com": true,
+	"ssh.dev.azure.com": true, "vs-ssh.visualstudio.com": true, "codeberg.org": true,
+	"git.sr.ht": true, "sourceforge.net": true,
+}
+
+// redactFields applies rules to data in place.
+func (p *pseudonyms) redactFields(data map[string]any, rules map[string]redactKind) {
+	fields := make([]string, 0, len(rules))
+	for field := range rules {
+		fields = append(fields, field)
+	}
+
+	// Sorted, so the same recording always gets the same pseudonyms. User and host names go
+	// first: a path or an URL reuses their pseudonyms for a component that is one of them.
+	slices.SortFunc(fields, func(a, b string) int {
+		if first, other := rules[a].isIdentity(), rules[b].isIdentity(); first != other {
+			if first {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	for _, field := range fields {
+		p.redactAt(data, strings.Split(field, "."), rules[field])
+	}
+}
+
+// redactAt walks fieldPath down value's maps and lists and redacts what it ends on.
+func (p *pseudonyms) redactAt(value any, fieldPath []string, kind redactKind) {
+	key, rest := fieldPath[0], fieldPath[1:]
+
+	switch container := value.(type) {
+	case map[string]any:
+		keys := []string{key}
+		if key == "*" {
+			keys = sortedKeys(container)
+		}
+
+		for _, k := range keys {
+			child, ok := container[k]
+			if !ok {
+				continue
+			}
+
+			if len(rest) != 0 {
+				p.redactAt(child, rest, kind)
+				continue
+			}
+
+			if kind == redactDrop {
+				delete(container, k)
+				continue
+			}
+
+			container[k] = p.redactValue(child, kind)
+		}
+	case []any:
+		if key != "*" {
+			return
+		}
+
+		for i := range container {
+			if len(rest) != 0 {
+				p.redactAt(container[i], rest, kind)
+				continue
+			}
+
+			container[i] = p.redactValue(container[i], kind)
+		}
+	}
+}
+
+// redactValue replaces value, or every string in it when it's a map or a list.
+func (p *pseudonyms) redactValue(value any, kind redactKind) any {
+	switch kind {
+	case redactKeep:
+		return value
+	case redactClear, redactDrop:
+		return zeroValue(value)
+	}
+
+	if fixed, ok := kind.fixedValue(); ok {
+		return fixed
+	}
+
+	switch v := value.(type) {
+	case string:
+		return p.replace(v, kind)
+	case map[string]any:
+		for _, k := range sortedKeys(v) {
+			v[k] = p.redactValue(v[k], kind)
+		}
+
+		return v
+	case []any:
+		for i := range v {
+			v[i] = p.redactValue(v[i], kind)
+		}
+
+		return v
+	default:
+		return value
+	}
+}
+
+func zeroValue(value any) any {
+	switch value.(type) {
+	case string:
+		return ""
+	case float64:
+		return 0
+	case bool:
+		return false
+	case map[string]any:
+		return map[string]any{}
+	case []any:
+		return []any{}
+	default:
+		return nil
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// replace returns the pseudonym of value as a kind of value.
+func (p *pseudonyms) replace(value string, kind redactKind) string {
+	if value == "" {
+		return ""
+	}
+
+	switch kind {
+	case redactUser:
+		return p.pick(redactUser, value, pseudonymUsers)
+	case redactHost:
+		return p.pick(redactHost, value, pseudonymHosts)
+	case redactName:
+		return p.pick(redactName, value, pseudonymNames)
+	case redactPath:
+		return p.path(value)
+	case redactEmail:
+		return p.email(value)
+	case redactURL:
+		return p.url(value)
+	case redactID:
+		return p.id(value)
+	default:
+		return value
+	}
+}
+
+// pick returns value's pseudonym from words: the word value hashes to, or the next free one when
+// another value took it first. Once every word is taken, they repeat with a number.
+func (p *pseudonyms) pick(kind redactKind, value string, words []string) string {
+	if pseudonym, ok := p.seen[kind][value]; ok {
+		return pseudonym
+	}
+
+	if p.seen[kind] == nil {
+		p.seen[kind] = map[string]string{}
+		p.taken[kind] = map[string]bool{}
+	}
+
+	start := int(hashValue(kind, value) % uint64(len(words)))
+
+	for i := 0; ; i++ {
+		pseudonym := words[(start+i)%len(words)]
+		if round := i / len(words); round > 0 {
+			pseudonym += strconv.Itoa(round + 1)
+		}
+
+		if p.taken[kind][pseudonym] {
+			continue
+		}
+
+		p.seen[kind][value] = pseudonym
+		p.taken[kind][pseudonym] = true
+
+		return pseudonym
+	}
+}
+
+// known returns the pseudonym kind already gave value, if it did.
+func (p *pseudonyms) known(kind redactKind, value string) (string, bool) {
+	pseudonym, ok := p.seen[kind][value]
+	return pseudonym, ok
+}
+
+var pathSeparators = regexp.MustCompile(`[/\\]`)
+
+// path replaces every component of value apart from the system ones. A component that is the user
+// or host name already redacted elsewhere gets that same pseudonym, so a home folder follows the
+// user it belongs to.
+func (p *pseudonyms) path(value string) string {
+	separators := pathSeparators.FindAllString(value, -1)
+	components := pathSeparators.Split(value, -1)
+
+	var b strings.Builder
+
+	for i, component := range components {
+		b.WriteString(p.pathComponent(component))
+
+		if i < len(separators) {
+			b.WriteString(separators[i])
+		}
+	}
+
+	return b.String()
+}
+
+func (p *pseudonyms) pathComponent(component string) string {
+	if systemPathComponents[strings.ToLower(component)] {
+		return component
+	}
+
+	// a drive letter
+	if len(component) == 2 && component[1] == ':' {
+		return component
+	}
+
+	if pseudonym, ok := p.known(redactUser, component); ok {
+		return pseudonym
+	}
+
+	if pseudonym, ok := p.known(redactHost, component); ok {
+		return pseudonym
+	}
+
+	// a repository's .git suffix, or a file's extension, is part of what it looks like
+	name, ext := component, ""
+	if i := strings.LastIndexByte(component, '.'); i > 0 {
+		name, ext = component[:i], component[i:]
+	}
+
+	return p.pick(redactPath, name, pseudonymFolders) + ext
+}
+
+func (p *pseudonyms) email(value string) string {
+	local, _, ok := strings.Cut(value, "@")
+	if !ok {
+		return p.replace(value, redactUser)
+	}
+
+	return p.replace(local, redactUser) + "@contoso.com"
+}
+
+var scpRemote = regexp.MustCompile(`^(?:([^@/]+)@)?([^:/]+):(.*)$`)
+
+// url replaces the user, the path and a private host of value, an URL or an scp style remote such
+// as git@github.com:owner/repo.git.
+func (p *pseudonyms) url(value string) string {
+	if !strings.Contains(value, "://") {
+		match := scpRemote.FindStringSubmatch(value)
+		if match == nil {
+			return p.path(value)
+		}
+
+		remote := p.host(match[2]) + ":" + p.path(match[3])
+		if match[1] != "" {
+			remote = p.urlUser(match[1]) + "@" + remote
+		}
+
+		return remote
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return p.path(value)
+	}
+
+	if u.User != nil {
+		u.User = url.User(p.urlUser(u.User.Username()))
+	}
+
+	host := p.host(u.Hostname())
+	if port := u.Port(); port != "" {
+		host += ":" + port
+	}
+
+	u.Host = host
+	u.Path = p.path(u.Path)
+	u.RawPath = ""
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String()
+}
+
+// urlUser keeps the generic account a git remote connects as.
+func (p *pseudonyms) urlUser(user string) string {
+	if user == "git" || user == "ssh" {
+		return user
+	}
+
+	return p.replace(user, redactUser)
+}
+
+func (p *pseudonyms) host(host string) string {
+	if publicHosts[strings.ToLower(host)] {
+		return host
+	}
+
+	return p.replace(host, redactHost) + ".example.com"
+}
+
+// id replaces every digit and letter of value with one derived from its hash, keeping the case.
+func (p *pseudonyms) id(value string) string {
+	if pseudonym, ok := p.known(redactID, value); ok {
+		return pseudonym
+	}
+
+	state := hashValue(redactID, value)
+	next := func(n uint64) uint64 {
+		// xorshift, seeded from the value, so the same id always gets the same pseudonym
+		state ^= state << 13
+		state ^= state >> 7
+		state ^= state << 17
+
+		return state % n
+	}
+
+	var b strings.Builder
+
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteByte(byte('0' + next(10)))
+		case r >= 'a' && r <= 'z':
+			b.WriteByte(byte('a' + next(26)))
+		case r >= 'A' && r <= 'Z':
+			b.WriteByte(byte('A' + next(26)))
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	if p.seen[redactID] == nil {
+		p.seen[redactID] = map[string]string{}
+	}
+
+	p.seen[redactID][value] = b.String()
+
+	return b.String()
+}
+
+func hashValue(kind redactKind, value string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(kind))
+	h.Write([]byte{0})
+	h.Write([]byte(value))
+
+	return h.Sum64()
+}
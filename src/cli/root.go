@@ -127,6 +127,5 @@ func init() {
 	// Hide flags that are deprecated or for internal use only.
 	_ = RootCmd.PersistentFlags().MarkHidden("silent")
 
-	// Disable completions
-	RootCmd.CompletionOptions.DisableDefaultCmd = true
+	_ = RootCmd.RegisterFlagCompletionFunc("config", completeThemes)
 }
@@ -20,6 +20,10 @@ import (
 // prompts (fish).
 var requestPipe string
 
+// Set for shells that consume stdout line by line and cannot split on NUL
+// (nu): every record is written as a JSON string on its own line instead.
+var jsonRecords bool
+
 var serveCmd = createServeCmd()
 
 func init() {
@@ -56,6 +60,12 @@ type serveRequest struct {
 	// up unread in the pipe buffer, and a full pipe blocks the record copier,
 	// which stopActiveCycle waits on.
 	Wait bool `json:"wait"`
+	// VIModes asks a Wait render for one more primary prompt per vi mode,
+	// see renderVIMode. For shells that can't render the prompt again when
+	// the mode changes (bash hands them to readline as its mode strings).
+	VIModes []string `json:"vi-modes"`
+	// Capabilities is only sent with a hello request, see negotiate.
+	Capabilities *serveCapabilities `json:"capabilities"`
 }
 
 const (
@@ -71,6 +81,11 @@ const (
 // stdout: "<id>\x1f<payload>\x00". \x1f is the ASCII unit separator.
 const serveIDMarker = "\x1f"
 
+// viModeMarker surrounds the vi mode a prompt was rendered for:
+// "\x1d<mode>\x1d<payload>", in a serve record or after the primary prompt
+// print primary --vi-mode writes. \x1d is the ASCII group separator.
+const viModeMarker = "\x1d"
+
 func createServeCmd() *cmdtree.Command {
 	serveCmd := &cmdtree.Command{
 		Use:    "serve",
@@ -105,9 +120,11 @@ func createServeCmd() *cmdtree.Command {
 
 	serveCmd.Flags().StringVar(&shellName, "shell", "", "the shell to serve for")
 	serveCmd.Flags().StringVar(&requestPipe, "request-pipe", "", "named pipe (fifo) to read requests from instead of stdin")
+	serveCmd.Flags().BoolVar(&jsonRecords, "json-records", false, "write every record as a JSON string on its own line instead of NUL terminated")
 
 	// Hide flags that are for internal use only.
 	_ = serveCmd.Flags().MarkHidden("request-pipe")
+	_ = serveCmd.Flags().MarkHidden("json-records")
 
 	return serveCmd
 }
@@ -165,6 +182,10 @@ func runServeLoop(in, out *os.File) bool {
 	var active *serveActiveCycle
 	renderedAtLeastOnce := false
 
+	// negotiated stays nil for the built-in shell scripts, a protocol client
+	// sets it with its hello request and gets typed records from then on.
+	var negotiated *serveCapabilities
+
 	// envKeys tracks which variables the previous request's env blob set, so
 	// a variable that disappears from a later request (e.g. VIRTUAL_ENV after
 	// `deactivate`, or anything a client stops forwarding) gets unset instead
@@ -237,9 +258,14 @@ func runServeLoop(in, out *os.File) bool {
 				// template.Init may never have run, in which case the shutdown
 				// path must not call template.SaveCache (it dereferences state
 				// only Init sets). A started cycle implies Init completed.
-				if active = startRenderCycle(&req, out, envKeys); active != nil {
+				if active = startRenderCycle(&req, out, envKeys, negotiated); active != nil {
 					renderedAtLeastOnce = true
 				}
+			case serveCommandHello:
+				// The reply shares stdout with the cycle's records.
+				stopActiveCycle()
+				negotiated = negotiate(&req)
+				fmt.Fprint(out, formatRecord(req.ID, helloRecord(negotiated)))
 			case serveCommandAbort:
 				stopActiveCycle()
 			case serveCommandQuit:
@@ -321,7 +347,7 @@ func applyEnvOverlay(env map[string]string, keys map[string]struct{}) {
 // A panic while setting up the cycle (e.g. in prompt.New) is recovered and
 // reported as "no cycle": the daemon stays alive, the shell's waiter times
 // out and falls back to the legacy path for that prompt.
-func startRenderCycle(req *serveRequest, out *os.File, envKeys map[string]struct{}) (cycle *serveActiveCycle) {
+func startRenderCycle(req *serveRequest, out *os.File, envKeys map[string]struct{}, negotiated *serveCapabilities) (cycle *serveActiveCycle) {
 	defer func() {
 		if r := recover(); r != nil {
 			cycle = nil
@@ -365,10 +391,11 @@ func startRenderCycle(req *serveRequest, out *os.File, envKeys map[string]struct
 	// runtime state, and goroutines abandoned by an aborted cycle still hold
 	// pointers into their own cycle's graph - a shared graph would let them
 	// race the active render.
-	shellName := req.Shell
-	if shellName == "" {
-		shellName = shell.GENERIC
-	}
+	sh := requestShell(req)
+
+	// A protocol client that can't handle updates gets a single, final
+	// primary prompt.
+	wait := req.Wait || (negotiated != nil && !negotiated.streaming())
 
 	flags := &runtime.Flags{
 		ConfigPath:    configFlag,
@@ -379,7 +406,7 @@ func startRenderCycle(req *serveRequest, out *os.File, envKeys map[string]struct
 		ExecutionTime: req.ExecutionTime,
 		StackCount:    req.StackCount,
 		TerminalWidth: req.TerminalWidth,
-		Shell:         shellName,
+		Shell:         sh,
 		ShellVersion:  req.ShellVersion,
 		Plain:         plain,
 		Type:          prompt.PRIMARY,
@@ -388,35 +415,59 @@ func startRenderCycle(req *serveRequest, out *os.File, envKeys map[string]struct
 		JobCount:      req.JobCount,
 		IsPrimary:     true,
 		Escape:        true,
-		Streaming:     !req.Wait,
+		Streaming:     !wait,
 	}
 
 	eng := prompt.New(flags)
 
+	if negotiated != nil {
+		eng.SeparateRPrompt = negotiated.has(prompt.RIGHT)
+		eng.Config.ShellIntegration = negotiated.Marks
+	}
+
 	var records <-chan string
-	if req.Wait {
-		records = renderComplete(eng)
+	if wait {
+		records = renderComplete(eng, req.VIModes)
 	} else {
 		records = eng.StreamPrimary()
 	}
 
+	if negotiated != nil {
+		records = typedRecords(eng, records, negotiated)
+	}
+
 	return &serveActiveCycle{
 		engine:     eng,
 		copierDone: copyRecords(req.ID, records, out),
 	}
 }
 
+// requestShell is the shell a request renders for, the daemon's own --shell
+// when the request doesn't name one.
+func requestShell(req *serveRequest) string {
+	switch {
+	case req.Shell != "":
+		return req.Shell
+	case shellName != "":
+		return shellName
+	default:
+		return shell.GENERIC
+	}
+}
+
 // renderComplete produces the two records of a Wait render: the fully
 // resolved primary prompt (Streaming is off, so segments block until done,
 // bounded by their regular timeouts - print primary semantics) and the
 // transient prompt. Rendered in a single goroutine for the same
-// thread-safety reasons as StreamPrimary.
+// thread-safety reasons as StreamPrimary. A record per vi mode the client
+// asked for goes in between, see renderVIMode.
 //
-// Exactly two records are emitted even when the render panics: wait-mode
-// clients (Clink) block-read both records with no timeout mechanism, so a
-// short reply would leave them hung on a silent daemon. An empty primary
-// tells the client to fall back to its one-shot path for this prompt.
-func renderComplete(eng *prompt.Engine) <-chan string {
+// The primary and transient records are emitted even when the render
+// panics: wait-mode clients (Clink) block-read both records with no timeout
+// mechanism, so a short reply would leave them hung on a silent daemon. An
+// empty primary tells the client to fall back to its one-shot path for this
+// prompt, a missing vi mode record to render that mode itself.
+func renderComplete(eng *prompt.Engine, viModes []string) <-chan string {
 	records := make(chan string, 2)
 
 	go func() {
@@ -439,13 +490,28 @@ func renderComplete(eng *prompt.Engine) <-chan string {
 
 		records <- eng.Primary()
 		sent++
-		records <- prompt.TransientMarker + eng.ExtraPrompt(prompt.Transient)
+
+		// the vi mode renders leave the vimode segment's data for their mode
+		// in the template cache
+		transient := eng.ExtraPrompt(prompt.Transient)
+
+		for _, mode := range viModes {
+			records <- renderVIMode(eng, mode)
+		}
+
+		records <- prompt.TransientMarker + transient
 		sent++
 	}()
 
 	return records
 }
 
+// renderVIMode renders eng's primary prompt again for the vi mode, see
+// prompt.Engine.PrimaryVIMode, prefixed with the mode it's for.
+func renderVIMode(eng *prompt.Engine, mode string) string {
+	return viModeMarker + mode + viModeMarker + eng.PrimaryVIMode(mode)
+}
+
 // copyRecords copies prompt records to out prefixed with the cycle id and
 // closes the returned channel once the source channel is exhausted.
 func copyRecords(id int64, records <-chan string, out *os.File) chan struct{} {
@@ -463,7 +529,7 @@ func copyRecords(id int64, records <-chan string, out *os.File) chan struct{} {
 			// terminates the daemon - the desired lifecycle when the shell
 			// disappears without sending quit, and the only exit signal in
 			// the request-pipe transport where stdin EOF never arrives.
-			fmt.Fprintf(out, "%d%s%s\x00", id, serveIDMarker, record)
+			fmt.Fprint(out, formatRecord(id, record))
 		}
 
 		// Deliberately no cache persistence here: unlike stream/print
@@ -478,3 +544,16 @@ func copyRecords(id int64, records <-chan string, out *os.File) chan struct{} {
 
 	return done
 }
+
+// formatRecord frames a record as "<id>\x1f<payload>\x00", or as that same
+// string JSON encoded on a single line when --json-records is set.
+func formatRecord(id int64, record string) string {
+	framed := fmt.Sprintf("%d%s%s", id, serveIDMarker, record)
+	if !jsonRecords {
+		return framed + "\x00"
+	}
+
+	encoded, _ := json.Marshal(framed)
+
+	return string(encoded) + "\n"
+}
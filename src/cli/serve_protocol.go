@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/shell"
+)
+
+// serveCommandHello negotiates the generic integration protocol, see
+// negotiate. The built-in shell scripts never send it.
+const serveCommandHello = "hello"
+
+// serveTypeMarker separates the prompt type from the payload in the records
+// of a client that negotiated the protocol: "<id>\x1f<type>\x1f<payload>\x00".
+const serveTypeMarker = "\x1f"
+
+// serveHelloType is the record type of the reply to a hello request.
+const serveHelloType = "hello"
+
+// serveCapabilities is what a protocol client declares it can handle in its
+// hello request, and what both sides agree on in the reply.
+type serveCapabilities struct {
+	Prompts  []string `json:"prompts"`
+	Features []string `json:"features"`
+	Protocol int      `json:"protocol"`
+	Marks    bool     `json:"marks"`
+}
+
+// negotiate intersects the client's capabilities with what oh-my-posh
+// supports and what the config enables for the client's shell. The primary
+// prompt is always part of the result, marks only when the config enables
+// shell_integration.
+//
+// A panic while loading the config costs the features, not the daemon.
+func negotiate(req *serveRequest) (negotiated *serveCapabilities) {
+	client := req.Capabilities
+	if client == nil {
+		client = &serveCapabilities{}
+	}
+
+	negotiated = &serveCapabilities{
+		Protocol: shell.ProtocolVersion,
+		Prompts:  []string{prompt.PRIMARY},
+		Features: []string{},
+	}
+
+	for _, promptType := range client.Prompts {
+		if !slices.Contains(shell.ProtocolPrompts, promptType) || slices.Contains(negotiated.Prompts, promptType) {
+			continue
+		}
+
+		negotiated.Prompts = append(negotiated.Prompts, promptType)
+	}
+
+	defer func() {
+		_ = recover()
+	}()
+
+	feats := serveFeatures(requestShell(req))
+	negotiated.Features = (feats & shell.ParseFeatures(client.Features)).Names()
+	negotiated.Marks = client.Marks && feats&shell.FTCSMarks != 0
+
+	return negotiated
+}
+
+// serveFeatures resolves the features the config enables for sh, the same
+// way init does for the built-in shells.
+func serveFeatures(sh string) shell.Features {
+	env := &runtime.Terminal{}
+	env.Init(&runtime.Flags{
+		Shell:      sh,
+		ConfigPath: configFlag,
+	})
+
+	reload, _ := cache.Get[bool](cache.Device, config.RELOAD)
+	cfg := config.Get(configFlag, reload)
+
+	return cfg.Features(env)
+}
+
+func (c *serveCapabilities) has(promptType string) bool {
+	return slices.Contains(c.Prompts, promptType)
+}
+
+func (c *serveCapabilities) streaming() bool {
+	return shell.ParseFeatures(c.Features)&shell.Streaming != 0
+}
+
+// helloRecord is the reply to a hello request, the negotiated capabilities
+// as JSON.
+func helloRecord(negotiated *serveCapabilities) string {
+	data, _ := json.Marshal(negotiated)
+	return serveHelloType + serveTypeMarker + string(data)
+}
+
+// typedRecords prefixes every record of a cycle with its prompt type for a
+// client that negotiated the protocol, dropping the types it didn't ask for.
+// Once the primary prompt is final, the right and secondary prompts follow.
+//
+// Those render in this goroutine after the engine's producer is done, so the
+// engine is never used concurrently; stopActiveCycle waits for the returned
+// channel to close before the next cycle starts.
+func typedRecords(eng *prompt.Engine, records <-chan string, negotiated *serveCapabilities) <-chan string {
+	out := make(chan string, 10)
+
+	go func() {
+		defer close(out)
+		defer func() {
+			_ = recover()
+		}()
+
+		for record := range records {
+			promptType := prompt.PRIMARY
+			if payload, ok := strings.CutPrefix(record, prompt.TransientMarker); ok {
+				promptType, record = prompt.TRANSIENT, payload
+			}
+
+			if !negotiated.has(promptType) {
+				continue
+			}
+
+			out <- promptType + serveTypeMarker + record
+		}
+
+		if eng.Aborted() {
+			return
+		}
+
+		if negotiated.has(prompt.RIGHT) {
+			out <- prompt.RIGHT + serveTypeMarker + eng.RPrompt()
+		}
+
+		if negotiated.has(prompt.SECONDARY) {
+			out <- prompt.SECONDARY + serveTypeMarker + eng.ExtraPrompt(prompt.Secondary)
+		}
+	}()
+
+	return out
+}
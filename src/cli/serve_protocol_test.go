@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/shell"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// typedRecord splits the payload of a negotiated client's record into its
+// prompt type and content.
+func typedRecord(t *testing.T, rec serveRecord) (string, string) {
+	t.Helper()
+
+	promptType, payload, found := strings.Cut(rec.payload, serveTypeMarker)
+	require.True(t, found, "a negotiated record carries its prompt type: %q", rec.payload)
+
+	return promptType, payload
+}
+
+func TestServeLoop_HelloNegotiatesTypedRecords(t *testing.T) {
+	h := startServeHarness(t)
+	pwd := t.TempDir()
+	chdirBackToWD(t)
+
+	h.send(map[string]any{
+		"command": "hello",
+		"id":      0,
+		"capabilities": map[string]any{
+			"protocol": 1,
+			"prompts":  []string{"primary", "secondary", "tooltip"},
+			"features": []string{"streaming"},
+			"marks":    true,
+		},
+	})
+
+	records := h.reader.collectUntil(200*time.Millisecond, carriesID("0"))
+	require.Len(t, records, 1, "a hello request gets exactly one reply")
+
+	promptType, payload := typedRecord(t, records[0])
+	assert.Equal(t, serveHelloType, promptType)
+
+	var negotiated serveCapabilities
+	require.NoError(t, json.Unmarshal([]byte(payload), &negotiated))
+	assert.Equal(t, shell.ProtocolVersion, negotiated.Protocol)
+	assert.Equal(t, []string{"primary", "secondary"}, negotiated.Prompts, "unsupported prompt types are dropped")
+	assert.Empty(t, negotiated.Features, "the default config does not stream")
+	assert.False(t, negotiated.Marks, "the default config has no shell integration")
+
+	h.send(map[string]any{"command": "render", "id": 1, "shell": "shell", "pwd": pwd})
+
+	records = h.reader.collectUntil(500*time.Millisecond, func(records []serveRecord) bool {
+		return len(records) == 2
+	})
+	require.Len(t, records, 2, "without streaming a negotiated client gets the final primary and the requested extras")
+
+	var types []string
+	for _, rec := range records {
+		assert.Equal(t, "1", rec.id)
+		assert.False(t, rec.transient, "the transient prompt wasn't negotiated")
+
+		promptType, payload := typedRecord(t, rec)
+		assert.NotEmpty(t, payload, promptType)
+		types = append(types, promptType)
+	}
+
+	assert.Equal(t, []string{"primary", "secondary"}, types)
+
+	h.quitAndWait()
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
 	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
 
 	"github.com/stretchr/testify/assert"
@@ -354,7 +355,7 @@ func TestRenderCompleteEmitsTwoRecordsOnPanic(t *testing.T) {
 	// block-read exactly two records with no timeout, so the reply must still
 	// contain both: an empty primary (the fallback signal) and an empty
 	// transient carrying its marker.
-	records := renderComplete(&prompt.Engine{})
+	records := renderComplete(&prompt.Engine{}, nil)
 
 	var got []string
 	timeout := time.After(2 * time.Second)
@@ -398,6 +399,43 @@ func TestServeLoop_WaitRenderEmitsExactlyTwoRecords(t *testing.T) {
 	h.quitAndWait()
 }
 
+func TestServeLoop_WaitRenderVIModes(t *testing.T) {
+	h := startServeHarness(t)
+	pwd := t.TempDir()
+
+	configPath := filepath.Join(t.TempDir(), "vimode.omp.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{
+  "version": 4,
+  "blocks": [{
+    "type": "prompt",
+    "alignment": "left",
+    "segments": [{"type": "vimode", "style": "plain", "template": "mode:{{ .Keymap }}"}]
+  }]
+}`), 0o644))
+
+	// the daemon renders with the session's config, which earlier renders
+	// in this process have cached already
+	config.Load(configPath).Store()
+	t.Cleanup(func() { config.Load("").Store() })
+	chdirBackToWD(t)
+
+	h.send(map[string]any{
+		"command": "render", "id": 1, "shell": "bash", "pwd": pwd, "wait": true,
+		"vi-modes": []string{"vicmd"},
+		"env":      map[string]string{"POSH_VI_MODE": "viins"},
+	})
+
+	records := h.records(500 * time.Millisecond)
+	require.Len(t, records, 3, "a wait render emits the primary, one record per vi mode and the transient")
+	assert.Contains(t, records[0].payload, "mode:viins")
+	assert.True(t, strings.HasPrefix(records[1].payload, viModeMarker+"vicmd"+viModeMarker), records[1].payload)
+	assert.Contains(t, records[1].payload, "mode:vicmd")
+	assert.True(t, records[2].transient, "the transient still ends the cycle")
+	assert.Equal(t, "viins", os.Getenv("POSH_VI_MODE"), "the vi mode render leaves the environment alone")
+
+	h.quitAndWait()
+}
+
 // TestServeLoop_RendersFollowDirectoryChanges guards against per-process
 // state pinning the prompt to the first request's context: template.Init
 // builds the template cache (PWD, Folder, ...) once per process, which in
@@ -620,3 +658,23 @@ func TestServeLoop_UTF8BOMOnFirstLine(t *testing.T) {
 
 	h.quitAndWait()
 }
+
+func TestFormatRecord(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Record   string
+		Expected string
+		JSON     bool
+	}{
+		{Case: "NUL terminated", Record: "> ", Expected: "7\x1f> \x00"},
+		{Case: "JSON line", Record: "> ", JSON: true, Expected: "\"7\\u001f\\u003e \"\n"},
+		{Case: "JSON line, multiline prompt", Record: "a\nb\x00", JSON: true, Expected: "\"7\\u001fa\\nb\\u0000\"\n"},
+	}
+
+	for _, tc := range cases {
+		jsonRecords = tc.JSON
+		assert.Equal(t, tc.Expected, formatRecord(7, tc.Record), tc.Case)
+	}
+
+	jsonRecords = false
+}
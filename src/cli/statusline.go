@@ -61,6 +61,7 @@ func statuslineRun[T any](shellConst, cacheKey string, sessionID func(*T) string
 		}
 
 		template.Init(env, cfg.Var, cfg.Maps)
+		template.Extend(cfg.TemplateExtensions())
 		terminal.Init(shellConst)
 		terminal.BackgroundColor = cfg.TerminalBackground.ResolveTemplate()
 		terminal.Colors = cfg.MakeColors(env)
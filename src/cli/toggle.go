@@ -14,6 +14,8 @@ var toggleCmd = &cmdtree.Command{
 	Short: "Toggle one or more segments on/off",
 	Long:  "Toggle one or more segments on/off on the fly. Multiple segments can be specified separated by spaces.",
 	Args:  cmdtree.MinimumNArgs(1),
+	// segments can be toggled by alias, see config.Segment.DataKey
+	ValidArgsFunction: completeSegments,
 	Run: func(cmd *cmdtree.Command, args []string) {
 		if len(args) == 0 {
 			_ = cmd.Help()
@@ -19,8 +19,9 @@ const (
 )
 
 const (
-	boolType = "bool"
-	trueStr  = "true"
+	boolType        = "bool"
+	stringArrayType = "stringArray"
+	trueStr         = "true"
 )
 
 // Value is the interface to the dynamic value stored in a flag.
@@ -98,6 +99,26 @@ func (v float64Value) Set(s string) error {
 }
 func (v float64Value) Type() string { return "float64" }
 
+// stringArrayValue collects every use of a repeatable flag, in order; the
+// first use replaces the default.
+type stringArrayValue struct {
+	p       *[]string
+	changed bool
+}
+
+func (v *stringArrayValue) String() string { return "[" + strings.Join(*v.p, ",") + "]" }
+func (v *stringArrayValue) Set(s string) error {
+	if !v.changed {
+		*v.p = nil
+		v.changed = true
+	}
+
+	*v.p = append(*v.p, s)
+
+	return nil
+}
+func (v *stringArrayValue) Type() string { return stringArrayType }
+
 // registration
 
 func (f *FlagSet) Var(value Value, name, shorthand, usage string) *Flag {
@@ -128,6 +149,13 @@ func (f *FlagSet) StringVarP(p *string, name, shorthand, value, usage string) {
 	f.Var(stringValue{p}, name, shorthand, usage)
 }
 
+// StringArrayVar registers a flag that can be repeated, each use adding one
+// value, commas included.
+func (f *FlagSet) StringArrayVar(p *[]string, name string, value []string, usage string) {
+	*p = value
+	f.Var(&stringArrayValue{p: p}, name, "", usage)
+}
+
 func (f *FlagSet) String(name, value, usage string) *string {
 	p := new(string)
 	f.StringVar(p, name, value, usage)
@@ -442,6 +470,8 @@ func defaultValue(flag *Flag) (string, bool) {
 		return flag.DefValue, flag.DefValue == trueStr
 	case "string":
 		return fmt.Sprintf("%q", flag.DefValue), flag.DefValue != ""
+	case stringArrayType:
+		return flag.DefValue, flag.DefValue != "[]"
 	default:
 		return flag.DefValue, flag.DefValue != "0"
 	}
@@ -105,3 +105,13 @@ func TestFlagUsages(t *testing.T) {
 `
 	assert.Equal(t, expected, fs.FlagUsages())
 }
+
+func TestStringArray(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	var data []string
+	fs.StringArrayVar(&data, "data", []string{"default.json"}, "data files")
+
+	assert.NoError(t, fs.Parse([]string{"--data", "a.json", "--data=b,c.json"}))
+	assert.Equal(t, []string{"a.json", "b,c.json"}, data, "every use adds a value and replaces the default")
+	assert.Equal(t, "      --data stringArray   data files (default [default.json])\n", fs.FlagUsages())
+}
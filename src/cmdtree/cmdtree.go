@@ -34,6 +34,8 @@ type Command struct {
 	PersistentPreRun  func(cmd *Command, args []string)
 	Args              PositionalArgs
 	Run               func(cmd *Command, args []string)
+	ValidArgsFunction CompletionFunc
+	flagCompletions   map[string]CompletionFunc
 	Example           string
 	Long              string
 	Short             string
@@ -45,6 +47,8 @@ type Command struct {
 	setArgs           []string
 	Hidden            bool
 	helpRequested     bool
+	// rawArgs hands every argument to Run unparsed, skipping the hooks.
+	rawArgs           bool
 	CompletionOptions struct{ DisableDefaultCmd bool }
 }
 
@@ -211,6 +215,7 @@ func (c *Command) Execute() error {
 	if c.parent == nil {
 		checkExplorerLaunch()
 		c.ensureHelpCommand()
+		c.ensureCompletionCommands()
 	}
 
 	args := c.setArgs
@@ -307,6 +312,11 @@ func removeFirst(args []string, value string) []string {
 }
 
 func (c *Command) execute(args []string) error {
+	if c.rawArgs {
+		c.Run(c, args)
+		return nil
+	}
+
 	c.registerHelpFlag()
 	flags := c.mergedFlags()
 
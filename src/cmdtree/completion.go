@@ -0,0 +1,422 @@
+package cmdtree
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cmdflag"
+)
+
+// CompletionFunc returns the candidates for toComplete, the word under the
+// cursor. A candidate is a bare value or "value\tdescription"; the caller
+// filters on the prefix, so returning every candidate is fine. args are the
+// positional arguments already on the command line.
+type CompletionFunc func(cmd *Command, args []string, toComplete string) []string
+
+const (
+	completionCmdName = "completion"
+	// completeCmdName is the hidden command every generated script calls back
+	// into: "__complete <args...> <toComplete>" prints one candidate per line.
+	completeCmdName = "__complete"
+)
+
+// CompletionShells lists the shells GenCompletion writes a script for.
+var CompletionShells = []string{"bash", "zsh", "fish", "pwsh", "nu"}
+
+// RegisterFlagCompletionFunc completes the values of flag name, a local or
+// persistent flag of this command, with fn.
+func (c *Command) RegisterFlagCompletionFunc(name string, fn CompletionFunc) error {
+	if c.Flags().Lookup(name) == nil && c.PersistentFlags().Lookup(name) == nil {
+		return fmt.Errorf("no such flag -%v", name)
+	}
+
+	if c.flagCompletions == nil {
+		c.flagCompletions = make(map[string]CompletionFunc)
+	}
+
+	c.flagCompletions[name] = fn
+	return nil
+}
+
+// ensureCompletionCommands adds the completion command and its hidden
+// callback unless DisableDefaultCmd is set or they're already registered.
+func (c *Command) ensureCompletionCommands() {
+	if c.CompletionOptions.DisableDefaultCmd || c.findChild(completionCmdName) != nil {
+		return
+	}
+
+	name := c.Name()
+
+	c.AddCommand(&Command{
+		Use:   completionCmdName + " [" + strings.Join(CompletionShells, "|") + "]",
+		Short: "Generate the autocompletion script for the specified shell",
+		Long: `Generate the autocompletion script for ` + name + ` for the specified shell.
+
+Load it in every session from your shell's profile, for example for bash:
+
+  source <(` + name + ` completion bash)
+
+See the documentation for every shell: https://ohmyposh.dev/docs/installation/completion.`,
+		ValidArgs: CompletionShells,
+		Args: func(cmd *Command, args []string) error {
+			if err := ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+
+			return OnlyValidArgs(cmd, args)
+		},
+		Run: func(cmd *Command, args []string) {
+			_ = c.GenCompletion(cmd.outWriter(), args[0])
+		},
+	})
+
+	c.AddCommand(&Command{
+		Use:    completeCmdName,
+		Short:  "Print the completion candidates for a command line",
+		Hidden: true,
+		// the command line being completed carries flags of other commands
+		rawArgs: true,
+		Run: func(cmd *Command, args []string) {
+			for _, candidate := range c.complete(args) {
+				fmt.Fprintln(cmd.outWriter(), candidate)
+			}
+		},
+	})
+}
+
+// GenCompletion writes the completion script for shell to w.
+func (c *Command) GenCompletion(w io.Writer, shell string) error {
+	var script string
+
+	switch shell {
+	case "bash":
+		script = bashCompletion
+	case "zsh":
+		script = zshCompletion
+	case "fish":
+		script = fishCompletion
+	case "pwsh":
+		script = pwshCompletion
+	case "nu":
+		script = nuCompletion
+	default:
+		return fmt.Errorf("unsupported shell %q", shell)
+	}
+
+	name := c.Root().Name()
+	ident := strings.NewReplacer("-", "_", ".", "_").Replace(name)
+
+	script = strings.NewReplacer("{{name}}", name, "{{ident}}", ident).Replace(script)
+
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+// complete resolves the candidates for a command line as handed to the
+// hidden __complete command: the words after the program name, the last one
+// being the word under the cursor.
+func (c *Command) complete(args []string) []string {
+	toComplete := ""
+	if len(args) > 0 {
+		toComplete = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+
+	// Older PowerShell versions drop an empty argument to a native command,
+	// the script sends a quoted empty string instead.
+	if toComplete == `""` {
+		toComplete = ""
+	}
+
+	cmd := c
+	for {
+		name, ok := firstPositional(cmd, args)
+		if !ok {
+			break
+		}
+
+		child := cmd.findChild(name)
+		if child == nil {
+			break
+		}
+
+		args = removeFirst(args, name)
+		cmd = child
+	}
+
+	flags := cmd.mergedFlags()
+	flags.ParseErrorsAllowlist.UnknownFlags = true
+	// a flag still waiting for its value fails the parse, see pendingFlag
+	_ = flags.Parse(args)
+	positionals := flags.Args()
+
+	if flag := pendingFlag(flags, args); flag != nil {
+		return filterCandidates(cmd.completeFlagValue(flag, positionals, toComplete), toComplete)
+	}
+
+	if name, value, found := strings.Cut(toComplete, "="); found && strings.HasPrefix(name, "--") {
+		flag := flags.Lookup(strings.TrimPrefix(name, "--"))
+		if flag == nil {
+			return nil
+		}
+
+		var candidates []string
+		for _, candidate := range filterCandidates(cmd.completeFlagValue(flag, positionals, value), value) {
+			candidates = append(candidates, name+"="+candidate)
+		}
+
+		return candidates
+	}
+
+	if strings.HasPrefix(toComplete, "-") {
+		return filterCandidates(flagCandidates(flags, toComplete), toComplete)
+	}
+
+	var candidates []string
+
+	if len(positionals) == 0 {
+		for _, child := range cmd.commands {
+			if child.Hidden {
+				continue
+			}
+
+			candidates = append(candidates, child.Name()+"\t"+child.Short)
+		}
+	}
+
+	candidates = append(candidates, cmd.ValidArgs...)
+
+	if cmd.ValidArgsFunction != nil {
+		candidates = append(candidates, cmd.ValidArgsFunction(cmd, positionals, toComplete)...)
+	}
+
+	return filterCandidates(candidates, toComplete)
+}
+
+// pendingFlag returns the flag the last word sets when it still needs its
+// value, which is then the word under the cursor.
+func pendingFlag(flags *cmdflag.FlagSet, args []string) *cmdflag.Flag {
+	if len(args) == 0 {
+		return nil
+	}
+
+	last := args[len(args)-1]
+
+	var flag *cmdflag.Flag
+
+	switch {
+	case last == "--" || strings.Contains(last, "="):
+		return nil
+	case strings.HasPrefix(last, "--"):
+		flag = flags.Lookup(last[2:])
+	case strings.HasPrefix(last, "-") && len(last) > 1:
+		flag = flags.ShorthandLookup(last[len(last)-1:])
+	}
+
+	if flag == nil || flag.Value.Type() == "bool" {
+		return nil
+	}
+
+	return flag
+}
+
+// completeFlagValue calls the completion function registered for flag by
+// this command or the nearest ancestor.
+func (c *Command) completeFlagValue(flag *cmdflag.Flag, args []string, toComplete string) []string {
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		if fn, ok := cmd.flagCompletions[flag.Name]; ok {
+			return fn(c, args, toComplete)
+		}
+	}
+
+	return nil
+}
+
+func flagCandidates(flags *cmdflag.FlagSet, toComplete string) []string {
+	var candidates []string
+
+	flags.VisitAll(func(flag *cmdflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+
+		candidates = append(candidates, "--"+flag.Name+"\t"+flag.Usage)
+
+		if flag.Shorthand != "" && !strings.HasPrefix(toComplete, "--") {
+			candidates = append(candidates, "-"+flag.Shorthand+"\t"+flag.Usage)
+		}
+	})
+
+	return candidates
+}
+
+// filterCandidates keeps the candidates whose value starts with toComplete.
+func filterCandidates(candidates []string, toComplete string) []string {
+	var filtered []string
+
+	for _, candidate := range candidates {
+		value, _, _ := strings.Cut(candidate, "\t")
+		if strings.HasPrefix(value, toComplete) {
+			filtered = append(filtered, candidate)
+		}
+	}
+
+	return filtered
+}
+
+const bashCompletion = `# bash completion for {{name}}, generated by '{{name}} completion bash'
+
+_{{ident}}_completion() {
+    local line=${COMP_LINE:0:COMP_POINT}
+    local -a words
+    read -r -a words <<< "$line"
+
+    # the word under the cursor, empty right after a space
+    local cur=''
+    if [[ $line != *[[:space:]] ]]; then
+        cur=${words[${#words[@]}-1]}
+        unset 'words[${#words[@]}-1]'
+    fi
+
+    # bash only replaces what follows the = of --flag=value
+    local prefix=''
+    if [[ $cur == --*=* && $COMP_WORDBREAKS == *=* ]]; then
+        prefix=${cur%%=*}=
+    fi
+
+    COMPREPLY=()
+
+    local candidate
+    while IFS= read -r candidate; do
+        [[ -n $candidate ]] || continue
+        candidate=${candidate%%$'\t'*}
+        COMPREPLY+=("${candidate#"$prefix"}")
+    done < <(command {{name}} __complete "${words[@]:1}" "$cur" 2>/dev/null)
+}
+
+complete -o default -F _{{ident}}_completion {{name}}
+`
+
+const zshCompletion = `#compdef {{name}}
+# zsh completion for {{name}}, generated by '{{name}} completion zsh'
+
+_{{name}}() {
+    local -a candidates
+    local line value
+
+    for line in "${(@f)$(command {{name}} __complete "${(@)words[2,CURRENT-1]}" "${words[CURRENT]}" 2>/dev/null)}"; do
+        [[ -n $line ]] || continue
+
+        value=${line%%$'\t'*}
+        value=${value//:/\\:}
+
+        if [[ $line == *$'\t'* ]]; then
+            candidates+=("$value:${line#*$'\t'}")
+        else
+            candidates+=("$value")
+        fi
+    done
+
+    if (( ${#candidates} == 0 )); then
+        _files
+        return
+    fi
+
+    _describe -t values '{{name}}' candidates
+}
+
+# sourced from a profile or autoloaded from $fpath
+if [[ $funcstack[1] == _{{name}} ]]; then
+    _{{name}} "$@"
+else
+    compdef _{{name}} {{name}}
+fi
+`
+
+const fishCompletion = `# fish completion for {{name}}, generated by '{{name}} completion fish'
+
+function __{{ident}}_complete
+    set -l tokens (commandline -opc)
+    set -e tokens[1]
+    set -l current (commandline -ct)
+
+    set -l candidates (command {{name}} __complete $tokens "$current" 2>/dev/null)
+    if test (count $candidates) -eq 0
+        __fish_complete_path "$current"
+        return
+    end
+
+    printf '%s\n' $candidates
+end
+
+complete -c {{name}} -f -a '(__{{ident}}_complete)'
+`
+
+const pwshCompletion = `# powershell completion for {{name}}, generated by '{{name}} completion pwsh'
+
+Register-ArgumentCompleter -Native -CommandName '{{name}}' -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $words = @($commandAst.CommandElements |
+        Where-Object { $_.Extent.StartOffset -lt $cursorPosition } |
+        Select-Object -Skip 1 |
+        ForEach-Object { $_.Extent.Text })
+
+    if ($wordToComplete -and $words.Count -gt 0) {
+        $words = @($words | Select-Object -SkipLast 1)
+    }
+
+    # older PowerShell versions drop an empty argument to a native command
+    $current = if ($wordToComplete) { $wordToComplete } else { '""' }
+
+    & '{{name}}' __complete @words $current 2>$null | ForEach-Object {
+        $value, $description = $_ -split "` + "`" + `t", 2
+        if (-not $description) {
+            $description = $value
+        }
+
+        [System.Management.Automation.CompletionResult]::new($value, $value, 'ParameterValue', $description)
+    }
+}
+`
+
+const nuCompletion = `# nushell completion for {{name}}, generated by '{{name}} completion nu'
+# Goes in front of the external completer that's already configured, if any.
+
+let __{{ident}}_previous_completer = $env.config.completions.external.completer?
+
+$env.config.completions.external.enable = true
+$env.config.completions.external.completer = {|spans: list<string>|
+    if ($spans | first) != '{{name}}' {
+        if $__{{ident}}_previous_completer == null {
+            return null
+        }
+
+        return (do $__{{ident}}_previous_completer $spans)
+    }
+
+    let candidates = (
+        ^{{name}} __complete ...($spans | skip 1)
+        | complete
+        | get stdout
+        | lines
+        | where {|line| $line != '' }
+        | each {|line|
+            let parts = ($line | split row "\t")
+            if ($parts | length) > 1 {
+                {value: $parts.0, description: ($parts | skip 1 | str join "\t")}
+            } else {
+                {value: $parts.0}
+            }
+        }
+    )
+
+    # fall back to file completion
+    if ($candidates | is-empty) {
+        return null
+    }
+
+    $candidates
+}
+`
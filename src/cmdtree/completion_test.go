@@ -0,0 +1,101 @@
+package cmdtree
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func completionTree() *Command {
+	root, _ := testTree()
+
+	var config, shell string
+	var force bool
+
+	root.PersistentFlags().StringVarP(&config, "config", "c", "", "config file path")
+	_ = root.RegisterFlagCompletionFunc("config", func(_ *Command, _ []string, _ string) []string {
+		return []string{"agnoster", "atomic", "paradox"}
+	})
+
+	child := root.findChild("child")
+	child.Short = "a child"
+	child.ValidArgs = []string{"get", "set"}
+	child.Flags().StringVar(&shell, "shell", "", "the shell")
+	child.Flags().BoolVarP(&force, "force", "f", false, "force it")
+
+	root.AddCommand(&Command{Use: "secret", Hidden: true})
+	root.AddCommand(&Command{
+		Use: "toggle",
+		ValidArgsFunction: func(_ *Command, args []string, _ string) []string {
+			return []string{"git", "path", strings.Join(args, ",")}
+		},
+	})
+
+	root.ensureCompletionCommands()
+
+	return root
+}
+
+func TestComplete(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Args     []string
+		Expected []string
+	}{
+		{Case: "subcommands", Args: []string{""}, Expected: []string{"child\ta child", "toggle\t", "completion\tGenerate the autocompletion script for the specified shell"}},
+		{Case: "subcommand prefix", Args: []string{"ch"}, Expected: []string{"child\ta child"}},
+		{Case: "nested subcommands and valid args", Args: []string{"child", ""}, Expected: []string{"grandchild\t", "get", "set"}},
+		{Case: "valid args after a positional", Args: []string{"child", "get", "s"}, Expected: []string{"set"}},
+		{Case: "flags", Args: []string{"child", "--"}, Expected: []string{"--shell\tthe shell", "--force\tforce it", "--config\tconfig file path"}},
+		{Case: "flags and shorthands", Args: []string{"child", "-"}, Expected: []string{"--shell\tthe shell", "--force\tforce it", "-f\tforce it", "--config\tconfig file path", "-c\tconfig file path"}},
+		{Case: "flag value", Args: []string{"child", "--config", "a"}, Expected: []string{"agnoster", "atomic"}},
+		{Case: "shorthand flag value", Args: []string{"-c", "p"}, Expected: []string{"paradox"}},
+		{Case: "inline flag value", Args: []string{"child", "--config=at"}, Expected: []string{"--config=atomic"}},
+		{Case: "flag without completion", Args: []string{"child", "--shell", ""}},
+		{Case: "bool flag takes no value", Args: []string{"child", "--force", "g"}, Expected: []string{"grandchild\t", "get"}},
+		{Case: "dynamic args", Args: []string{"toggle", "git", ""}, Expected: []string{"git", "path", "git"}},
+		{Case: "empty PowerShell argument", Args: []string{"toggle", `""`}, Expected: []string{"git", "path", ""}},
+		{Case: "hidden commands", Args: []string{"sec"}},
+	}
+
+	for _, tc := range cases {
+		root := completionTree()
+		assert.Equal(t, tc.Expected, root.complete(tc.Args), tc.Case)
+	}
+}
+
+func TestCompleteCommand(t *testing.T) {
+	root := completionTree()
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs([]string{"__complete", "--config", "x", "child", "--sh"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "--shell\tthe shell\n", out.String())
+}
+
+func TestGenCompletion(t *testing.T) {
+	root := completionTree()
+
+	for _, shell := range CompletionShells {
+		var out bytes.Buffer
+		require.NoError(t, root.GenCompletion(&out, shell), shell)
+		assert.Contains(t, out.String(), "__complete", shell)
+		assert.NotContains(t, out.String(), "{{", shell)
+	}
+
+	assert.Error(t, root.GenCompletion(&bytes.Buffer{}, "cmd"))
+}
+
+func TestDisableCompletionCommand(t *testing.T) {
+	root, _ := testTree()
+	root.CompletionOptions.DisableDefaultCmd = true
+	root.ensureCompletionCommands()
+
+	assert.Nil(t, root.findChild("completion"))
+	assert.Nil(t, root.findChild("__complete"))
+}
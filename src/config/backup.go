@@ -12,8 +12,12 @@ import (
 )
 
 func (cfg *Config) Backup() {
-	dst := cfg.Source + ".bak"
-	source, err := os.Open(cfg.Source)
+	backup(cfg.Source)
+}
+
+func backup(path string) {
+	dst := path + ".bak"
+	source, err := os.Open(path)
 	if err != nil {
 		return
 	}
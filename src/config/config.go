@@ -59,17 +59,19 @@ const (
 )
 
 type Config struct {
-	Palette                 color.Palette      `json:"palette,omitempty" toml:"palette,omitempty" yaml:"palette,omitempty"`
-	DebugPrompt             *Segment           `json:"debug_prompt,omitempty" toml:"debug_prompt,omitempty" yaml:"debug_prompt,omitempty"`
-	Var                     map[string]any     `json:"var,omitempty" toml:"var,omitempty" yaml:"var,omitempty"`
-	Palettes                *color.Palettes    `json:"palettes,omitempty" toml:"palettes,omitempty" yaml:"palettes,omitempty"`
-	ValidLine               *Segment           `json:"valid_line,omitempty" toml:"valid_line,omitempty" yaml:"valid_line,omitempty"`
-	SecondaryPrompt         *Segment           `json:"secondary_prompt,omitempty" toml:"secondary_prompt,omitempty" yaml:"secondary_prompt,omitempty"`
-	TransientPrompt         *Segment           `json:"transient_prompt,omitempty" toml:"transient_prompt,omitempty" yaml:"transient_prompt,omitempty"`
-	ErrorLine               *Segment           `json:"error_line,omitempty" toml:"error_line,omitempty" yaml:"error_line,omitempty"`
-	Maps                    *maps.Config       `json:"maps,omitempty" toml:"maps,omitempty" yaml:"maps,omitempty"`
-	Upgrade                 *upgrade.Config    `json:"upgrade,omitempty" toml:"upgrade,omitempty" yaml:"upgrade,omitempty"`
-	TerminalFeatures        *terminal.Features `json:"terminal_features,omitempty" toml:"terminal_features,omitempty" yaml:"terminal_features,omitempty"`
+	Palette                 color.Palette                 `json:"palette,omitempty" toml:"palette,omitempty" yaml:"palette,omitempty"`
+	DebugPrompt             *Segment                      `json:"debug_prompt,omitempty" toml:"debug_prompt,omitempty" yaml:"debug_prompt,omitempty"`
+	Var                     map[string]any                `json:"var,omitempty" toml:"var,omitempty" yaml:"var,omitempty"`
+	Partials                map[string]string             `json:"partials,omitempty" toml:"partials,omitempty" yaml:"partials,omitempty"`
+	Functions               map[string]*template.Function `json:"functions,omitempty" toml:"functions,omitempty" yaml:"functions,omitempty"`
+	Palettes                *color.Palettes               `json:"palettes,omitempty" toml:"palettes,omitempty" yaml:"palettes,omitempty"`
+	ValidLine               *Segment                      `json:"valid_line,omitempty" toml:"valid_line,omitempty" yaml:"valid_line,omitempty"`
+	SecondaryPrompt         *Segment                      `json:"secondary_prompt,omitempty" toml:"secondary_prompt,omitempty" yaml:"secondary_prompt,omitempty"`
+	TransientPrompt         *Segment                      `json:"transient_prompt,omitempty" toml:"transient_prompt,omitempty" yaml:"transient_prompt,omitempty"`
+	ErrorLine               *Segment                      `json:"error_line,omitempty" toml:"error_line,omitempty" yaml:"error_line,omitempty"`
+	Maps                    *maps.Config                  `json:"maps,omitempty" toml:"maps,omitempty" yaml:"maps,omitempty"`
+	Upgrade                 *upgrade.Config               `json:"upgrade,omitempty" toml:"upgrade,omitempty" yaml:"upgrade,omitempty"`
+	TerminalFeatures        *terminal.Features            `json:"terminal_features,omitempty" toml:"terminal_features,omitempty" yaml:"terminal_features,omitempty"`
 	presentFields           map[string]bool
 	Extends                 string                 `json:"extends,omitempty" toml:"extends,omitempty" yaml:"extends,omitempty"`
 	PWD                     string                 `json:"pwd,omitempty" toml:"pwd,omitempty" yaml:"pwd,omitempty"`
@@ -95,9 +97,19 @@ type Config struct {
 	PatchPwshBleed          bool `json:"patch_pwsh_bleed,omitempty" toml:"patch_pwsh_bleed,omitempty" yaml:"patch_pwsh_bleed,omitempty"`
 	AutoUpgrade             bool `json:"-" toml:"-" yaml:"-"`
 	EnableCursorPositioning bool `json:"enable_cursor_positioning,omitempty" toml:"enable_cursor_positioning,omitempty" yaml:"enable_cursor_positioning,omitempty"`
+	DetectBackground        bool `json:"detect_background,omitempty" toml:"detect_background,omitempty" yaml:"detect_background,omitempty"`
 	MigrateGlyphs           bool `json:"-" toml:"-" yaml:"-"`
 }
 
+// TemplateExtensions returns what the config adds to the template language,
+// see template.Extend.
+func (cfg *Config) TemplateExtensions() *template.Extensions {
+	return &template.Extensions{
+		Partials:  cfg.Partials,
+		Functions: cfg.Functions,
+	}
+}
+
 func (cfg *Config) MakeColors(env runtime.Environment) color.String {
 	cacheDisabled := env.Getenv("OMP_CACHE_DISABLED") == "1"
 	return color.MakeColors(cfg.getPalette(), !cacheDisabled, cfg.AccentColor, env)
@@ -143,7 +155,8 @@ func (cfg *Config) Features(env runtime.Environment) shell.Features {
 		log.Debug("transient prompt enabled")
 		feats |= shell.Transient
 
-		if env.Shell() == shell.FISH && len(cfg.TransientPrompt.RightTemplate) != 0 {
+		rightTransientShells := []string{shell.FISH, shell.NU}
+		if slices.Contains(rightTransientShells, env.Shell()) && len(cfg.TransientPrompt.RightTemplate) != 0 {
 			log.Debug("transient right prompt enabled")
 			feats |= shell.TransientRPrompt
 		}
@@ -158,11 +171,6 @@ func (cfg *Config) Features(env runtime.Environment) shell.Features {
 		feats |= shell.KeyHandlers
 	}
 
-	unsupportedShells := []string{shell.ELVISH, shell.XONSH}
-	if slices.Contains(unsupportedShells, env.Shell()) {
-		cfg.ShellIntegration = false
-	}
-
 	if cfg.ShellIntegration {
 		log.Debug("shell integration enabled")
 		feats |= shell.FTCSMarks
@@ -188,6 +196,11 @@ func (cfg *Config) Features(env runtime.Environment) shell.Features {
 		feats |= shell.Tooltips
 	}
 
+	if cfg.DetectBackground {
+		log.Debug("background detection enabled")
+		feats |= shell.Background
+	}
+
 	if env.Shell() == shell.FISH && cfg.ITermFeatures != nil && cfg.ITermFeatures.Contains(terminal.PromptMark) {
 		log.Debug("prompt mark enabled")
 		feats |= shell.PromptMark
@@ -221,7 +234,7 @@ func (cfg *Config) Features(env runtime.Environment) shell.Features {
 				}
 			}
 
-			if segment.Type == VIMODE && slices.Contains([]string{shell.ZSH, shell.PWSH, shell.FISH}, env.Shell()) {
+			if segment.Type == VIMODE && slices.Contains([]string{shell.ZSH, shell.PWSH, shell.FISH, shell.BASH, shell.ELVISH}, env.Shell()) {
 				log.Debug("vi mode tracking enabled")
 				feats |= shell.VIMode
 			}
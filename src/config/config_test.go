@@ -136,6 +136,18 @@ func TestFeaturesShellIntegration(t *testing.T) {
 			ShellIntegration: true,
 			ExpectedFeats:    shell.FTCSMarks,
 		},
+		{
+			Case:             "elvish with shell integration enables FTCSMarks only",
+			Shell:            shell.ELVISH,
+			ShellIntegration: true,
+			ExpectedFeats:    shell.FTCSMarks,
+		},
+		{
+			Case:             "xonsh with shell integration enables FTCSMarks only",
+			Shell:            shell.XONSH,
+			ShellIntegration: true,
+			ExpectedFeats:    shell.FTCSMarks,
+		},
 		{
 			Case:             "pwsh without shell integration enables nothing",
 			Shell:            shell.PWSH,
@@ -168,23 +180,38 @@ func TestFeaturesShellIntegration(t *testing.T) {
 func TestFeaturesTransientRightPrompt(t *testing.T) {
 	cases := []struct {
 		Case          string
+		Shell         string
 		RightTemplate string
 		ExpectedFeats shell.Features
 	}{
 		{
 			Case:          "transient prompt without right template",
+			Shell:         shell.FISH,
 			ExpectedFeats: shell.Transient | shell.KeyHandlers,
 		},
 		{
 			Case:          "transient prompt with right template",
+			Shell:         shell.FISH,
+			RightTemplate: "R>",
+			ExpectedFeats: shell.Transient | shell.TransientRPrompt | shell.KeyHandlers,
+		},
+		{
+			Case:          "nu transient prompt with right template",
+			Shell:         shell.NU,
 			RightTemplate: "R>",
 			ExpectedFeats: shell.Transient | shell.TransientRPrompt | shell.KeyHandlers,
 		},
+		{
+			Case:          "bash transient prompt with right template",
+			Shell:         shell.BASH,
+			RightTemplate: "R>",
+			ExpectedFeats: shell.Transient | shell.KeyHandlers,
+		},
 	}
 
 	for _, tc := range cases {
 		env := &mock.Environment{}
-		env.On("Shell").Return(shell.FISH)
+		env.On("Shell").Return(tc.Shell)
 
 		cfg := &Config{
 			TransientPrompt: &Segment{RightTemplate: tc.RightTemplate},
@@ -196,6 +223,18 @@ func TestFeaturesTransientRightPrompt(t *testing.T) {
 	}
 }
 
+func TestFeaturesBackground(t *testing.T) {
+	env := &mock.Environment{}
+	env.On("Shell").Return(shell.BASH)
+
+	cfg := &Config{
+		DetectBackground: true,
+		Upgrade:          &upgrade.Config{},
+	}
+
+	assert.Equal(t, shell.Background, cfg.Features(env))
+}
+
 func TestFeaturesVIMode(t *testing.T) {
 	cases := []struct {
 		Case          string
@@ -218,8 +257,18 @@ func TestFeaturesVIMode(t *testing.T) {
 			ExpectedFeats: shell.VIMode,
 		},
 		{
-			Case:          "bash does not enable vi mode tracking",
+			Case:          "bash enables vi mode tracking",
 			Shell:         shell.BASH,
+			ExpectedFeats: shell.VIMode,
+		},
+		{
+			Case:          "elvish enables vi mode tracking",
+			Shell:         shell.ELVISH,
+			ExpectedFeats: shell.VIMode,
+		},
+		{
+			Case:          "nu does not enable vi mode tracking",
+			Shell:         shell.NU,
 			ExpectedFeats: 0,
 		},
 	}
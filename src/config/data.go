@@ -2,11 +2,13 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 	"github.com/jandedobbeleer/oh-my-posh/src/text"
@@ -32,6 +34,9 @@ const (
 	DataVersionKey  = "version"
 	DataEnvKey      = "env"
 	DataSegmentsKey = "segments"
+	DataFramesKey   = "frames"
+	DataStartedKey  = "started"
+	DataAtKey       = "at"
 )
 
 // ThemeFileExtensions is every extension a bundled theme file is recognized
@@ -114,7 +119,40 @@ func MergeRecordedMethods(data, methods any) any {
 type Data struct {
 	Segments map[string]json.RawMessage
 	Env      json.RawMessage
-	Version  int
+	// Frames holds a recorded session instead of a single snapshot: every
+	// frame is a document of its own, with its own env and segments, in the
+	// order they happened. A document with frames has no env or segments of
+	// its own, see Timeline.
+	Frames  []DataFrame
+	Version int
+}
+
+// DataFrame is one snapshot in a recorded session, At after the session
+// started. In a file, at is a number of milliseconds.
+type DataFrame struct {
+	Data
+	At time.Duration
+}
+
+// Timeline returns d's frames, or d itself as the only frame when it's a
+// single snapshot, so a replay can treat both the same way.
+func (d *Data) Timeline() []DataFrame {
+	if len(d.Frames) != 0 {
+		return d.Frames
+	}
+
+	return []DataFrame{{Data: *d}}
+}
+
+// Frame returns the snapshot at index in d's Timeline.
+func (d *Data) Frame(index int) (*Data, error) {
+	timeline := d.Timeline()
+
+	if index < 0 || index >= len(timeline) {
+		return nil, fmt.Errorf("frame %d is out of range, the data file has %d", index, len(timeline))
+	}
+
+	return &timeline[index].Data, nil
 }
 
 // EnvData holds the subset of the env section that maps directly onto
@@ -235,9 +273,65 @@ func dataFromRoot(root map[string]json.RawMessage) (*Data, error) {
 		data.Segments = segments
 	}
 
+	framesRaw, OK := root[DataFramesKey]
+	if !OK {
+		return data, nil
+	}
+
+	if len(data.Env) != 0 || len(data.Segments) != 0 {
+		return nil, fmt.Errorf("a data file has either %s or %s and %s, not both", DataFramesKey, DataEnvKey, DataSegmentsKey)
+	}
+
+	var frames []map[string]json.RawMessage
+	if err := json.Unmarshal(framesRaw, &frames); err != nil {
+		return nil, fmt.Errorf("failed to parse frames in data file: %w", err)
+	}
+
+	data.Frames = make([]DataFrame, 0, len(frames))
+
+	for i, frameRoot := range frames {
+		frame, err := frameFromRoot(frameRoot, data.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse frame %d in data file: %w", i, err)
+		}
+
+		data.Frames = append(data.Frames, *frame)
+	}
+
 	return data, nil
 }
 
+// frameFromRoot builds one frame of a session. A frame without a version of
+// its own was recorded by the same recorder as the whole session and carries
+// the session's.
+func frameFromRoot(root map[string]json.RawMessage, version int) (*DataFrame, error) {
+	if _, OK := root[DataFramesKey]; OK {
+		return nil, errors.New("a frame can't hold frames of its own")
+	}
+
+	data, err := dataFromRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, OK := root[DataVersionKey]; !OK {
+		data.Version = version
+	}
+
+	frame := &DataFrame{Data: *data}
+
+	if atRaw, OK := root[DataAtKey]; OK {
+		var milliseconds float64
+		if err := json.Unmarshal(atRaw, &milliseconds); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", DataAtKey, err)
+		}
+
+		frame.At = time.Duration(milliseconds * float64(time.Millisecond))
+	}
+
+	return frame, nil
+}
+
 // normalize re-marshals a generically decoded section (as produced by the
 // YAML/TOML unmarshalers) to a map of raw JSON so the rest of the pipeline
 // can treat all formats uniformly.
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -209,3 +210,100 @@ func TestLoadDataInvalidContent(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, data)
 }
+
+func TestLoadDataFrames(t *testing.T) {
+	cases := []struct {
+		Case             string
+		Contents         string
+		ExpectedAt       []time.Duration
+		ExpectedPWD      []string
+		ExpectedVersions []int
+		ExpectedError    bool
+	}{
+		{
+			Case:             "single snapshot is its own timeline",
+			Contents:         `{"version": 1, "env": {"PWD": "~"}}`,
+			ExpectedAt:       []time.Duration{0},
+			ExpectedPWD:      []string{"~"},
+			ExpectedVersions: []int{1},
+		},
+		{
+			Case: "session",
+			Contents: `{"version": 1, "started": "2026-01-02T03:04:05Z", "frames": [
+				{"at": 0, "env": {"PWD": "~"}},
+				{"at": 1500, "env": {"PWD": "~/repo"}, "segments": {"git": {"data": {"HEAD": "main"}, "enabled": true}}}
+			]}`,
+			ExpectedAt:       []time.Duration{0, 1500 * time.Millisecond},
+			ExpectedPWD:      []string{"~", "~/repo"},
+			ExpectedVersions: []int{1, 1},
+		},
+		{
+			Case: "frame keeps its own version",
+			Contents: `{"version": 1, "frames": [
+				{"version": 0, "env": {"PWD": "~"}}
+			]}`,
+			ExpectedAt:       []time.Duration{0},
+			ExpectedPWD:      []string{"~"},
+			ExpectedVersions: []int{0},
+		},
+		{
+			Case:          "frames next to env",
+			Contents:      `{"env": {"PWD": "~"}, "frames": []}`,
+			ExpectedError: true,
+		},
+		{
+			Case:          "nested frames",
+			Contents:      `{"frames": [{"frames": []}]}`,
+			ExpectedError: true,
+		},
+		{
+			Case:          "invalid at",
+			Contents:      `{"frames": [{"at": "soon"}]}`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "data.json")
+			require.NoError(t, os.WriteFile(path, []byte(tc.Contents), 0644))
+
+			data, err := LoadData(path)
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			timeline := data.Timeline()
+			require.Len(t, timeline, len(tc.ExpectedAt))
+
+			for i := range timeline {
+				env, err := timeline[i].EnvFlags()
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.ExpectedAt[i], timeline[i].At)
+				assert.Equal(t, tc.ExpectedPWD[i], *env.PWD)
+				assert.Equal(t, tc.ExpectedVersions[i], timeline[i].Version)
+			}
+		})
+	}
+}
+
+func TestDataFrame(t *testing.T) {
+	data := &Data{Frames: []DataFrame{
+		{Data: Data{Env: json.RawMessage(`{"PWD": "~"}`)}},
+		{Data: Data{Env: json.RawMessage(`{"PWD": "~/repo"}`)}},
+	}}
+
+	frame, err := data.Frame(1)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"PWD": "~/repo"}`, string(frame.Env))
+
+	_, err = data.Frame(2)
+	assert.Error(t, err)
+
+	_, err = data.Frame(-1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,461 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/regex"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// Document is a config file's syntax tree rather than its decoded Config.
+// Where Export round-trips through the Go structs - and so drops comments,
+// reorders keys and expands defaults - a Document keeps every comment, the
+// original key order and the values exactly as written, which makes it the
+// right tool for format conversion and for migrations that must only touch
+// what they change.
+//
+// Every format is parsed into a yaml.Node tree: YAML natively, JSON/JSONC and
+// TOML through their own parsers (document_json.go, document_toml.go) which
+// attach comments the way the YAML decoder would. Comments are stored in
+// YAML form ("# text") and rewritten for the target format on encode.
+type Document struct {
+	root *yaml.Node
+	// schema holds the "$schema" reference, lifted out of the tree on parse
+	// because every format spells it differently: a key in JSON, a
+	// yaml-language-server modeline in YAML and a #:schema directive in TOML.
+	schema string
+	// tokens holds the source text of a TOML document's scalars, see
+	// tomlDocumentParser.
+	tokens map[*yaml.Node]tomlToken
+	Source string
+	Format string
+}
+
+var (
+	errDocumentRoot   = errors.New("config document root must be a mapping")
+	errRemoteDocument = errors.New("unable to write a remote config")
+)
+
+const (
+	yamlSchemaPrefix = "# yaml-language-server: $schema="
+	tomlSchemaPrefix = "#:schema "
+	schemaKey        = "$schema"
+)
+
+// documentFormat maps a config file extension to the format label the
+// converter works with. Unlike ParseBytes, JSON and JSONC stay distinct:
+// both parse the same way, but only JSONC keeps comments on encode.
+func documentFormat(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case JSON:
+		return JSON, nil
+	case JSONC:
+		return JSONC, nil
+	case YAML, YML:
+		return YAML, nil
+	case TOML, TML:
+		return TOML, nil
+	default:
+		return "", ErrInvalidExtension
+	}
+}
+
+// ReadDocument loads a config file (or URL, or bundled theme name) as a
+// Document, deriving the format from its extension the same way read does.
+func ReadDocument(configFile string) (*Document, error) {
+	defer log.Trace(time.Now())
+
+	if configFile == "" {
+		return nil, ErrNoConfig
+	}
+
+	configFile = resolveConfigLocation(configFile)
+
+	data, err := getData(configFile)
+	if err != nil {
+		log.Error(err)
+		return nil, ErrFileNotFound
+	}
+
+	doc, err := ParseDocument(filepath.Ext(configFile), data)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Source = configFile
+
+	return doc, nil
+}
+
+// ParseDocument parses config data in the given format (a file extension,
+// with or without the leading dot) into a Document.
+func ParseDocument(format string, data []byte) (*Document, error) {
+	format, err := documentFormat(strings.TrimPrefix(format, "."))
+	if err != nil {
+		return nil, err
+	}
+
+	var root *yaml.Node
+	var tokens map[*yaml.Node]tomlToken
+
+	switch format {
+	case JSON, JSONC:
+		root, err = parseJSONDocument(data)
+	case TOML:
+		root, tokens, err = parseTOMLDocument(data)
+	case YAML:
+		root, err = parseYAMLDocument(data)
+	}
+
+	if err != nil {
+		log.Errorf("failed to parse config document: %v", err)
+		return nil, ErrParse
+	}
+
+	doc := &Document{
+		root:   root,
+		tokens: tokens,
+		Format: format,
+	}
+
+	doc.extractSchema()
+
+	return doc, nil
+}
+
+// ConvertDocument converts config data from one format to another without
+// going through Config, see Document.
+func ConvertDocument(data []byte, from, to string) ([]byte, error) {
+	doc, err := ParseDocument(from, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.Encode(to)
+}
+
+// Encode writes the document in the given format; an empty format keeps the
+// one it was parsed from.
+func (doc *Document) Encode(format string) ([]byte, error) {
+	if format == "" {
+		format = doc.Format
+	}
+
+	format, err := documentFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case JSON:
+		return encodeJSONDocument(doc, false), nil
+	case JSONC:
+		return encodeJSONDocument(doc, true), nil
+	case TOML:
+		return encodeTOMLDocument(doc), nil
+	default:
+		return encodeYAMLDocument(doc)
+	}
+}
+
+// Write encodes the document in the given format and writes it next to its
+// source, returning the path written to. Keeping the format overwrites the
+// source after taking a .bak backup; a conversion swaps the extension instead
+// so the original file is never replaced by another format.
+func (doc *Document) Write(format string) (string, error) {
+	if strings.HasPrefix(doc.Source, "https://") {
+		return "", errRemoteDocument
+	}
+
+	content, err := doc.Encode(format)
+	if err != nil {
+		return "", err
+	}
+
+	target := doc.Source
+	extension := filepath.Ext(target)
+
+	if format != "" && !strings.EqualFold(format, strings.TrimPrefix(extension, ".")) {
+		target = strings.TrimSuffix(target, extension) + "." + strings.ToLower(format)
+	}
+
+	if target == doc.Source {
+		backup(doc.Source)
+	}
+
+	if err := os.WriteFile(target, content, 0644); err != nil {
+		return "", err
+	}
+
+	return target, nil
+}
+
+// mapping returns the document's top-level mapping node.
+func (doc *Document) mapping() *yaml.Node {
+	return doc.root.Content[0]
+}
+
+func (doc *Document) extractSchema() {
+	root := doc.mapping()
+
+	if index := mappingIndex(root, schemaKey); index >= 0 {
+		key, value := root.Content[index], root.Content[index+1]
+		doc.schema = value.Value
+
+		// the schema entry is re-added by each encoder, but any comments on
+		// it would go with the node, so keep them on the document instead
+		for _, comment := range []string{key.HeadComment, key.LineComment, value.LineComment} {
+			if comment != "" {
+				doc.root.HeadComment = appendComment(doc.root.HeadComment, comment)
+			}
+		}
+
+		removeMappingEntry(root, index)
+		return
+	}
+
+	// a modeline directly above the first key belongs to that key rather
+	// than to the document when no blank line separates them
+	comments := []*string{&doc.root.HeadComment}
+	if len(root.Content) != 0 {
+		comments = append(comments, &root.Content[0].HeadComment)
+	}
+
+	for _, comment := range comments {
+		if schema, rest, OK := cutSchemaDirective(*comment); OK {
+			doc.schema = schema
+			*comment = rest
+			return
+		}
+	}
+}
+
+// cutSchemaDirective finds a YAML or TOML schema directive in a comment block
+// and returns the referenced schema along with the remaining comment lines.
+func cutSchemaDirective(comment string) (schema, rest string, found bool) {
+	lines := strings.Split(comment, "\n")
+
+	for i, line := range lines {
+		for _, prefix := range []string{yamlSchemaPrefix, tomlSchemaPrefix} {
+			if !strings.HasPrefix(line, prefix) {
+				continue
+			}
+
+			schema = strings.TrimSpace(strings.TrimPrefix(line, prefix))
+			lines = append(lines[:i], lines[i+1:]...)
+			return schema, strings.TrimSpace(strings.Join(lines, "\n")), true
+		}
+	}
+
+	return "", comment, false
+}
+
+func parseYAMLDocument(data []byte) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	// an empty file decodes into a zero node
+	if root.Kind == 0 {
+		root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{newMapping()}}
+	}
+
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, errDocumentRoot
+	}
+
+	resolveAliases(&root)
+
+	return &root, nil
+}
+
+// resolveAliases replaces YAML aliases with copies of their anchored node so
+// formats without anchors can still represent the value.
+func resolveAliases(node *yaml.Node) {
+	for i, child := range node.Content {
+		if child.Kind == yaml.AliasNode && child.Alias != nil {
+			clone := *child.Alias
+			clone.Anchor = ""
+			clone.HeadComment = child.HeadComment
+			clone.LineComment = child.LineComment
+			clone.FootComment = child.FootComment
+			node.Content[i] = &clone
+		}
+
+		node.Content[i].Anchor = ""
+		resolveAliases(node.Content[i])
+	}
+}
+
+func encodeYAMLDocument(doc *Document) ([]byte, error) {
+	root := *doc.root
+	root.HeadComment = doc.headComment(yamlSchemaPrefix)
+
+	var result bytes.Buffer
+	encoder := yaml.NewEncoder(&result)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&root); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return result.Bytes(), nil
+}
+
+// headComment returns the document's head comment with the schema reference
+// put back in front using the given format's prefix.
+func (doc *Document) headComment(schemaPrefix string) string {
+	if doc.schema == "" {
+		return doc.root.HeadComment
+	}
+
+	modeline := schemaPrefix + doc.schema
+	if doc.root.HeadComment == "" {
+		return modeline
+	}
+
+	return modeline + "\n\n" + doc.root.HeadComment
+}
+
+func newMapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func newSequence() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+}
+
+func newScalar(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+// mappingIndex returns the index of key's key node in a mapping's Content, or
+// -1 when the mapping does not contain it.
+func mappingIndex(mapping *yaml.Node, key string) int {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return -1
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	index := mappingIndex(mapping, key)
+	if index < 0 {
+		return nil
+	}
+
+	return mapping.Content[index+1]
+}
+
+func removeMappingEntry(mapping *yaml.Node, index int) {
+	mapping.Content = append(mapping.Content[:index], mapping.Content[index+2:]...)
+}
+
+// isNull reports whether a node is an explicit null, which JSON and YAML can
+// express but TOML cannot.
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
+// canonicalNumber rewrites an int or float scalar in the plain decimal form
+// JSON and TOML both accept, dropping YAML/TOML specific spellings such as
+// hex prefixes and digit separators. Anything it does not recognize is
+// returned untouched.
+func canonicalNumber(node *yaml.Node) string {
+	value := strings.ReplaceAll(node.Value, "_", "")
+
+	switch node.ShortTag() {
+	case "!!int":
+		if i, err := strconv.ParseInt(value, 0, 64); err == nil {
+			return strconv.FormatInt(i, 10)
+		}
+
+		if u, err := strconv.ParseUint(value, 0, 64); err == nil {
+			return strconv.FormatUint(u, 10)
+		}
+	case "!!float":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return node.Value
+		}
+
+		if value == node.Value && isJSONNumber(value) {
+			return value
+		}
+
+		formatted := strconv.FormatFloat(f, 'f', -1, 64)
+		if !strings.Contains(formatted, ".") {
+			formatted += ".0"
+		}
+
+		return formatted
+	}
+
+	return node.Value
+}
+
+func isJSONNumber(value string) bool {
+	return regex.MatchString(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`, value)
+}
+
+// commentLines splits a YAML-style comment into its lines with the leading
+// "#" removed, so each encoder can re-prefix them with its own marker. Blank
+// lines inside the comment are kept as empty strings.
+func commentLines(comment string) []string {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil
+	}
+
+	var lines []string
+	for line := range strings.SplitSeq(comment, "\n") {
+		lines = append(lines, strings.TrimPrefix(strings.TrimSpace(line), "#"))
+	}
+
+	return lines
+}
+
+// inlineCommentLines collects the non-blank lines of comments that have to
+// fit on the end of a single line.
+func inlineCommentLines(comments ...string) []string {
+	var lines []string
+	for _, comment := range comments {
+		for _, line := range commentLines(comment) {
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	return lines
+}
+
+// appendComment joins a comment onto an existing YAML-style comment block.
+func appendComment(existing, comment string) string {
+	if existing == "" {
+		return comment
+	}
+
+	return fmt.Sprintf("%s\n%s", existing, comment)
+}
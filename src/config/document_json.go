@@ -0,0 +1,538 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// jsonDocumentParser is a small JSONC parser that, unlike encoding/json,
+// keeps key order and comments. It accepts the same input ParseBytes does
+// (JSON plus // and /* */ comments) and attaches comments to the nodes the
+// way the YAML decoder would: comments on their own lines become the
+// HeadComment of the next entry, a comment after a value on the same line
+// its LineComment, and comments left before a closing brace the FootComment
+// of the last entry.
+type jsonDocumentParser struct {
+	trailing     *string
+	data         []byte
+	pending      string
+	pos          int
+	line         int
+	trailingLine int
+}
+
+func parseJSONDocument(data []byte) (*yaml.Node, error) {
+	p := &jsonDocumentParser{data: data}
+
+	p.skip()
+	head := p.takePending()
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if value.Kind != yaml.MappingNode {
+		return nil, errDocumentRoot
+	}
+
+	root := &yaml.Node{
+		Kind:        yaml.DocumentNode,
+		HeadComment: head,
+		Content:     []*yaml.Node{value},
+	}
+
+	p.trail(&root.FootComment)
+	p.skip()
+
+	if p.pos < len(p.data) {
+		return nil, p.errorf("unexpected data after top-level value")
+	}
+
+	root.FootComment = appendComment(root.FootComment, p.takePending())
+
+	return root, nil
+}
+
+func (p *jsonDocumentParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", p.line+1, fmt.Sprintf(format, args...))
+}
+
+// trail marks target as the receiver of a comment that follows on the
+// current line.
+func (p *jsonDocumentParser) trail(target *string) {
+	p.trailing = target
+	p.trailingLine = p.line
+}
+
+func (p *jsonDocumentParser) takePending() string {
+	pending := p.pending
+	p.pending = ""
+	return pending
+}
+
+func (p *jsonDocumentParser) addComment(comment string, line int) {
+	if p.trailing != nil && line == p.trailingLine {
+		*p.trailing = appendComment(*p.trailing, comment)
+		return
+	}
+
+	p.pending = appendComment(p.pending, comment)
+}
+
+// skip moves past whitespace and comments, collecting the latter.
+func (p *jsonDocumentParser) skip() {
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+
+		switch {
+		case c == '\n':
+			p.line++
+			p.pos++
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case c == '/' && p.peek(1) == '/':
+			start := p.pos + 2
+			end := bytes.IndexByte(p.data[start:], '\n')
+			if end < 0 {
+				end = len(p.data) - start
+			}
+
+			p.addComment("#"+strings.TrimRight(string(p.data[start:start+end]), " \t\r"), p.line)
+			p.pos = start + end
+		case c == '/' && p.peek(1) == '*':
+			start := p.pos + 2
+			end := bytes.Index(p.data[start:], []byte("*/"))
+			if end < 0 {
+				end = len(p.data) - start
+			}
+
+			line := p.line
+			body := string(p.data[start : start+end])
+			for text := range strings.SplitSeq(body, "\n") {
+				text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "*"))
+				if text != "" {
+					p.addComment("# "+text, line)
+				}
+			}
+
+			p.line += strings.Count(body, "\n")
+			p.pos = min(start+end+2, len(p.data))
+		default:
+			return
+		}
+	}
+}
+
+func (p *jsonDocumentParser) peek(offset int) byte {
+	if p.pos+offset >= len(p.data) {
+		return 0
+	}
+
+	return p.data[p.pos+offset]
+}
+
+func (p *jsonDocumentParser) parseValue() (*yaml.Node, error) {
+	p.skip()
+
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		return p.parseObject()
+	case c == '[':
+		return p.parseArray()
+	case c == '"':
+		value, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+
+		return newScalar("!!str", value), nil
+	default:
+		return p.parseLiteral()
+	}
+}
+
+func (p *jsonDocumentParser) parseObject() (*yaml.Node, error) {
+	node := newMapping()
+
+	p.pos++
+	p.trail(&node.LineComment)
+
+	for {
+		p.skip()
+
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unterminated object")
+		}
+
+		if p.data[p.pos] == '}' {
+			p.pos++
+			p.closeContainer(node)
+			return node, nil
+		}
+
+		if p.data[p.pos] != '"' {
+			return nil, p.errorf("expected object key, found %q", p.data[p.pos])
+		}
+
+		head := p.takePending()
+
+		key, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+
+		keyNode := newScalar("!!str", key)
+		keyNode.HeadComment = head
+
+		p.skip()
+		if p.pos >= len(p.data) || p.data[p.pos] != ':' {
+			return nil, p.errorf("expected ':' after object key %q", key)
+		}
+
+		p.pos++
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content, keyNode, value)
+
+		// the YAML decoder keeps a comment after "key:" on the key when the
+		// value is a collection, and one after a scalar on the value
+		if value.Kind == yaml.ScalarNode {
+			p.trail(&value.LineComment)
+		} else {
+			keyNode.LineComment = value.LineComment
+			value.LineComment = ""
+			p.trail(&keyNode.FootComment)
+		}
+
+		if err := p.separator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *jsonDocumentParser) parseArray() (*yaml.Node, error) {
+	node := newSequence()
+
+	p.pos++
+	p.trail(&node.LineComment)
+
+	for {
+		p.skip()
+
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unterminated array")
+		}
+
+		if p.data[p.pos] == ']' {
+			p.pos++
+			p.closeContainer(node)
+			return node, nil
+		}
+
+		head := p.takePending()
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		value.HeadComment = appendComment(head, value.HeadComment)
+		node.Content = append(node.Content, value)
+
+		if value.Kind == yaml.ScalarNode {
+			p.trail(&value.LineComment)
+		} else {
+			p.trail(&value.FootComment)
+		}
+
+		if err := p.separator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// separator consumes the comma between two entries, leaving a closing
+// bracket for the caller's loop to handle.
+func (p *jsonDocumentParser) separator(closing byte) error {
+	p.skip()
+
+	if p.pos >= len(p.data) {
+		return p.errorf("unexpected end of input")
+	}
+
+	switch p.data[p.pos] {
+	case ',':
+		p.pos++
+		return nil
+	case closing:
+		return nil
+	default:
+		return p.errorf("expected ',' or '%c', found %q", closing, p.data[p.pos])
+	}
+}
+
+// closeContainer hands comments collected before a closing bracket to the
+// last entry, or to the container itself when it is empty.
+func (p *jsonDocumentParser) closeContainer(node *yaml.Node) {
+	pending := p.takePending()
+
+	if len(node.Content) == 0 {
+		node.FootComment = appendComment(node.FootComment, pending)
+		return
+	}
+
+	index := len(node.Content) - 1
+	if node.Kind == yaml.MappingNode {
+		index--
+	}
+
+	last := node.Content[index]
+	last.FootComment = appendComment(last.FootComment, pending)
+}
+
+func (p *jsonDocumentParser) parseString() (string, error) {
+	start := p.pos
+	p.pos++
+
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+
+			var value string
+			if err := json.Unmarshal(p.data[start:p.pos], &value); err != nil {
+				return "", p.errorf("invalid string: %v", err)
+			}
+
+			return value, nil
+		case '\n':
+			return "", p.errorf("unterminated string")
+		}
+
+		p.pos++
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+func (p *jsonDocumentParser) parseLiteral() (*yaml.Node, error) {
+	start := p.pos
+
+	for p.pos < len(p.data) && strings.IndexByte("+-.0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ", p.data[p.pos]) >= 0 {
+		p.pos++
+	}
+
+	literal := string(p.data[start:p.pos])
+
+	switch {
+	case literal == "true" || literal == "false":
+		return newScalar("!!bool", literal), nil
+	case literal == "null":
+		return newScalar("!!null", literal), nil
+	case !isJSONNumber(literal):
+		return nil, p.errorf("invalid value %q", literal)
+	case strings.ContainsAny(literal, ".eE"):
+		return newScalar("!!float", literal), nil
+	default:
+		return newScalar("!!int", literal), nil
+	}
+}
+
+// jsonDocumentEncoder writes a document as indented JSON in the layout
+// Export produces, optionally with the comments rewritten as // lines.
+type jsonDocumentEncoder struct {
+	sb       strings.Builder
+	comments bool
+}
+
+func encodeJSONDocument(doc *Document, comments bool) []byte {
+	e := &jsonDocumentEncoder{comments: comments}
+
+	if e.comments && doc.root.HeadComment != "" {
+		e.comment(doc.root.HeadComment, 0)
+		e.sb.WriteString("\n")
+	}
+
+	root := doc.mapping()
+	if doc.schema != "" {
+		withSchema := *root
+		withSchema.Content = append([]*yaml.Node{newScalar("!!str", schemaKey), newScalar("!!str", doc.schema)}, root.Content...)
+		root = &withSchema
+	}
+
+	e.value(root, 0, "")
+	e.sb.WriteString("\n")
+	e.comment(doc.root.FootComment, 0)
+
+	return []byte(e.sb.String())
+}
+
+func (e *jsonDocumentEncoder) indent(depth int) {
+	e.sb.WriteString(strings.Repeat("  ", depth))
+}
+
+func (e *jsonDocumentEncoder) comment(comment string, depth int) {
+	if !e.comments {
+		return
+	}
+
+	for _, line := range commentLines(comment) {
+		if line == "" {
+			e.sb.WriteString("\n")
+			continue
+		}
+
+		e.indent(depth)
+		e.sb.WriteString("//" + line + "\n")
+	}
+}
+
+func (e *jsonDocumentEncoder) lineComment(comments ...string) {
+	if !e.comments {
+		return
+	}
+
+	if lines := inlineCommentLines(comments...); len(lines) != 0 {
+		e.sb.WriteString(" //" + strings.Join(lines, " "))
+	}
+}
+
+func (e *jsonDocumentEncoder) value(node *yaml.Node, depth int, open string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		e.mapping(node, depth, open)
+	case yaml.SequenceNode:
+		e.sequence(node, depth, open)
+	default:
+		e.sb.WriteString(jsonScalar(node))
+	}
+}
+
+func (e *jsonDocumentEncoder) isEmpty(node *yaml.Node, open string) bool {
+	if len(node.Content) != 0 {
+		return false
+	}
+
+	return !e.comments || (open == "" && node.FootComment == "")
+}
+
+func (e *jsonDocumentEncoder) mapping(node *yaml.Node, depth int, open string) {
+	if e.isEmpty(node, open) {
+		e.sb.WriteString("{}")
+		return
+	}
+
+	e.sb.WriteString("{")
+	e.lineComment(open)
+	e.sb.WriteString("\n")
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		e.comment(key.HeadComment, depth+1)
+		e.indent(depth + 1)
+		e.sb.WriteString(jsonString(key.Value) + ": ")
+
+		if value.Kind == yaml.ScalarNode {
+			e.value(value, depth+1, "")
+		} else {
+			e.value(value, depth+1, appendComment(key.LineComment, value.LineComment))
+		}
+
+		if i+2 < len(node.Content) {
+			e.sb.WriteString(",")
+		}
+
+		if value.Kind == yaml.ScalarNode {
+			e.lineComment(key.LineComment, value.LineComment)
+		}
+
+		e.sb.WriteString("\n")
+		e.comment(value.FootComment, depth+1)
+		e.comment(key.FootComment, depth+1)
+	}
+
+	e.comment(node.FootComment, depth+1)
+	e.indent(depth)
+	e.sb.WriteString("}")
+}
+
+func (e *jsonDocumentEncoder) sequence(node *yaml.Node, depth int, open string) {
+	if e.isEmpty(node, open) {
+		e.sb.WriteString("[]")
+		return
+	}
+
+	e.sb.WriteString("[")
+	e.lineComment(open)
+	e.sb.WriteString("\n")
+
+	for i, item := range node.Content {
+		e.comment(item.HeadComment, depth+1)
+		e.indent(depth + 1)
+
+		if item.Kind == yaml.ScalarNode {
+			e.value(item, depth+1, "")
+		} else {
+			e.value(item, depth+1, item.LineComment)
+		}
+
+		if i+1 < len(node.Content) {
+			e.sb.WriteString(",")
+		}
+
+		if item.Kind == yaml.ScalarNode {
+			e.lineComment(item.LineComment)
+		}
+
+		e.sb.WriteString("\n")
+		e.comment(item.FootComment, depth+1)
+	}
+
+	e.comment(node.FootComment, depth+1)
+	e.indent(depth)
+	e.sb.WriteString("]")
+}
+
+func jsonScalar(node *yaml.Node) string {
+	switch node.ShortTag() {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return strings.ToLower(node.Value)
+	case "!!int", "!!float":
+		if number := canonicalNumber(node); isJSONNumber(number) {
+			return number
+		}
+	}
+
+	return jsonString(node.Value)
+}
+
+// jsonString quotes a string the way Export does: no HTML escaping, and
+// glyphs outside the basic plane written as \u escapes.
+func jsonString(value string) string {
+	var result bytes.Buffer
+
+	encoder := json.NewEncoder(&result)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+
+	return EscapeGlyphs(strings.TrimSuffix(result.String(), "\n"), false)
+}
@@ -0,0 +1,359 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const commentedConfig = `// my theme
+
+{
+  "$schema": "https://raw.githubusercontent.com/JanDeDobbeleer/oh-my-posh/main/themes/schema.json",
+  // the blocks
+  "blocks": [
+    {
+      "type": "prompt", // left side
+      "alignment": "left",
+      "segments": [
+        {
+          "type": "path",
+          "template": " {{ if eq .Path \"~\" }}home{{ end }} ",
+          "properties": {
+            "style": "folder"
+          }
+        }
+      ]
+    }
+  ],
+  "final_space": true,
+  "version": 3
+}
+`
+
+// TestConvertDocumentThemes converts every bundled theme to each format and
+// back, asserting the decoded config is identical to the original's.
+func TestConvertDocumentThemes(t *testing.T) {
+	themesDir := filepath.Join("..", "..", "themes")
+
+	entries, err := os.ReadDir(themesDir)
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.Contains(name, ".omp.") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(themesDir, name))
+		require.NoError(t, err)
+
+		from := filepath.Ext(name)
+
+		original, err := ParseBytes(strings.TrimPrefix(from, "."), data)
+		require.NoError(t, err, name)
+
+		expected := original.Export(JSON)
+
+		for _, to := range []string{JSON, JSONC, YAML, TOML} {
+			converted, err := ConvertDocument(data, from, to)
+			require.NoError(t, err, name)
+
+			cfg, err := ParseBytes(to, converted)
+			require.NoError(t, err, "%s to %s:\n%s", name, to, converted)
+
+			assert.Equal(t, expected, cfg.Export(JSON), "%s to %s", name, to)
+		}
+	}
+}
+
+func TestConvertDocumentComments(t *testing.T) {
+	cases := []struct {
+		Case     string
+		To       string
+		Expected []string
+	}{
+		{
+			Case: "yaml",
+			To:   YAML,
+			Expected: []string{
+				"# yaml-language-server: $schema=https://raw.githubusercontent.com/JanDeDobbeleer/oh-my-posh/main/themes/schema.json\n",
+				"# my theme\n",
+				"# the blocks\nblocks:\n",
+				"  - type: prompt # left side\n",
+				"final_space: true\nversion: 3\n",
+			},
+		},
+		{
+			Case: "toml",
+			To:   TOML,
+			Expected: []string{
+				"#:schema https://raw.githubusercontent.com/JanDeDobbeleer/oh-my-posh/main/themes/schema.json\n",
+				"# my theme\n",
+				"final_space = true\nversion = 3\n",
+				"# the blocks\n[[blocks]]\ntype = \"prompt\" # left side\n",
+				"template = ' {{ if eq .Path \"~\" }}home{{ end }} '\n",
+				"[blocks.segments.properties]\nstyle = \"folder\"\n",
+			},
+		},
+		{
+			Case: "jsonc",
+			To:   JSONC,
+			Expected: []string{
+				"// my theme\n\n{\n",
+				"  \"$schema\": \"https://raw.githubusercontent.com/JanDeDobbeleer/oh-my-posh/main/themes/schema.json\",\n",
+				"  // the blocks\n  \"blocks\": [\n",
+				"      \"type\": \"prompt\", // left side\n",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		converted, err := ConvertDocument([]byte(commentedConfig), JSONC, tc.To)
+		require.NoError(t, err, tc.Case)
+
+		for _, expected := range tc.Expected {
+			assert.Contains(t, string(converted), expected, tc.Case)
+		}
+
+		// converting back keeps the comments too
+		back, err := ConvertDocument(converted, tc.To, JSONC)
+		require.NoError(t, err, tc.Case)
+
+		assert.Contains(t, string(back), "// my theme", tc.Case)
+		assert.Contains(t, string(back), "// the blocks", tc.Case)
+		assert.Contains(t, string(back), "// left side", tc.Case)
+	}
+}
+
+func TestConvertDocumentSchemaComment(t *testing.T) {
+	cases := []struct {
+		Case   string
+		Format string
+		Source string
+	}{
+		{
+			Case:   "jsonc",
+			Format: JSONC,
+			Source: "{\n  // my theme\n  \"$schema\": \"https://ohmyposh.dev/schema.json\", // schema\n  \"version\": 3\n}\n",
+		},
+		{
+			Case:   "yaml",
+			Format: YAML,
+			Source: "# my theme\n$schema: https://ohmyposh.dev/schema.json # schema\nversion: 3\n",
+		},
+	}
+
+	for _, tc := range cases {
+		for _, to := range []string{JSONC, YAML, TOML} {
+			converted, err := ConvertDocument([]byte(tc.Source), tc.Format, to)
+			require.NoError(t, err, tc.Case)
+
+			back, err := ConvertDocument(converted, to, tc.Format)
+			require.NoError(t, err, tc.Case)
+
+			for _, result := range [][]byte{converted, back} {
+				assert.Contains(t, string(result), " my theme", "%s to %s", tc.Case, to)
+				assert.Contains(t, string(result), " schema", "%s to %s", tc.Case, to)
+				assert.Contains(t, string(result), "https://ohmyposh.dev/schema.json", "%s to %s", tc.Case, to)
+			}
+		}
+	}
+}
+
+func TestConvertDocumentJSONDropsComments(t *testing.T) {
+	converted, err := ConvertDocument([]byte(commentedConfig), JSONC, JSON)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(converted), "// ")
+
+	cfg, err := ParseBytes(JSON, converted)
+	require.NoError(t, err)
+	assert.Len(t, cfg.Blocks, 1)
+}
+
+func TestConvertDocumentKeyOrder(t *testing.T) {
+	source := "version: 4\nfinal_space: true\nconsole_title_template: '{{ .Folder }}'\naccent_color: '#ff0000'\n"
+
+	converted, err := ConvertDocument([]byte(source), YAML, JSON)
+	require.NoError(t, err)
+
+	expected := `{
+  "version": 4,
+  "final_space": true,
+  "console_title_template": "{{ .Folder }}",
+  "accent_color": "#ff0000"
+}
+`
+	assert.Equal(t, expected, string(converted))
+}
+
+func TestConvertDocumentTOMLValues(t *testing.T) {
+	source := `# header
+
+version = 4 # current
+answer = 0x2A
+big = 1_000
+
+[palette]
+red = "#ff0000"
+
+[[blocks]]
+type = "prompt"
+
+  [[blocks.segments]]
+  type = "text"
+  options = { text = 'say "hi"', max = 3 }
+`
+
+	converted, err := ConvertDocument([]byte(source), TOML, JSONC)
+	require.NoError(t, err)
+
+	expected := `// header
+
+{
+  "version": 4, // current
+  "answer": 42,
+  "big": 1000,
+  "palette": {
+    "red": "#ff0000"
+  },
+  "blocks": [
+    {
+      "type": "prompt",
+      "segments": [
+        {
+          "type": "text",
+          "options": {
+            "text": "say \"hi\"",
+            "max": 3
+          }
+        }
+      ]
+    }
+  ]
+}
+`
+	assert.Equal(t, expected, string(converted))
+}
+
+func TestParseDocumentErrors(t *testing.T) {
+	cases := []struct {
+		Case   string
+		Format string
+		Data   string
+		Error  error
+	}{
+		{Case: "unknown format", Format: "ini", Data: "a=1", Error: ErrInvalidExtension},
+		{Case: "root is not an object", Format: JSON, Data: "[1]", Error: ErrParse},
+		{Case: "trailing data", Format: JSON, Data: "{} x", Error: ErrParse},
+		{Case: "unterminated object", Format: JSONC, Data: `{"a": 1 // open`, Error: ErrParse},
+		{Case: "invalid toml", Format: TOML, Data: "a = ", Error: ErrParse},
+		{Case: "yaml sequence root", Format: YAML, Data: "- a", Error: ErrParse},
+	}
+
+	for _, tc := range cases {
+		_, err := ParseDocument(tc.Format, []byte(tc.Data))
+		assert.ErrorIs(t, err, tc.Error, tc.Case)
+	}
+}
+
+func TestDocumentMigrate(t *testing.T) {
+	doc, err := ParseDocument(JSONC, []byte(commentedConfig))
+	require.NoError(t, err)
+
+	assert.True(t, doc.Migrate())
+
+	migrated, err := doc.Encode("")
+	require.NoError(t, err)
+
+	assert.Contains(t, string(migrated), "\"options\": {")
+	assert.NotContains(t, string(migrated), "\"properties\"")
+	assert.Contains(t, string(migrated), "\"version\": 4\n")
+	assert.Contains(t, string(migrated), "\"type\": \"prompt\", // left side\n")
+
+	// nothing left to do the second time around
+	assert.False(t, doc.Migrate())
+}
+
+func TestDocumentMigrateAddsVersion(t *testing.T) {
+	doc, err := ParseDocument(YAML, []byte("# no version\nfinal_space: true\n"))
+	require.NoError(t, err)
+
+	assert.True(t, doc.Migrate())
+
+	migrated, err := doc.Encode("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "version: 4\n# no version\nfinal_space: true\n", string(migrated))
+}
+
+func TestMigrateDocumentGlyphs(t *testing.T) {
+	doc, err := ParseDocument(JSON, []byte(`{"blocks": [{"segments": [{"type": "path", "template": "\uf115 {{ .Path }}"}]}]}`))
+	require.NoError(t, err)
+
+	cp := codePoints{0xf115: 0xf07c}
+
+	assert.True(t, migrateGlyphs(doc.mapping(), cp))
+
+	migrated, err := doc.Encode("")
+	require.NoError(t, err)
+
+	assert.Contains(t, string(migrated), `"template": "\uf07c {{ .Path }}"`)
+	assert.False(t, migrateGlyphs(doc.mapping(), cp))
+}
+
+func TestDocumentWrite(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "theme.omp.json")
+	require.NoError(t, os.WriteFile(source, []byte(commentedConfig), 0o644))
+
+	doc, err := ReadDocument(source)
+	require.NoError(t, err)
+
+	target, err := doc.Write(YAML)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "theme.omp.yaml"), target)
+	assert.NoFileExists(t, source+".bak", "a conversion must not touch the original")
+
+	target, err = doc.Write("")
+	require.NoError(t, err)
+	assert.Equal(t, source, target)
+	assert.FileExists(t, source+".bak")
+}
+
+func TestConvertDocumentTOMLKeepsValuesAsWritten(t *testing.T) {
+	source := `answer = 0x2A
+big = 1_000
+ratio = 1e3
+since = 1979-05-27T07:32:00Z
+day = 1979-05-27
+text = 'say "hi"'
+items = [0b101, "a", { mask = 0o755, at = 07:32:00 }]
+
+[options]
+mask = 0o755
+`
+
+	converted, err := ConvertDocument([]byte(source), TOML, TOML)
+	require.NoError(t, err)
+	assert.Equal(t, source, string(converted))
+}
+
+func TestConvertDocumentTOMLDropsNullMembers(t *testing.T) {
+	source := "list: [1, {a: ~}, {b: 2, c: ~}]\noptions: {text: ~, max: 3}\n"
+
+	converted, err := ConvertDocument([]byte(source), YAML, TOML)
+	require.NoError(t, err)
+
+	expected := `list = [1, {}, { b = 2 }]
+
+[options]
+max = 3
+`
+	assert.Equal(t, expected, string(converted))
+}
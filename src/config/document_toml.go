@@ -0,0 +1,584 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/regex"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// tomlDocumentParser builds a document tree from go-toml's expression
+// parser. TOML scatters a nested structure over table headers, so every
+// expression is resolved against the tree built so far: key-values land in
+// the current table, [table] headers walk down from the root and [[array]]
+// headers append a new element that the following keys are written into.
+//
+// The parser only reports comments on their own lines; a comment trailing a
+// scalar value or a table header is recovered from the source bytes. Comments
+// inside multi-line arrays or after a collection value are not reported by
+// go-toml at all and are lost.
+//
+// Scalars are stored in the form the other formats understand, so a hex
+// integer becomes decimal and a datetime a string. Their source text is kept
+// in tokens to write them back as they were when the target is TOML again.
+type tomlDocumentParser struct {
+	parser  unstable.Parser
+	root    *yaml.Node
+	current *yaml.Node
+	tokens  map[*yaml.Node]tomlToken
+	pending string
+	// commentEnd is the offset right after the last pending comment, used to
+	// tell a file's header comment apart from the first key's own comment
+	commentEnd int
+	started    bool
+}
+
+// tomlToken is a scalar's text in the TOML source, valid as long as the
+// node still holds the value it was parsed into.
+type tomlToken struct {
+	value string
+	text  string
+}
+
+func parseTOMLDocument(data []byte) (*yaml.Node, map[*yaml.Node]tomlToken, error) {
+	p := &tomlDocumentParser{
+		root:   newMapping(),
+		tokens: make(map[*yaml.Node]tomlToken),
+	}
+
+	p.current = p.root
+	p.parser.KeepComments = true
+	p.parser.Reset(data)
+
+	document := &yaml.Node{
+		Kind:    yaml.DocumentNode,
+		Content: []*yaml.Node{p.root},
+	}
+
+	for p.parser.NextExpression() {
+		expression := p.parser.Expression()
+
+		if expression.Kind == unstable.Comment {
+			p.pending = appendComment(p.pending, strings.TrimRight(string(expression.Data), " \t\r"))
+			raw := p.parser.Range(expression.Data)
+			p.commentEnd = int(raw.Offset + raw.Length)
+			continue
+		}
+
+		if !p.started {
+			p.started = true
+			document.HeadComment = p.headerComment(expression)
+		}
+
+		var err error
+
+		switch expression.Kind {
+		case unstable.KeyValue:
+			err = p.keyValue(p.current, expression)
+		case unstable.Table:
+			err = p.table(expression)
+		case unstable.ArrayTable:
+			err = p.arrayTable(expression)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := p.parser.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	document.FootComment = p.takePending()
+
+	return document, p.tokens, nil
+}
+
+// headerComment returns the comments at the top of the file when a blank
+// line separates them from the first expression, the same rule the YAML
+// decoder applies to a document's head comment.
+func (p *tomlDocumentParser) headerComment(expression *unstable.Node) string {
+	if p.pending == "" {
+		return ""
+	}
+
+	keys := expression.Key()
+	if !keys.Next() {
+		return ""
+	}
+
+	start := int(keys.Node().Raw.Offset)
+	gap := p.parser.Data()[p.commentEnd:start]
+
+	if bytes.Count(gap, []byte("\n")) < 2 {
+		return ""
+	}
+
+	return p.takePending()
+}
+
+func (p *tomlDocumentParser) takePending() string {
+	pending := p.pending
+	p.pending = ""
+	return pending
+}
+
+// lineComment returns the comment trailing offset on the same source line.
+func (p *tomlDocumentParser) lineComment(offset int) string {
+	data := p.parser.Data()
+
+	end := offset
+	for end < len(data) && data[end] != '\n' {
+		end++
+	}
+
+	index := bytes.IndexByte(data[offset:end], '#')
+	if index < 0 {
+		return ""
+	}
+
+	return strings.TrimRight(string(data[offset+index:end]), " \t\r")
+}
+
+func (p *tomlDocumentParser) keys(expression *unstable.Node) ([]string, int) {
+	var keys []string
+	var end int
+
+	it := expression.Key()
+	for it.Next() {
+		key := it.Node()
+		keys = append(keys, string(key.Data))
+		end = int(key.Raw.Offset + key.Raw.Length)
+	}
+
+	return keys, end
+}
+
+// child returns the table stored under key in parent, creating it when
+// missing. A key holding an array of tables resolves to its last element,
+// which is what a TOML header or dotted key addresses.
+func (p *tomlDocumentParser) child(parent *yaml.Node, key string) (*yaml.Node, *yaml.Node, error) {
+	index := mappingIndex(parent, key)
+	if index < 0 {
+		keyNode := newScalar("!!str", key)
+		value := newMapping()
+		parent.Content = append(parent.Content, keyNode, value)
+		return keyNode, value, nil
+	}
+
+	keyNode, value := parent.Content[index], parent.Content[index+1]
+
+	switch {
+	case value.Kind == yaml.MappingNode:
+		return keyNode, value, nil
+	case value.Kind == yaml.SequenceNode && len(value.Content) != 0:
+		last := value.Content[len(value.Content)-1]
+		if last.Kind == yaml.MappingNode {
+			return keyNode, last, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("key %q is not a table", key)
+}
+
+func (p *tomlDocumentParser) walk(parent *yaml.Node, keys []string) (*yaml.Node, error) {
+	for _, key := range keys {
+		var err error
+		if _, parent, err = p.child(parent, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return parent, nil
+}
+
+func (p *tomlDocumentParser) keyValue(table *yaml.Node, expression *unstable.Node) error {
+	keys, _ := p.keys(expression)
+
+	parent, err := p.walk(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	key := keys[len(keys)-1]
+	if mappingIndex(parent, key) >= 0 {
+		return fmt.Errorf("duplicate key %q", key)
+	}
+
+	value, err := p.value(expression.Value())
+	if err != nil {
+		return err
+	}
+
+	keyNode := newScalar("!!str", key)
+	keyNode.HeadComment = p.takePending()
+
+	if value.Kind == yaml.ScalarNode {
+		raw := expression.Value().Raw
+		value.LineComment = p.lineComment(int(raw.Offset + raw.Length))
+	}
+
+	parent.Content = append(parent.Content, keyNode, value)
+
+	return nil
+}
+
+func (p *tomlDocumentParser) table(expression *unstable.Node) error {
+	keys, end := p.keys(expression)
+
+	parent, err := p.walk(p.root, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	keyNode, table, err := p.child(parent, keys[len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	keyNode.HeadComment = appendComment(keyNode.HeadComment, p.takePending())
+	keyNode.LineComment = appendComment(keyNode.LineComment, p.lineComment(end))
+	p.current = table
+
+	return nil
+}
+
+func (p *tomlDocumentParser) arrayTable(expression *unstable.Node) error {
+	keys, end := p.keys(expression)
+
+	parent, err := p.walk(p.root, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	key := keys[len(keys)-1]
+
+	array := mappingValue(parent, key)
+	if array == nil {
+		array = newSequence()
+		parent.Content = append(parent.Content, newScalar("!!str", key), array)
+	}
+
+	if array.Kind != yaml.SequenceNode {
+		return fmt.Errorf("key %q is not an array of tables", key)
+	}
+
+	item := newMapping()
+	item.HeadComment = p.takePending()
+	item.LineComment = p.lineComment(end)
+	array.Content = append(array.Content, item)
+	p.current = item
+
+	return nil
+}
+
+func (p *tomlDocumentParser) value(node *unstable.Node) (*yaml.Node, error) {
+	switch node.Kind {
+	case unstable.String:
+		return p.scalar(node, newScalar("!!str", string(node.Data))), nil
+	case unstable.Bool:
+		return p.scalar(node, newScalar("!!bool", string(node.Data))), nil
+	case unstable.Integer:
+		value := newScalar("!!int", string(node.Data))
+		value.Value = canonicalNumber(value)
+		return p.scalar(node, value), nil
+	case unstable.Float:
+		return p.scalar(node, newScalar("!!float", tomlFloatToYAML(string(node.Data)))), nil
+	case unstable.Array:
+		sequence := newSequence()
+
+		it := node.Children()
+		for it.Next() {
+			item, err := p.value(it.Node())
+			if err != nil {
+				return nil, err
+			}
+
+			sequence.Content = append(sequence.Content, item)
+		}
+
+		return sequence, nil
+	case unstable.InlineTable:
+		mapping := newMapping()
+
+		it := node.Children()
+		for it.Next() {
+			if err := p.keyValue(mapping, it.Node()); err != nil {
+				return nil, err
+			}
+		}
+
+		return mapping, nil
+	default:
+		// dates and times have no counterpart in the other formats
+		return p.scalar(node, newScalar("!!str", string(node.Data))), nil
+	}
+}
+
+// scalar records the source text of node for value.
+func (p *tomlDocumentParser) scalar(node *unstable.Node, value *yaml.Node) *yaml.Node {
+	p.tokens[value] = tomlToken{
+		value: value.Value,
+		text:  string(p.parser.Raw(node.Raw)),
+	}
+
+	return value
+}
+
+func tomlFloatToYAML(value string) string {
+	switch strings.TrimPrefix(value, "+") {
+	case "inf":
+		return ".inf"
+	case "-inf":
+		return "-.inf"
+	case "nan", "-nan":
+		return ".nan"
+	default:
+		return strings.ReplaceAll(value, "_", "")
+	}
+}
+
+// tomlDocumentEncoder writes a document as TOML. TOML requires a table's
+// plain keys to precede its sub-tables, so within each table the key-values
+// are written first and the [table] / [[array]] sections after them; that is
+// the only reordering the encoder does.
+type tomlDocumentEncoder struct {
+	tokens map[*yaml.Node]tomlToken
+	sb     strings.Builder
+}
+
+func encodeTOMLDocument(doc *Document) []byte {
+	e := &tomlDocumentEncoder{
+		tokens: doc.tokens,
+	}
+
+	if head := doc.headComment(tomlSchemaPrefix); head != "" {
+		e.comment(head)
+		e.sb.WriteString("\n")
+	}
+
+	e.table(nil, doc.mapping())
+	e.comment(doc.root.FootComment)
+
+	return []byte(e.sb.String())
+}
+
+func (e *tomlDocumentEncoder) comment(comment string) {
+	for _, line := range commentLines(comment) {
+		if line == "" {
+			e.sb.WriteString("\n")
+			continue
+		}
+
+		e.sb.WriteString("#" + line + "\n")
+	}
+}
+
+func (e *tomlDocumentEncoder) lineComment(comments ...string) {
+	if lines := inlineCommentLines(comments...); len(lines) != 0 {
+		e.sb.WriteString(" #" + strings.Join(lines, " "))
+	}
+}
+
+// section separates a table header from what precedes it.
+func (e *tomlDocumentEncoder) section() {
+	if e.sb.Len() != 0 {
+		e.sb.WriteString("\n")
+	}
+}
+
+func isTOMLTable(node *yaml.Node) bool {
+	return node.Kind == yaml.MappingNode && len(node.Content) != 0
+}
+
+func isTOMLArrayOfTables(node *yaml.Node) bool {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return false
+	}
+
+	for _, item := range node.Content {
+		if item.Kind != yaml.MappingNode {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e *tomlDocumentEncoder) table(path []string, node *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		if isNull(value) || isTOMLTable(value) || isTOMLArrayOfTables(value) {
+			continue
+		}
+
+		e.comment(key.HeadComment)
+		e.sb.WriteString(tomlKey(key.Value) + " = " + e.inline(value))
+		e.lineComment(key.LineComment, value.LineComment)
+		e.sb.WriteString("\n")
+		e.comment(value.FootComment)
+		e.comment(key.FootComment)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		childPath := append(append([]string{}, path...), tomlKey(key.Value))
+		header := strings.Join(childPath, ".")
+
+		switch {
+		case isTOMLTable(value):
+			e.section()
+			e.comment(key.HeadComment)
+			e.sb.WriteString("[" + header + "]")
+			e.lineComment(key.LineComment, value.LineComment)
+			e.sb.WriteString("\n")
+			e.table(childPath, value)
+			e.comment(value.FootComment)
+			e.comment(key.FootComment)
+		case isTOMLArrayOfTables(value):
+			for j, item := range value.Content {
+				e.section()
+
+				if j == 0 {
+					e.comment(key.HeadComment)
+				}
+
+				e.comment(item.HeadComment)
+				e.sb.WriteString("[[" + header + "]]")
+
+				if j == 0 {
+					e.lineComment(key.LineComment, item.LineComment)
+				} else {
+					e.lineComment(item.LineComment)
+				}
+
+				e.sb.WriteString("\n")
+				e.table(childPath, item)
+				e.comment(item.FootComment)
+			}
+
+			e.comment(key.FootComment)
+		}
+	}
+}
+
+func (e *tomlDocumentEncoder) inline(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if isNull(item) {
+				continue
+			}
+
+			items = append(items, e.inline(item))
+		}
+
+		return "[" + strings.Join(items, ", ") + "]"
+	case yaml.MappingNode:
+		pairs := make([]string, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if isNull(node.Content[i+1]) {
+				continue
+			}
+
+			pairs = append(pairs, tomlKey(node.Content[i].Value)+" = "+e.inline(node.Content[i+1]))
+		}
+
+		if len(pairs) == 0 {
+			return "{}"
+		}
+
+		return "{ " + strings.Join(pairs, ", ") + " }"
+	default:
+		return e.scalar(node)
+	}
+}
+
+func (e *tomlDocumentEncoder) scalar(node *yaml.Node) string {
+	if token, OK := e.tokens[node]; OK && token.value == node.Value {
+		return token.text
+	}
+
+	switch node.ShortTag() {
+	case "!!bool":
+		return strings.ToLower(node.Value)
+	case "!!int":
+		return canonicalNumber(node)
+	case "!!float":
+		switch strings.ToLower(node.Value) {
+		case ".inf", "+.inf":
+			return "inf"
+		case "-.inf":
+			return "-inf"
+		case ".nan":
+			return "nan"
+		}
+
+		return canonicalNumber(node)
+	default:
+		return tomlString(node.Value)
+	}
+}
+
+func tomlKey(key string) string {
+	if regex.MatchString(`^[A-Za-z0-9_-]+$`, key) {
+		return key
+	}
+
+	return tomlString(key)
+}
+
+// tomlString prefers a 'literal string' for text full of quotes or
+// backslashes - templates mostly - so it stays readable, and falls back to a
+// "basic string" with escapes otherwise.
+func tomlString(value string) string {
+	hasControl := strings.ContainsFunc(value, func(r rune) bool {
+		return unicode.IsControl(r)
+	})
+
+	if !hasControl && !strings.Contains(value, "'") && strings.ContainsAny(value, `"\`) {
+		return "'" + value + "'"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`"`)
+
+	for _, r := range value {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if unicode.IsControl(r) {
+				sb.WriteString(`\u` + fmt.Sprintf("%04X", r))
+				continue
+			}
+
+			sb.WriteRune(r)
+		}
+	}
+
+	sb.WriteString(`"`)
+
+	return sb.String()
+}
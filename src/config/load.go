@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"hash/fnv"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
 	runtimelib "runtime"
+	"slices"
 	"strings"
 	"time"
 
@@ -159,6 +161,7 @@ func Parse(configFile string) (*Config, error) {
 	// Migrate segment properties to options for TOML configs
 	// (go-toml/v2 doesn't support custom unmarshalers)
 	cfg.migrateSegmentProperties()
+	cfg.checkOptions()
 
 	cfg.toggleSegments()
 
@@ -269,6 +272,23 @@ func read(configFile string, h hashWriter) (*Config, error) {
 // caller that must not touch the network at all is the entire reason this
 // path exists.
 func ParseBytes(format string, data []byte) (*Config, error) {
+	cfg, err := decodeBytes(format, data)
+	if errors.Is(err, ErrInvalidExtension) {
+		log.Errorf("unsupported config file format: %s", format)
+		return nil, err
+	}
+
+	if err != nil {
+		log.Errorf("failed to parse config: %v", err)
+		return nil, ErrParse
+	}
+
+	return cfg, nil
+}
+
+// decodeBytes is ParseBytes returning the decoder's own error, which Validate
+// needs to point at where the config stopped parsing.
+func decodeBytes(format string, data []byte) (*Config, error) {
 	var cfg Config
 	cfg.Format = format
 
@@ -281,8 +301,8 @@ func ParseBytes(format string, data []byte) (*Config, error) {
 	case JSONC, JSON:
 		cfg.Format = JSON
 
-		str := text.StripJSONComments(string(data))
-		data = []byte(str)
+		// blanked rather than stripped, so an error's offset is one into the text as written
+		data = []byte(text.BlankJSONComments(string(data)))
 
 		decoder := json.NewDecoder(bytes.NewReader(data))
 		parseErr = decoder.Decode(&cfg)
@@ -294,20 +314,21 @@ func ParseBytes(format string, data []byte) (*Config, error) {
 		// error it is.
 		if parseErr == nil {
 			if _, tokErr := decoder.Token(); tokErr != io.EOF {
-				parseErr = fmt.Errorf("unexpected data after top-level value")
+				offset := decoder.InputOffset()
+				rest := data[offset:]
+				offset += int64(len(rest) - len(bytes.TrimLeft(rest, " \t\r\n")))
+				parseErr = &trailingDataError{offset: offset}
 			}
 		}
 	case TOML, TML:
 		cfg.Format = TOML
 		parseErr = toml.Unmarshal(data, &cfg)
 	default:
-		log.Errorf("unsupported config file format: %s", cfg.Format)
 		return nil, ErrInvalidExtension
 	}
 
 	if parseErr != nil {
-		log.Errorf("failed to parse config: %v", parseErr)
-		return nil, ErrParse
+		return nil, parseErr
 	}
 
 	populatePresence(&cfg, data)
@@ -315,6 +336,16 @@ func ParseBytes(format string, data []byte) (*Config, error) {
 	return &cfg, nil
 }
 
+// trailingDataError is what follows a JSON config's top-level value, offset
+// bytes into it.
+type trailingDataError struct {
+	offset int64
+}
+
+func (e *trailingDataError) Error() string {
+	return "unexpected data after top-level value"
+}
+
 // populatePresence records which top-level keys - and, within them, which
 // block and segment keys - were present in the decoded source config. merge()
 // consults this to distinguish an explicitly set zero value from a field the
@@ -544,6 +575,11 @@ var themes = map[string]string{
 	"zash":                     "zash.omp.json",
 }
 
+// Themes lists the names of the bundled themes, as accepted by --config.
+func Themes() []string {
+	return slices.Sorted(maps.Keys(themes))
+}
+
 func isTheme(config string) (string, bool) {
 	themeFile, OK := themes[config]
 	if !OK {
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -226,3 +227,12 @@ func TestParseExtendsPreservesUnsetScalarFields(t *testing.T) {
 		})
 	}
 }
+
+func TestThemesMatchBundledFiles(t *testing.T) {
+	names := Themes()
+	assert.True(t, slices.IsSorted(names), "theme names are sorted for completion")
+
+	for _, name := range names {
+		assert.FileExists(t, filepath.Join("..", "..", "themes", themes[name]), name)
+	}
+}
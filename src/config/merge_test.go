@@ -96,6 +96,31 @@ func TestConfigMerge(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "merge partials map",
+			baseConfig: &Config{
+				Partials: map[string]string{
+					"git":  "{{ .HEAD }}",
+					"path": "{{ .Path }}",
+				},
+				Version: 3,
+			},
+			overrideConfig: &Config{
+				Partials: map[string]string{
+					"path": "{{ .Folder }}",
+				},
+				Version: 3,
+			},
+			expectedResult: &Config{
+				Partials: map[string]string{
+					"git":  "{{ .HEAD }}",
+					"path": "{{ .Folder }}",
+				},
+				Version:  3,
+				extended: true,
+			},
+			expectError: false,
+		},
 		{
 			name: "merge blocks with matching alignment",
 			baseConfig: &Config{
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"strconv"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// segmentKeys are the top-level keys holding a single segment.
+var segmentKeys = []string{"debug_prompt", "valid_line", "secondary_prompt", "transient_prompt", "error_line"}
+
+// Migrate brings the document up to the current config Version as a set of
+// edits to the tree, so everything a migration does not need to change -
+// comments, key order, formatting of untouched values - survives as is.
+// It returns whether anything changed.
+func (doc *Document) Migrate() bool {
+	var changed bool
+
+	for _, segment := range doc.segments() {
+		if migrateSegmentOptions(segment) {
+			changed = true
+		}
+	}
+
+	if doc.migrateVersion() {
+		changed = true
+	}
+
+	return changed
+}
+
+// MigrateGlyphs moves Nerd Font glyphs in every string value to their
+// current code points (see getGlyphCodePoints). Keys and comments are left
+// alone. It returns whether anything changed.
+func (doc *Document) MigrateGlyphs() (bool, error) {
+	cp, err := getGlyphCodePoints()
+	if err != nil {
+		return false, err
+	}
+
+	return migrateGlyphs(doc.mapping(), cp), nil
+}
+
+func migrateGlyphs(node *yaml.Node, cp codePoints) bool {
+	var changed bool
+
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" {
+		runes := []rune(node.Value)
+		for i, r := range runes {
+			if val, OK := cp[uint64(r)]; OK {
+				runes[i] = rune(val)
+				changed = true
+			}
+		}
+
+		if changed {
+			node.Value = string(runes)
+		}
+
+		return changed
+	}
+
+	for i, child := range node.Content {
+		// mapping keys are never glyphs
+		if node.Kind == yaml.MappingNode && i%2 == 0 {
+			continue
+		}
+
+		if migrateGlyphs(child, cp) {
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// segments returns every segment mapping in the document: those in blocks,
+// the tooltips and the single-segment prompts.
+func (doc *Document) segments() []*yaml.Node {
+	root := doc.mapping()
+
+	var segments []*yaml.Node
+
+	appendMappings := func(sequence *yaml.Node) {
+		if sequence == nil || sequence.Kind != yaml.SequenceNode {
+			return
+		}
+
+		for _, item := range sequence.Content {
+			if item.Kind == yaml.MappingNode {
+				segments = append(segments, item)
+			}
+		}
+	}
+
+	if blocks := mappingValue(root, "blocks"); blocks != nil && blocks.Kind == yaml.SequenceNode {
+		for _, block := range blocks.Content {
+			appendMappings(mappingValue(block, "segments"))
+		}
+	}
+
+	appendMappings(mappingValue(root, "tooltips"))
+
+	for _, key := range segmentKeys {
+		if segment := mappingValue(root, key); segment != nil && segment.Kind == yaml.MappingNode {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+// migrateSegmentOptions renames a segment's legacy "properties" key to
+// "options" in place, the same move Segment.MigratePropertiesToOptions does
+// on the decoded struct. A segment that already has options keeps both, as
+// the decoder ignores properties in that case anyway.
+func migrateSegmentOptions(segment *yaml.Node) bool {
+	index := mappingIndex(segment, "properties")
+	if index < 0 || mappingIndex(segment, "options") >= 0 {
+		return false
+	}
+
+	segment.Content[index].Value = "options"
+
+	return true
+}
+
+func (doc *Document) migrateVersion() bool {
+	root := doc.mapping()
+	version := strconv.Itoa(Version)
+
+	value := mappingValue(root, "version")
+	if value == nil {
+		root.Content = append([]*yaml.Node{newScalar("!!str", "version"), newScalar("!!int", version)}, root.Content...)
+		return true
+	}
+
+	if value.Value == version {
+		return false
+	}
+
+	value.Tag = "!!int"
+	value.Value = version
+	value.Style = 0
+
+	return true
+}
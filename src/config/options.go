@@ -0,0 +1,81 @@
+package config
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+)
+
+// checkOptions validates the options of every segment once, when the config
+// is parsed, and keeps the result with the config in the session cache so a
+// render doesn't repeat it, see Segment.checkOptions.
+func (cfg *Config) checkOptions() {
+	for _, block := range cfg.Blocks {
+		for _, segment := range block.Segments {
+			segment.checkOptions()
+		}
+	}
+
+	for _, tooltip := range cfg.Tooltips {
+		tooltip.checkOptions()
+	}
+}
+
+// checkOptions records the options the segment's writer doesn't declare or
+// holds a value for it can't read. The config keeps them as written so
+// exporting it never loses a setting; config validate reports them, the log
+// only says why the writer falls back to its default.
+func (segment *Segment) checkOptions() {
+	segment.IgnoredOptions = nil
+
+	if len(segment.Options) == 0 {
+		return
+	}
+
+	writer, err := newSegmentWriter(segment.Type)
+	if err != nil || writer == nil {
+		return
+	}
+
+	declarations, ok := options.Declarations(writer)
+	if !ok {
+		return
+	}
+
+	errs := options.Validate(declarations, segment.Options)
+
+	for _, name := range slices.Sorted(maps.Keys(errs)) {
+		log.Errorf("segment %s: %s", segment.Name(), errs[name])
+		segment.IgnoredOptions = append(segment.IgnoredOptions, name)
+	}
+}
+
+// readableOptions returns the options the writer gets to read: the segment's
+// own, minus the ones checkOptions found it ignores.
+func (segment *Segment) readableOptions() options.Map {
+	if len(segment.IgnoredOptions) == 0 {
+		return segment.Options
+	}
+
+	readable := maps.Clone(segment.Options)
+
+	for _, name := range segment.IgnoredOptions {
+		delete(readable, name)
+	}
+
+	return readable
+}
+
+// SegmentOptions returns the options a segment type accepts, and false when
+// the type is unknown or its writer declares none, like in the wasm build
+// that has no writers to ask.
+func SegmentOptions(segmentType SegmentType) ([]options.Declaration, bool) {
+	writer, err := newSegmentWriter(segmentType)
+	if err != nil || writer == nil {
+		return nil, false
+	}
+
+	return options.Declarations(writer)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadableOptions(t *testing.T) {
+	cases := []struct {
+		Options  options.Map
+		Expected options.Map
+		Case     string
+		Type     SegmentType
+	}{
+		{
+			Case:     "valid",
+			Type:     PATH,
+			Options:  options.Map{"style": "folder", "max_depth": float64(2)},
+			Expected: options.Map{"style": "folder", "max_depth": float64(2)},
+		},
+		{
+			Case:     "unknown option",
+			Type:     PATH,
+			Options:  options.Map{"style": "folder", "stlye": "full"},
+			Expected: options.Map{"style": "folder"},
+		},
+		{
+			Case:     "mistyped option",
+			Type:     GIT,
+			Options:  options.Map{"fetch_status": "true", "branch_icon": ""},
+			Expected: options.Map{"branch_icon": ""},
+		},
+		{
+			Case:     "inherited language option",
+			Type:     NODE,
+			Options:  options.Map{"fetch_version": false},
+			Expected: options.Map{"fetch_version": false},
+		},
+		{
+			Case:     "additional option",
+			Type:     OS,
+			Options:  options.Map{"ubuntu": "U", "display_distro_name": "yes"},
+			Expected: options.Map{"ubuntu": "U"},
+		},
+		{
+			Case:     "segment without options",
+			Type:     TEXT,
+			Options:  options.Map{"text": "hello"},
+			Expected: options.Map{},
+		},
+	}
+
+	for _, tc := range cases {
+		segment := &Segment{Type: tc.Type, Options: maps.Clone(tc.Options)}
+
+		segment.checkOptions()
+
+		assert.Equal(t, tc.Expected, segment.readableOptions(), tc.Case)
+		// the config keeps what the user wrote, so exporting it loses nothing
+		assert.Equal(t, tc.Options, segment.Options, tc.Case)
+	}
+}
+
+func TestParseChecksOptions(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.omp.json")
+	content := `{"blocks": [{"type": "prompt", "segments": [{"type": "path", "options": {"style": "folder", "stlye": "full"}}]}]}`
+	assert.NoError(t, os.WriteFile(configFile, []byte(content), 0644))
+
+	cfg, err := Parse(configFile)
+	assert.NoError(t, err)
+
+	segment := cfg.Blocks[0].Segments[0]
+	assert.Equal(t, []options.Option{"stlye"}, segment.IgnoredOptions)
+
+	// a render reads the result from the cached config instead of validating again
+	var restored Config
+	assert.NoError(t, restored.Restore(cfg.Base64()))
+	assert.Equal(t, options.Map{"style": "folder"}, restored.Blocks[0].Segments[0].readableOptions())
+}
@@ -48,9 +48,10 @@ type Segment struct {
 	// normally stores it (SegmentWriter.SetText/Text), which a build with no writers cannot do.
 	text                   string
 	env                    runtime.Environment
-	Options                options.Map `json:"options,omitempty" toml:"options,omitempty" yaml:"options,omitempty"`
-	Properties             options.Map `json:"-" toml:"properties,omitempty" yaml:"-"`
-	Cache                  *Cache      `json:"cache,omitempty" toml:"cache,omitempty" yaml:"cache,omitempty"`
+	Options                options.Map      `json:"options,omitempty" toml:"options,omitempty" yaml:"options,omitempty"`
+	Properties             options.Map      `json:"-" toml:"properties,omitempty" yaml:"-"`
+	IgnoredOptions         []options.Option `json:"-" toml:"-" yaml:"-"`
+	Cache                  *Cache           `json:"cache,omitempty" toml:"cache,omitempty" yaml:"cache,omitempty"`
 	presentFields          map[string]bool
 	Alias                  string `json:"alias,omitempty" toml:"alias,omitempty" yaml:"alias,omitempty"`
 	styleCache             SegmentStyle
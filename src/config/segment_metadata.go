@@ -0,0 +1,173 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+)
+
+// SegmentMetadata describes a segment type for an editor: the options it
+// accepts and the fields its template can read. Options is nil when the
+// writer declares none at all, so options set on it aren't checked, and empty
+// when it declares it accepts none.
+type SegmentMetadata struct {
+	Type    SegmentType     `json:"type"`
+	Options []SegmentOption `json:"options"`
+	Fields  []TemplateField `json:"fields"`
+}
+
+// SegmentOption is an options.Declaration as an editor reads it.
+type SegmentOption struct {
+	Default     any            `json:"default,omitempty"`
+	Name        options.Option `json:"name"`
+	Type        options.Type   `json:"type"`
+	Description string         `json:"description,omitempty"`
+	Values      []string       `json:"values,omitempty"`
+}
+
+// TemplateField is a name a segment's template can read, {{ .Name }}, and the
+// Go type of its value. Method is set when the name calls a method rather
+// than reading a field.
+type TemplateField struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Method bool   `json:"method,omitempty"`
+}
+
+// writerMethods are the SegmentWriter methods every writer implements. They
+// drive the segment rather than describe what it renders.
+var writerMethods = map[string]bool{
+	"Enabled":  true,
+	"Template": true,
+	"Init":     true,
+	"CacheKey": true,
+}
+
+// Declarations returns the segment's options in the form options.Validate
+// takes, and whether it declared them at all.
+func (m *SegmentMetadata) Declarations() ([]options.Declaration, bool) {
+	if m.Options == nil {
+		return nil, false
+	}
+
+	declarations := make([]options.Declaration, 0, len(m.Options))
+	for _, option := range m.Options {
+		declarations = append(declarations, options.Declaration{
+			Default:     option.Default,
+			Name:        option.Name,
+			Type:        option.Type,
+			Description: option.Description,
+			Values:      option.Values,
+		})
+	}
+
+	return declarations, true
+}
+
+// describeSegment builds the metadata of a segment type from one of its
+// writers.
+func describeSegment(segmentType SegmentType, writer SegmentWriter) SegmentMetadata {
+	metadata := SegmentMetadata{
+		Type:   segmentType,
+		Fields: templateFields(writer),
+	}
+
+	declarations, ok := options.Declarations(writer)
+	if !ok {
+		return metadata
+	}
+
+	metadata.Options = make([]SegmentOption, 0, len(declarations))
+	for _, declaration := range declarations {
+		metadata.Options = append(metadata.Options, SegmentOption{
+			Default:     declaration.Default,
+			Name:        declaration.Name,
+			Type:        declaration.Type,
+			Description: declaration.Description,
+			Values:      declaration.Values,
+		})
+	}
+
+	return metadata
+}
+
+// templateFields lists the exported fields of writer, the ones of embedded
+// structs included, and its exported methods taking no arguments and
+// returning one value, sorted by name. A field wins over a method of the same
+// name, like it does for a template.
+func templateFields(writer SegmentWriter) []TemplateField {
+	writerType := reflect.TypeOf(writer)
+
+	var fields []TemplateField
+
+	seen := map[string]bool{}
+
+	structType := writerType
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() == reflect.Struct {
+		fields = addTemplateFields(structType, fields, seen)
+	}
+
+	for method := range writerType.Methods() {
+		if writerMethods[method.Name] || seen[method.Name] {
+			continue
+		}
+
+		// the receiver is the first argument
+		if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 {
+			continue
+		}
+
+		seen[method.Name] = true
+		fields = append(fields, TemplateField{Name: method.Name, Type: method.Type.Out(0).String(), Method: true})
+	}
+
+	slices.SortFunc(fields, func(a, b TemplateField) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return fields
+}
+
+// addTemplateFields adds the struct's own fields before the ones of the
+// structs it embeds, as the shallower field is the one a template reads.
+func addTemplateFields(structType reflect.Type, fields []TemplateField, seen map[string]bool) []TemplateField {
+	var embedded []reflect.Type
+
+	for field := range structType.Fields() {
+		if field.Anonymous {
+			inner := field.Type
+			for inner.Kind() == reflect.Pointer {
+				inner = inner.Elem()
+			}
+
+			if inner.Kind() == reflect.Struct {
+				embedded = append(embedded, inner)
+				continue
+			}
+		}
+
+		if !field.IsExported() || seen[field.Name] {
+			continue
+		}
+
+		// a field kept out of JSON holds internal state, not anything a prompt renders
+		if tag, tagged := field.Tag.Lookup("json"); tagged && strings.HasPrefix(tag, "-") {
+			continue
+		}
+
+		seen[field.Name] = true
+		fields = append(fields, TemplateField{Name: field.Name, Type: field.Type.String()})
+	}
+
+	for _, inner := range embedded {
+		fields = addTemplateFields(inner, fields, seen)
+	}
+
+	return fields
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The js/wasm build embeds segment_catalog.json in place of the writers it
+// has no room for. This test regenerates it from the registry and fails on
+// drift; run with UPDATE_SCHEMAS=1 to rewrite the file after changing a
+// segment's options or fields.
+func TestSegmentCatalogUpToDate(t *testing.T) {
+	generated, err := json.MarshalIndent(SegmentCatalog(), "", "  ")
+	require.NoError(t, err)
+
+	generated = append(generated, '\n')
+
+	if os.Getenv("UPDATE_SCHEMAS") != "" {
+		assert.NoError(t, os.WriteFile("segment_catalog.json", generated, 0644))
+		return
+	}
+
+	embedded, err := os.ReadFile("segment_catalog.json")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(generated), string(embedded),
+		"run UPDATE_SCHEMAS=1 go test ./config -run TestSegmentCatalogUpToDate to regenerate segment_catalog.json")
+}
+
+func TestSegmentCatalog(t *testing.T) {
+	catalog := map[SegmentType]SegmentMetadata{}
+	for _, metadata := range SegmentCatalog() {
+		catalog[metadata.Type] = metadata
+	}
+
+	assert.Len(t, catalog, len(Segments))
+
+	fields := func(segmentType SegmentType) map[string]TemplateField {
+		byName := map[string]TemplateField{}
+		for _, field := range catalog[segmentType].Fields {
+			byName[field.Name] = field
+		}
+
+		return byName
+	}
+
+	path := fields(PATH)
+	assert.Equal(t, TemplateField{Name: "Path", Type: "string"}, path["Path"])
+	assert.Equal(t, TemplateField{Name: "Folders", Type: "segments.Folders"}, path["Folders"])
+	assert.NotContains(t, path, "Enabled", "writer methods aren't template fields")
+
+	git := fields(GIT)
+	assert.Equal(t, "Working", git["Working"].Name, "fields of embedded structs are flattened")
+
+	metadata := catalog[GIT]
+	declarations, ok := metadata.Declarations()
+	assert.True(t, ok)
+	assert.Contains(t, declarations, options.Declaration{
+		Name:        "fetch_status",
+		Type:        options.BoolType,
+		Default:     false,
+		Description: declarationOf(t, GIT, "fetch_status").Description,
+	})
+}
+
+func declarationOf(t *testing.T, segmentType SegmentType, name options.Option) options.Declaration {
+	t.Helper()
+
+	declarations, ok := SegmentOptions(segmentType)
+	require.True(t, ok)
+
+	for _, declaration := range declarations {
+		if declaration.Name == name {
+			return declaration
+		}
+	}
+
+	t.Fatalf("%s declares no option %s", segmentType, name)
+
+	return options.Declaration{}
+}
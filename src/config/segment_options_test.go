@@ -0,0 +1,517 @@
+package config
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const modulePath = "github.com/jandedobbeleer/oh-my-posh/src/"
+
+// TestSegmentOptionsDeclared reads the segments package's source for every
+// option a writer reads through its options.Provider and checks it's
+// declared with the type it's read as, so the load time validation, the
+// schema and get options can't reject or miss an option a segment uses.
+func TestSegmentOptionsDeclared(t *testing.T) {
+	read, err := readSegmentOptions()
+	require.NoError(t, err)
+
+	for segmentType, newWriter := range Segments {
+		declarations, ok := SegmentOptions(segmentType)
+		if !assert.True(t, ok, "%s declares no options", segmentType) {
+			continue
+		}
+
+		byName := map[string]options.Type{}
+		for _, declaration := range declarations {
+			byName[string(declaration.Name)] = declaration.Type
+		}
+
+		writer := reflect.Indirect(reflect.ValueOf(newWriter())).Type().Name()
+
+		for name, accessor := range read.forType(writer) {
+			declared, ok := byName[name]
+			if !assert.True(t, ok, "%s reads undeclared option %s", segmentType, name) {
+				continue
+			}
+
+			if accessor == options.AnyType {
+				continue
+			}
+
+			assert.Equal(t, accessor, declared, "%s option %s", segmentType, name)
+		}
+	}
+}
+
+// optionAccessors maps the options.Provider methods to the type of the value
+// they read.
+var optionAccessors = map[string]options.Type{
+	"Bool":        options.BoolType,
+	"String":      options.StringType,
+	"Template":    options.TemplateType,
+	"Color":       options.ColorType,
+	"Int":         options.IntType,
+	"Float64":     options.Float64Type,
+	"KeyValueMap": options.KeyValueMapType,
+	"StringArray": options.StringArrayType,
+	"Any":         options.AnyType,
+}
+
+// segmentOptions is what the segments package reads from its options: per
+// function (Type.method for methods), the options read and the functions
+// called, so a writer's options are those reachable from its method set.
+type segmentOptions struct {
+	constants map[string]map[string]ast.Expr
+	files     map[string]map[string]string
+	functions map[string]*optionFunction
+	embedded  map[string][]string
+}
+
+type optionFunction struct {
+	options map[string]options.Type
+	calls   []methodRef
+}
+
+// methodRef is a call to a function (empty receiver) or a method.
+type methodRef struct {
+	receiver string
+	name     string
+}
+
+func readSegmentOptions() (*segmentOptions, error) {
+	so := &segmentOptions{
+		constants: map[string]map[string]ast.Expr{},
+		files:     map[string]map[string]string{},
+		functions: map[string]*optionFunction{},
+		embedded:  map[string][]string{},
+	}
+
+	files, err := so.parse("segments")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		imports := fileImports(file)
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				so.readEmbedded(decl)
+			case *ast.FuncDecl:
+				so.readFunction(decl, imports)
+			}
+		}
+	}
+
+	return so, nil
+}
+
+// parse reads the non-test files of a package in the module, recording its
+// constants.
+func (so *segmentOptions) parse(pkg string) ([]*ast.File, error) {
+	dir := filepath.Join("..", filepath.FromSlash(pkg))
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	constants := map[string]ast.Expr{}
+	so.constants[pkg] = constants
+
+	fset := token.NewFileSet()
+
+	var files []*ast.File
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		imports := fileImports(file)
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				for i, ident := range valueSpec.Names {
+					if i < len(valueSpec.Values) {
+						constants[ident.Name] = valueSpec.Values[i]
+						so.files[pkg+"."+ident.Name] = imports
+					}
+				}
+			}
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+func fileImports(file *ast.File) map[string]string {
+	imports := map[string]string{}
+
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if !strings.HasPrefix(importPath, modulePath) {
+			continue
+		}
+
+		pkg := strings.TrimPrefix(importPath, modulePath)
+
+		name := path.Base(pkg)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		imports[name] = pkg
+	}
+
+	return imports
+}
+
+func (so *segmentOptions) readEmbedded(decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		typeSpec, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+
+		for _, field := range structType.Fields.List {
+			if len(field.Names) != 0 {
+				continue
+			}
+
+			if name := typeName(field.Type); name != "" {
+				so.embedded[typeSpec.Name.Name] = append(so.embedded[typeSpec.Name.Name], name)
+			}
+		}
+	}
+}
+
+func typeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return typeName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	case *ast.IndexExpr:
+		return typeName(expr.X)
+	default:
+		return ""
+	}
+}
+
+func (so *segmentOptions) readFunction(decl *ast.FuncDecl, imports map[string]string) {
+	var receiver, receiverType string
+
+	key := decl.Name.Name
+
+	if decl.Recv != nil && len(decl.Recv.List) == 1 {
+		receiverType = typeName(decl.Recv.List[0].Type)
+		key = receiverType + "." + key
+
+		if names := decl.Recv.List[0].Names; len(names) == 1 {
+			receiver = names[0].Name
+		}
+	}
+
+	if decl.Body == nil {
+		return
+	}
+
+	function := &optionFunction{options: map[string]options.Type{}}
+	so.functions[key] = function
+
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			function.calls = append(function.calls, methodRef{name: fun.Name})
+		case *ast.SelectorExpr:
+			if accessor, ok := optionAccessors[fun.Sel.Name]; ok && readsOptions(fun.X) && len(call.Args) >= 2 {
+				so.readOption(function, call, accessor, imports)
+			}
+
+			switch x := fun.X.(type) {
+			case *ast.Ident:
+				if receiver != "" && x.Name == receiver {
+					function.calls = append(function.calls, methodRef{receiver: receiverType, name: fun.Sel.Name})
+				}
+			case *ast.SelectorExpr:
+				// an explicit call to an embedded type's method, like l.Language.Enabled()
+				if ident, ok := x.X.(*ast.Ident); ok && receiver != "" && ident.Name == receiver {
+					function.calls = append(function.calls, methodRef{receiver: x.Sel.Name, name: fun.Sel.Name})
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+// readsOptions reports whether the accessor is called on a writer's
+// options, like b.options.String(...).
+func readsOptions(expr ast.Expr) bool {
+	selector, ok := expr.(*ast.SelectorExpr)
+	return ok && selector.Sel.Name == "options"
+}
+
+func (so *segmentOptions) readOption(function *optionFunction, call *ast.CallExpr, accessor options.Type, imports map[string]string) {
+	value, ok := so.value("segments", call.Args[0], imports)
+	if !ok {
+		return
+	}
+
+	name, ok := value.(string)
+	if !ok || name == "" {
+		return
+	}
+
+	function.options[name] = mergeAccessor(function.options[name], accessor)
+}
+
+// mergeAccessor picks the type of an option read more than once: the first
+// typed read wins, except for a template which reads strings too.
+func mergeAccessor(existing, accessor options.Type) options.Type {
+	switch {
+	case existing == "", existing == options.AnyType:
+		return accessor
+	case existing == options.StringType && accessor == options.TemplateType:
+		return accessor
+	default:
+		return existing
+	}
+}
+
+// value resolves a constant expression: literals, constants in this module,
+// conversions and slice or map literals of those.
+func (so *segmentOptions) value(pkg string, expr ast.Expr, imports map[string]string) (any, bool) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		switch expr.Kind {
+		case token.STRING:
+			value, err := strconv.Unquote(expr.Value)
+			return value, err == nil
+		case token.INT:
+			value, err := strconv.ParseInt(expr.Value, 0, 64)
+			return value, err == nil
+		case token.FLOAT:
+			value, err := strconv.ParseFloat(expr.Value, 64)
+			return value, err == nil
+		default:
+			return nil, false
+		}
+	case *ast.Ident:
+		switch expr.Name {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+
+		return so.constant(pkg, expr.Name)
+	case *ast.SelectorExpr:
+		ident, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return nil, false
+		}
+
+		imported, ok := imports[ident.Name]
+		if !ok {
+			return nil, false
+		}
+
+		if _, parsed := so.constants[imported]; !parsed {
+			if _, err := so.parse(imported); err != nil {
+				return nil, false
+			}
+		}
+
+		return so.constant(imported, expr.Sel.Name)
+	case *ast.UnaryExpr:
+		value, ok := so.value(pkg, expr.X, imports)
+		if !ok || expr.Op != token.SUB {
+			return nil, false
+		}
+
+		switch value := value.(type) {
+		case int64:
+			return -value, true
+		case float64:
+			return -value, true
+		}
+
+		return nil, false
+	case *ast.CallExpr:
+		// a conversion, like options.Option("name") or string(cache.NONE)
+		if len(expr.Args) != 1 {
+			return nil, false
+		}
+
+		return so.value(pkg, expr.Args[0], imports)
+	case *ast.CompositeLit:
+		return so.composite(pkg, expr, imports)
+	default:
+		return nil, false
+	}
+}
+
+func (so *segmentOptions) composite(pkg string, expr *ast.CompositeLit, imports map[string]string) (any, bool) {
+	switch expr.Type.(type) {
+	case *ast.ArrayType:
+		values := []any{}
+
+		for _, element := range expr.Elts {
+			value, ok := so.value(pkg, element, imports)
+			if !ok {
+				return nil, false
+			}
+
+			values = append(values, value)
+		}
+
+		return values, true
+	case *ast.MapType:
+		values := map[string]any{}
+
+		for _, element := range expr.Elts {
+			keyValue, ok := element.(*ast.KeyValueExpr)
+			if !ok {
+				return nil, false
+			}
+
+			key, ok := so.value(pkg, keyValue.Key, imports)
+			if !ok {
+				return nil, false
+			}
+
+			value, ok := so.value(pkg, keyValue.Value, imports)
+			if !ok {
+				return nil, false
+			}
+
+			name, ok := key.(string)
+			if !ok {
+				return nil, false
+			}
+
+			values[name] = value
+		}
+
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+func (so *segmentOptions) constant(pkg, name string) (any, bool) {
+	expr, ok := so.constants[pkg][name]
+	if !ok {
+		return nil, false
+	}
+
+	return so.value(pkg, expr, so.files[pkg+"."+name])
+}
+
+// forType returns the options a writer type reads, through its own methods,
+// the ones it promotes from embedded types and everything those call.
+func (so *segmentOptions) forType(writerType string) map[string]options.Type {
+	visited := map[string]bool{}
+
+	var queue []string
+	for _, function := range so.methodSet(writerType) {
+		queue = append(queue, function)
+	}
+
+	properties := map[string]options.Type{}
+
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+
+		if visited[key] {
+			continue
+		}
+
+		visited[key] = true
+
+		function, ok := so.functions[key]
+		if !ok {
+			continue
+		}
+
+		for name, option := range function.options {
+			properties[name] = mergeAccessor(properties[name], option)
+		}
+
+		for _, call := range function.calls {
+			if call.receiver == "" {
+				queue = append(queue, call.name)
+				continue
+			}
+
+			if resolved, ok := so.methodSet(call.receiver)[call.name]; ok {
+				queue = append(queue, resolved)
+			}
+		}
+	}
+
+	return properties
+}
+
+// methodSet maps every method name of a type, including the promoted ones, to
+// the function implementing it.
+func (so *segmentOptions) methodSet(typeName string) map[string]string {
+	methods := map[string]string{}
+
+	for _, embedded := range so.embedded[typeName] {
+		for name, function := range so.methodSet(embedded) {
+			methods[name] = function
+		}
+	}
+
+	prefix := typeName + "."
+
+	for key := range so.functions {
+		if name, ok := strings.CutPrefix(key, prefix); ok {
+			methods[name] = key
+		}
+	}
+
+	return methods
+}
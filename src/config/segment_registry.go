@@ -5,6 +5,8 @@ package config
 import (
 	"encoding/gob"
 	"errors"
+	"slices"
+	"strings"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/segments"
 )
@@ -22,6 +24,22 @@ func newSegmentWriter(segmentType SegmentType) (SegmentWriter, error) {
 	return f(), nil
 }
 
+// SegmentCatalog describes every segment type from its writer, sorted by type.
+// The js/wasm build has no writers to ask and reads the same catalog from the
+// copy embedded in it instead (see segment_registry_js.go).
+func SegmentCatalog() []SegmentMetadata {
+	catalog := make([]SegmentMetadata, 0, len(Segments))
+	for segmentType, factory := range Segments {
+		catalog = append(catalog, describeSegment(segmentType, factory()))
+	}
+
+	slices.SortFunc(catalog, func(a, b SegmentMetadata) int {
+		return strings.Compare(string(a.Type), string(b.Type))
+	})
+
+	return catalog
+}
+
 func init() {
 	gob.Register(&segments.Angular{})
 	gob.Register(&segments.Version{})
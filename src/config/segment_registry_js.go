@@ -2,6 +2,35 @@
 
 package config
 
+import (
+	_ "embed"
+	"encoding/json"
+	"sync"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+)
+
+// segmentCatalogJSON is SegmentCatalog as the other builds derive it from their writers, kept
+// up to date by TestSegmentCatalogUpToDate.
+//
+//go:embed segment_catalog.json
+var segmentCatalogJSON []byte
+
+var segmentCatalog = sync.OnceValue(func() []SegmentMetadata {
+	var catalog []SegmentMetadata
+	if err := json.Unmarshal(segmentCatalogJSON, &catalog); err != nil {
+		log.Error(err)
+	}
+
+	return catalog
+})
+
+// SegmentCatalog describes every segment type, sorted by type. This build has no writers to
+// describe them from, so it returns the copy embedded in it.
+func SegmentCatalog() []SegmentMetadata {
+	return segmentCatalog()
+}
+
 // newSegmentWriter answers that this build has no writers.
 //
 // The browser build renders a config from recorded data and never runs a segment's detection - it
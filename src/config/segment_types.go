@@ -283,7 +283,7 @@ func (segment *Segment) MapSegmentWithWriter(env runtime.Environment) error {
 	// nil on a build with no writers (see segment_registry_js.go); the segment renders from its
 	// recorded data instead.
 	if writer != nil {
-		writer.Init(segment.Options, env)
+		writer.Init(segment.readableOptions(), env)
 	}
 
 	segment.writer = writer
@@ -0,0 +1,307 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/regex"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// Severity tells how much a Diagnostic matters: an error breaks what it
+// points at, a warning is ignored or outdated but renders.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a problem Validate found in a config. Path points at the
+// value, like blocks[0].segments[2].options.max_depth. Line and Column, both
+// starting at 1, are only set when the config didn't parse: the decoders
+// know where they stopped, the decoded Config no longer knows where anything
+// came from.
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+}
+
+// Validate checks config data in the given format the way loading it would,
+// and reports what loading it would only log, or silently work around, as
+// diagnostics: the parse error, an outdated version, unknown block and
+// segment types, unknown options or values a segment can't read, and
+// templates that don't parse. Segments are checked against SegmentCatalog,
+// so the js/wasm build, which has no writers, validates them all the same.
+// No diagnostics means the config is valid.
+func Validate(format string, data []byte) []Diagnostic {
+	cfg, err := decodeBytes(format, data)
+	if errors.Is(err, ErrInvalidExtension) {
+		return []Diagnostic{{Severity: SeverityError, Message: fmt.Sprintf("unsupported config format: %s", format)}}
+	}
+
+	if err != nil {
+		return []Diagnostic{parseDiagnostic(err, data)}
+	}
+
+	v := &validator{
+		catalog: map[SegmentType]*SegmentMetadata{},
+	}
+
+	catalog := SegmentCatalog()
+	for i := range catalog {
+		v.catalog[catalog[i].Type] = &catalog[i]
+	}
+
+	for name := range cfg.Functions {
+		v.functions = append(v.functions, name)
+	}
+
+	v.config(cfg)
+
+	return v.diagnostics
+}
+
+// ValidateFile validates a config file (or URL, or bundled theme name),
+// deriving the format from its extension the same way ReadDocument does.
+func ValidateFile(configFile string) ([]Diagnostic, error) {
+	if configFile == "" {
+		return nil, ErrNoConfig
+	}
+
+	configFile = resolveConfigLocation(configFile)
+
+	data, err := getData(configFile)
+	if err != nil {
+		log.Error(err)
+		return nil, ErrFileNotFound
+	}
+
+	return Validate(strings.TrimPrefix(filepath.Ext(configFile), "."), data), nil
+}
+
+type validator struct {
+	catalog     map[SegmentType]*SegmentMetadata
+	functions   []string
+	diagnostics []Diagnostic
+}
+
+func (v *validator) report(severity Severity, path, format string, a ...any) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{
+		Severity: severity,
+		Path:     path,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+func (v *validator) config(cfg *Config) {
+	switch {
+	case cfg.Version < Version:
+		v.report(SeverityWarning, "version", "version %d is outdated, the current version is %d: run oh-my-posh config migrate", cfg.Version, Version)
+	case cfg.Version > Version:
+		v.report(SeverityWarning, "version", "version %d is newer than %d, the version this build supports", cfg.Version, Version)
+	}
+
+	for i, block := range cfg.Blocks {
+		v.block(fmt.Sprintf("blocks[%d]", i), block)
+	}
+
+	for i, tooltip := range cfg.Tooltips {
+		v.segment(fmt.Sprintf("tooltips[%d]", i), tooltip)
+	}
+
+	extraPrompts := []struct {
+		segment *Segment
+		path    string
+	}{
+		{path: "transient_prompt", segment: cfg.TransientPrompt},
+		{path: "secondary_prompt", segment: cfg.SecondaryPrompt},
+		{path: "debug_prompt", segment: cfg.DebugPrompt},
+		{path: "valid_line", segment: cfg.ValidLine},
+		{path: "error_line", segment: cfg.ErrorLine},
+	}
+
+	for _, prompt := range extraPrompts {
+		if prompt.segment != nil {
+			v.templates(prompt.path, prompt.segment)
+		}
+	}
+
+	v.template("console_title_template", cfg.ConsoleTitleTemplate)
+
+	for _, name := range sortedKeys(cfg.Functions) {
+		if function := cfg.Functions[name]; function != nil {
+			v.template(fmt.Sprintf("functions.%s.template", name), function.Template)
+		}
+	}
+
+	for _, name := range sortedKeys(cfg.Partials) {
+		v.template("partials."+name, cfg.Partials[name])
+	}
+}
+
+func (v *validator) block(path string, block *Block) {
+	if block == nil {
+		return
+	}
+
+	if !slices.Contains([]BlockType{Prompt, RPrompt}, block.Type) {
+		v.report(SeverityError, path+".type", "unknown block type %q, expected %s or %s", block.Type, Prompt, RPrompt)
+	}
+
+	if block.Type == Prompt && !slices.Contains([]BlockAlignment{Left, Right}, block.Alignment) {
+		v.report(SeverityError, path+".alignment", "unknown alignment %q, expected %s or %s", block.Alignment, Left, Right)
+	}
+
+	if block.Overflow != "" && !slices.Contains([]Overflow{Break, Hide}, block.Overflow) {
+		v.report(SeverityWarning, path+".overflow", "unknown overflow %q, expected %s or %s", block.Overflow, Break, Hide)
+	}
+
+	for i, segment := range block.Segments {
+		v.segment(fmt.Sprintf("%s.segments[%d]", path, i), segment)
+	}
+}
+
+func (v *validator) segment(path string, segment *Segment) {
+	if segment == nil {
+		return
+	}
+
+	metadata, known := v.catalog[segment.Type]
+
+	switch {
+	case segment.Type == "":
+		v.report(SeverityError, path+".type", "segment has no type")
+	case !known:
+		v.report(SeverityError, path+".type", "unknown segment type %q", segment.Type)
+	}
+
+	if strings.Contains(string(segment.Style), "{{") {
+		v.template(path+".style", string(segment.Style))
+	} else if segment.Style != "" && !slices.Contains([]SegmentStyle{Plain, Powerline, Accordion, Diamond}, segment.Style) {
+		v.report(SeverityWarning, path+".style", "unknown style %q, the segment is drawn %s", segment.Style, Plain)
+	}
+
+	if known {
+		v.options(path+".options", metadata, segment.Options)
+	}
+
+	v.templates(path, segment)
+}
+
+// options reports the options a segment ignores, see readableOptions.
+func (v *validator) options(path string, metadata *SegmentMetadata, m options.Map) {
+	declarations, ok := metadata.Declarations()
+	if !ok || len(m) == 0 {
+		return
+	}
+
+	errs := options.Validate(declarations, m)
+
+	for _, name := range sortedKeys(errs) {
+		v.report(SeverityWarning, fmt.Sprintf("%s.%s", path, name), "%s, the option is ignored", errs[name])
+	}
+}
+
+func (v *validator) templates(path string, segment *Segment) {
+	v.template(path+".template", segment.Template)
+	v.template(path+".fallback_template", segment.FallbackTemplate)
+
+	lists := []struct {
+		name string
+		list []string
+	}{
+		{name: "templates", list: segment.Templates},
+		{name: "foreground_templates", list: segment.ForegroundTemplates},
+		{name: "background_templates", list: segment.BackgroundTemplates},
+	}
+
+	for _, list := range lists {
+		for i, text := range list.list {
+			v.template(fmt.Sprintf("%s.%s[%d]", path, list.name, i), text)
+		}
+	}
+}
+
+func (v *validator) template(path, text string) {
+	if !strings.Contains(text, "{{") {
+		return
+	}
+
+	if err := template.Check(text, v.functions...); err != nil {
+		v.report(SeverityError, path, "%s", err)
+	}
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// parseDiagnostic points at where the decoder stopped. JSON reports a byte
+// offset into data, TOML a position and YAML a line in its message.
+func parseDiagnostic(err error, data []byte) Diagnostic {
+	diagnostic := Diagnostic{
+		Severity: SeverityError,
+		Message:  err.Error(),
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var trailingErr *trailingDataError
+	var tomlErr *toml.DecodeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		// the offset is just past the character the decoder choked on
+		diagnostic.Line, diagnostic.Column = position(data, syntaxErr.Offset-1)
+	case errors.As(err, &typeErr):
+		diagnostic.Line, diagnostic.Column = position(data, typeErr.Offset)
+		diagnostic.Path = typeErr.Field
+	case errors.As(err, &trailingErr):
+		diagnostic.Line, diagnostic.Column = position(data, trailingErr.offset)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		diagnostic.Line, diagnostic.Column = position(data, int64(len(data)))
+	case errors.As(err, &tomlErr):
+		diagnostic.Line, diagnostic.Column = tomlErr.Position()
+	default:
+		match := regex.FindNamedRegexMatch(`line (?P<line>\d+)`, diagnostic.Message)
+		diagnostic.Line, _ = strconv.Atoi(match["line"])
+	}
+
+	return diagnostic
+}
+
+// position turns a byte offset into data into a line and a column, counting
+// characters rather than bytes.
+func position(data []byte, offset int64) (line, column int) {
+	offset = min(max(offset, 0), int64(len(data)))
+	before := data[:offset]
+
+	start := 0
+	if i := strings.LastIndexByte(string(before), '\n'); i >= 0 {
+		start = i + 1
+	}
+
+	return strings.Count(string(before), "\n") + 1, utf8.RuneCount(before[start:]) + 1
+}
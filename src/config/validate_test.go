@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Format   string
+		Config   string
+		Expected []Diagnostic
+	}{
+		{
+			Case:   "valid",
+			Format: YAML,
+			Config: `version: 4
+blocks:
+  - type: prompt
+    alignment: left
+    segments:
+      - type: path
+        style: powerline
+        template: "{{ .Path }}"
+        options:
+          max_depth: 2`,
+		},
+		{
+			Case:   "outdated version",
+			Format: JSON,
+			Config: `{"version": 3}`,
+			Expected: []Diagnostic{
+				{Severity: SeverityWarning, Path: "version", Message: "version 3 is outdated, the current version is 4: run oh-my-posh config migrate"},
+			},
+		},
+		{
+			Case:   "blocks and segments",
+			Format: YAML,
+			Config: `version: 4
+blocks:
+  - type: prompt
+    alignment: center
+    segments:
+      - type: nosuch
+      - type: path
+        style: bogus
+        options:
+          max_depth: deep
+          nope: 1`,
+			Expected: []Diagnostic{
+				{Severity: SeverityError, Path: "blocks[0].alignment", Message: `unknown alignment "center", expected left or right`},
+				{Severity: SeverityError, Path: "blocks[0].segments[0].type", Message: `unknown segment type "nosuch"`},
+				{Severity: SeverityWarning, Path: "blocks[0].segments[1].style", Message: `unknown style "bogus", the segment is drawn plain`},
+				{
+					Severity: SeverityWarning,
+					Path:     "blocks[0].segments[1].options.max_depth",
+					Message:  "option max_depth: expected int, got string, the option is ignored",
+				},
+				{Severity: SeverityWarning, Path: "blocks[0].segments[1].options.nope", Message: "unknown option nope, the option is ignored"},
+			},
+		},
+		{
+			Case:   "templates",
+			Format: TOML,
+			Config: `version = 4
+console_title_template = "{{ .Folder"
+
+[functions.greet]
+template = "hi"
+
+[[blocks]]
+type = "prompt"
+alignment = "left"
+
+[[blocks.segments]]
+type = "text"
+template = "{{ greet }}"
+templates = ["{{ .Text }}", "{{ if }}"]
+
+[transient_prompt]
+template = "{{ nope }}"`,
+			Expected: []Diagnostic{
+				{Severity: SeverityError, Path: "blocks[0].segments[0].templates[1]", Message: "line 1: missing value for if"},
+				{Severity: SeverityError, Path: "transient_prompt.template", Message: `line 1: function "nope" not defined`},
+				{Severity: SeverityError, Path: "console_title_template", Message: "line 1: unclosed action"},
+			},
+		},
+		{
+			Case:     "json syntax error after a comment",
+			Format:   JSONC,
+			Config:   "{\n  // the version\n  \"version\": 4,\n  \"blocks\": ]\n}",
+			Expected: []Diagnostic{{Severity: SeverityError, Message: "invalid character ']' looking for beginning of value", Line: 4, Column: 13}},
+		},
+		{
+			Case:     "json cut short",
+			Format:   JSON,
+			Config:   "{\n  \"version\": 4,",
+			Expected: []Diagnostic{{Severity: SeverityError, Message: "unexpected EOF", Line: 2, Column: 16}},
+		},
+		{
+			Case:   "json value of the wrong type",
+			Format: JSON,
+			Config: "{\n  \"version\": \"4\"\n}",
+			Expected: []Diagnostic{
+				{Severity: SeverityError, Path: "version", Message: "json: cannot unmarshal string into Go struct field Config.version of type int", Line: 2, Column: 17},
+			},
+		},
+		{
+			Case:     "json trailing data",
+			Format:   JSON,
+			Config:   "{\"version\": 4}\n x",
+			Expected: []Diagnostic{{Severity: SeverityError, Message: "unexpected data after top-level value", Line: 2, Column: 2}},
+		},
+		{
+			Case:     "yaml syntax error",
+			Format:   YAML,
+			Config:   "version: 4\nblocks:\n  - type: [\n",
+			Expected: []Diagnostic{{Severity: SeverityError, Message: "yaml: line 3: did not find expected node content", Line: 3}},
+		},
+		{
+			Case:     "toml syntax error",
+			Format:   TOML,
+			Config:   "version = 4\n[[blocks]\n",
+			Expected: []Diagnostic{{Severity: SeverityError, Message: "toml: expected ']]' to close array table name", Line: 2, Column: 9}},
+		},
+		{
+			Case:     "unsupported format",
+			Format:   "xml",
+			Config:   "<config/>",
+			Expected: []Diagnostic{{Severity: SeverityError, Message: "unsupported config format: xml"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, Validate(tc.Format, []byte(tc.Config)))
+		})
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "theme.omp.yaml")
+	err := os.WriteFile(configFile, []byte("version: 4\nblocks:\n  - type: prompt\n    alignment: left\n    segments:\n      - type: path\n        options:\n          max_dept: 2\n"), 0644)
+	assert.NoError(t, err)
+
+	diagnostics, err := ValidateFile(configFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []Diagnostic{{
+		Severity: SeverityWarning,
+		Path:     "blocks[0].segments[0].options.max_dept",
+		Message:  "unknown option max_dept, the option is ignored",
+	}}, diagnostics)
+
+	_, err = ValidateFile(filepath.Join(t.TempDir(), "missing.omp.json"))
+	assert.ErrorIs(t, err, ErrFileNotFound)
+}
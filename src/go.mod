@@ -28,6 +28,7 @@ require (
 	github.com/pelletier/go-toml/v2 v2.4.3
 	github.com/shirou/gopsutil/v4 v4.26.7
 	go.yaml.in/yaml/v3 v3.0.5
+	golang.org/x/image v0.25.0
 )
 
 require (
@@ -0,0 +1,324 @@
+// Package golden pins a rendered prompt to a file and tells, cell by cell,
+// how a later render differs from it. A golden holds the captured runs rather
+// than the printed bytes: two renders that print the same cells in the same
+// colors match even when the escape sequences or the way the text is cut into
+// runs changed, and when they don't match, the difference reads as what a
+// user would see change - a glyph, a color, a style, a link.
+package golden
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+)
+
+// styles name Run.Attributes' slots, in the order of knownStyles in
+// terminal/writer.go.
+var styles = [...]string{"bold", "underline", "overline", "italic", "strikethrough", "dim", "blink", "reverse"}
+
+// modes name terminal.RunMode's values.
+var modes = [...]string{"", "background painted", "reverse video"}
+
+// Run is a terminal.Run as a golden file stores it: the text and its width,
+// the colors as the config wrote them, the gradient color a cell was stamped
+// with, the styles set on it and the link it's the label of.
+type Run struct {
+	Text          string           `json:"text"`
+	Foreground    color.Ansi       `json:"foreground,omitempty"`
+	Background    color.Ansi       `json:"background,omitempty"`
+	ForegroundRGB string           `json:"foreground_rgb,omitempty"`
+	BackgroundRGB string           `json:"background_rgb,omitempty"`
+	Hyperlink     string           `json:"hyperlink,omitempty"`
+	Styles        []string         `json:"styles,omitempty"`
+	Cells         int              `json:"cells"`
+	Mode          terminal.RunMode `json:"mode,omitempty"`
+}
+
+// File is the content of a golden file.
+type File struct {
+	Rows [][]Run `json:"rows"`
+}
+
+// New converts rows of runs, as prompt.Engine.CapturedRuns returns them.
+// Runs without cells are left out, they draw nothing.
+func New(rows [][]terminal.Run) *File {
+	file := &File{Rows: make([][]Run, 0, len(rows))}
+
+	for _, row := range rows {
+		runs := make([]Run, 0, len(row))
+
+		for i := range row {
+			if row[i].Cells == 0 {
+				continue
+			}
+
+			runs = append(runs, newRun(&row[i]))
+		}
+
+		file.Rows = append(file.Rows, runs)
+	}
+
+	return file
+}
+
+func newRun(run *terminal.Run) Run {
+	golden := Run{
+		Text:          run.Text,
+		Cells:         run.Cells,
+		Foreground:    run.ForegroundSource,
+		Background:    run.BackgroundSource,
+		ForegroundRGB: hex(run.ForegroundRGB),
+		BackgroundRGB: hex(run.BackgroundRGB),
+		Mode:          run.Mode,
+		Hyperlink:     run.Hyperlink,
+	}
+
+	for i, depth := range run.Attributes {
+		if depth > 0 && i < len(styles) {
+			golden.Styles = append(golden.Styles, styles[i])
+		}
+	}
+
+	return golden
+}
+
+func hex(rgb *color.RGB) string {
+	if rgb == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", rgb.R, rgb.G, rgb.B)
+}
+
+// Parse reads a golden file.
+func Parse(data []byte) (*File, error) {
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+// Bytes writes the golden file.
+func (f *File) Bytes() ([]byte, error) {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// Difference is a span of a row where the actual render differs from the
+// golden in one respect, Kind: its text, foreground, background, style or
+// link. Row, Start and End start at 0, End is inclusive. A row only one of
+// the two has is a Difference of kind row.
+type Difference struct {
+	Kind     string
+	Expected string
+	Actual   string
+	Row      int
+	Start    int
+	End      int
+}
+
+// String reports the difference counting rows and columns from 1, like a
+// terminal does.
+func (d *Difference) String() string {
+	if d.Kind == "row" {
+		return fmt.Sprintf("row %d: expected %q, got %q", d.Row+1, d.Expected, d.Actual)
+	}
+
+	columns := fmt.Sprintf("column %d", d.Start+1)
+	if d.End > d.Start {
+		columns = fmt.Sprintf("columns %d-%d", d.Start+1, d.End+1)
+	}
+
+	return fmt.Sprintf("row %d, %s: %s %s, got %s", d.Row+1, columns, d.Kind, quote(d.Kind, d.Expected), quote(d.Kind, d.Actual))
+}
+
+func quote(kind, value string) string {
+	if kind == "text" {
+		return fmt.Sprintf("%q", value)
+	}
+
+	if value == "" {
+		return "none"
+	}
+
+	return value
+}
+
+// Diff compares actual against the golden f, cell by cell, and returns every
+// difference row by row, a row's text before its colors, styles and links.
+// None means they match.
+func (f *File) Diff(actual *File) []Difference {
+	var differences []Difference
+
+	for row := range max(len(f.Rows), len(actual.Rows)) {
+		if row >= len(f.Rows) || row >= len(actual.Rows) {
+			differences = append(differences, Difference{
+				Kind:     "row",
+				Row:      row,
+				Expected: rowText(f.Rows, row),
+				Actual:   rowText(actual.Rows, row),
+			})
+
+			continue
+		}
+
+		differences = append(differences, diffRow(row, cells(f.Rows[row]), cells(actual.Rows[row]))...)
+	}
+
+	return differences
+}
+
+func rowText(rows [][]Run, row int) string {
+	if row >= len(rows) {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, run := range rows[row] {
+		b.WriteString(run.Text)
+	}
+
+	return b.String()
+}
+
+// cell is one column of a row: the glyph drawn there, empty for the second
+// column of a wide glyph, and what each kind of difference compares.
+type cell struct {
+	text    string
+	aspects [4]string
+}
+
+// aspects are the kinds a cell differs in besides its text, in the order of
+// cell.aspects.
+var aspects = [...]string{"foreground", "background", "style", "link"}
+
+func cells(row []Run) []cell {
+	var result []cell
+
+	for _, run := range row {
+		aspect := [4]string{
+			colorOf(run.Foreground, run.ForegroundRGB),
+			colorOf(run.Background, run.BackgroundRGB),
+			styleOf(&run),
+			run.Hyperlink,
+		}
+
+		for _, r := range run.Text {
+			glyph := string(r)
+			width := terminal.VisibleCells(glyph)
+
+			// a combining mark joins the glyph before it
+			if width == 0 {
+				if len(result) > 0 {
+					result[len(result)-1].text += glyph
+				}
+
+				continue
+			}
+
+			result = append(result, cell{text: glyph, aspects: aspect})
+
+			for range width - 1 {
+				result = append(result, cell{aspects: aspect})
+			}
+		}
+	}
+
+	return result
+}
+
+// colorOf prefers the gradient color a cell was stamped with over the
+// gradient's definition, which every cell of it shares.
+func colorOf(source color.Ansi, rgb string) string {
+	if rgb != "" {
+		return rgb
+	}
+
+	return string(source)
+}
+
+func styleOf(run *Run) string {
+	parts := run.Styles
+	if int(run.Mode) > 0 && int(run.Mode) < len(modes) {
+		parts = append([]string{modes[run.Mode]}, parts...)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func diffRow(row int, expected, actual []cell) []Difference {
+	var differences []Difference
+
+	width := max(len(expected), len(actual))
+
+	at := func(cells []cell, column int) (cell, bool) {
+		if column >= len(cells) {
+			return cell{}, false
+		}
+
+		return cells[column], true
+	}
+
+	// text first, then every aspect, each as spans of adjacent columns
+	var text *Difference
+
+	for column := range width {
+		want, hasWant := at(expected, column)
+		got, hasGot := at(actual, column)
+
+		if hasWant == hasGot && want.text == got.text {
+			text = nil
+			continue
+		}
+
+		if text == nil {
+			differences = append(differences, Difference{Kind: "text", Row: row, Start: column})
+			text = &differences[len(differences)-1]
+		}
+
+		text.End = column
+		text.Expected += want.text
+		text.Actual += got.text
+	}
+
+	for i, kind := range aspects {
+		var span *Difference
+
+		for column := range width {
+			want, hasWant := at(expected, column)
+			got, hasGot := at(actual, column)
+
+			// a column only one side has is a text difference already
+			if !hasWant || !hasGot || want.aspects[i] == got.aspects[i] {
+				span = nil
+				continue
+			}
+
+			if span != nil && span.Expected == want.aspects[i] && span.Actual == got.aspects[i] && span.End == column-1 {
+				span.End = column
+				continue
+			}
+
+			differences = append(differences, Difference{
+				Kind:     kind,
+				Row:      row,
+				Start:    column,
+				End:      column,
+				Expected: want.aspects[i],
+				Actual:   got.aspects[i],
+			})
+			span = &differences[len(differences)-1]
+		}
+	}
+
+	return differences
+}
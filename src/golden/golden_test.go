@@ -0,0 +1,108 @@
+package golden
+
+import (
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func run(text string, foreground color.Ansi) terminal.Run {
+	return terminal.Run{Text: text, Cells: terminal.VisibleCells(text), ForegroundSource: foreground}
+}
+
+func TestNew(t *testing.T) {
+	rows := [][]terminal.Run{{
+		{Text: "", Cells: 0, ForegroundSource: "red"},
+		{
+			Text:          "a",
+			Cells:         1,
+			ForegroundRGB: &color.RGB{R: 255},
+			Attributes:    [8]uint8{1, 0, 0, 2},
+			Mode:          terminal.RunReverseVideo,
+			Hyperlink:     "https://ohmyposh.dev",
+		},
+	}}
+
+	file := New(rows)
+
+	expected := &File{Rows: [][]Run{{{
+		Text:          "a",
+		Cells:         1,
+		ForegroundRGB: "#ff0000",
+		Styles:        []string{"bold", "italic"},
+		Mode:          terminal.RunReverseVideo,
+		Hyperlink:     "https://ohmyposh.dev",
+	}}}}
+
+	assert.Equal(t, expected, file, "a run without cells is left out")
+
+	data, err := file.Bytes()
+	require.NoError(t, err)
+
+	parsed, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, file, parsed)
+}
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Expected [][]terminal.Run
+		Actual   [][]terminal.Run
+		Diff     []string
+	}{
+		{
+			Case:     "runs cut differently match",
+			Expected: [][]terminal.Run{{run("main", "red")}},
+			Actual:   [][]terminal.Run{{run("ma", "red"), run("in", "red")}},
+		},
+		{
+			Case:     "text",
+			Expected: [][]terminal.Run{{run("~ main ", "red")}},
+			Actual:   [][]terminal.Run{{run("~ dev ", "red")}},
+			Diff:     []string{`row 1, columns 3-7: text "main ", got "dev "`},
+		},
+		{
+			Case:     "foreground of part of a run",
+			Expected: [][]terminal.Run{{run("main", "red")}},
+			Actual:   [][]terminal.Run{{run("ma", "red"), run("in", "blue")}},
+			Diff:     []string{"row 1, columns 3-4: foreground red, got blue"},
+		},
+		{
+			Case:     "style and link",
+			Expected: [][]terminal.Run{{{Text: "a", Cells: 1, Attributes: [8]uint8{1}}}},
+			Actual:   [][]terminal.Run{{{Text: "a", Cells: 1, Hyperlink: "https://ohmyposh.dev"}}},
+			Diff: []string{
+				"row 1, column 1: style bold, got none",
+				"row 1, column 1: link none, got https://ohmyposh.dev",
+			},
+		},
+		{
+			Case:     "wide glyph",
+			Expected: [][]terminal.Run{{run("日a", "red")}},
+			Actual:   [][]terminal.Run{{run("日b", "red")}},
+			Diff:     []string{`row 1, column 3: text "a", got "b"`},
+		},
+		{
+			Case:     "missing row",
+			Expected: [][]terminal.Run{{run("~", "red")}, {run("❯ ", "green")}},
+			Actual:   [][]terminal.Run{{run("~", "red")}},
+			Diff:     []string{`row 2: expected "❯ ", got ""`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			var diff []string
+			for _, difference := range New(tc.Expected).Diff(New(tc.Actual)) {
+				diff = append(diff, difference.String())
+			}
+
+			assert.Equal(t, tc.Diff, diff)
+		})
+	}
+}
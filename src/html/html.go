@@ -0,0 +1,185 @@
+// Package html encodes a captured Run stream as a <pre> block for embedding a
+// prompt in a web page: docs, a dashboard, a bug report. It lays the rows out
+// exactly like package svg does, cursor, watermark and overflow included (see
+// svg.Layout), but writes them as text a reader can select and copy, styled
+// inline so the block needs no stylesheet, with every hyperlink the prompt
+// printed as a clickable link.
+package html
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+)
+
+// linkSchemes are the hyperlink targets a link is written for. A segment's
+// content can end up in a link's target - a folder name in the path segment's
+// file:// link, say - so anything else, a javascript: URL first of all, is
+// written as plain text instead.
+var linkSchemes = map[string]bool{
+	"http":  true,
+	"https": true,
+	"file":  true,
+}
+
+// Encode renders rows of runs, as prompt.Engine.CapturedRuns returns them, into
+// a self-contained <pre> element. opts is the same svg.Options svg.Encode takes:
+// the colors, cursor and columns lay the block out the way they lay the SVG out,
+// and FontFamily, FontSize and LineHeight style it. Window chrome and the
+// per-cell metrics only exist on a canvas and are ignored.
+//
+// A gradient keeps the color of every cell it was stamped with, as each of those
+// is a run of its own. Blinking text is drawn steady, as an inline style can't
+// animate.
+//
+//nolint:gocritic
+func Encode(rows [][]terminal.Run, opts svg.Options) string {
+	spans, opts := svg.Layout(rows, opts)
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<pre style="%s">`, preStyle(&opts))
+
+	for i, row := range spans {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		writeRow(&b, row)
+	}
+
+	b.WriteString("</pre>\n")
+
+	return b.String()
+}
+
+func preStyle(opts *svg.Options) string {
+	var b strings.Builder
+
+	b.WriteString("margin:0;padding:1em;white-space:pre;overflow-x:auto")
+	writeColor(&b, "background-color", opts.CanvasBackground)
+
+	if foreground, ok := svg.ResolveStaticRGB(color.Ansi("default"), false, opts); ok {
+		writeColor(&b, "color", foreground)
+	}
+
+	fmt.Fprintf(&b, ";font-family:%s;font-size:%spx;line-height:%spx",
+		opts.FontFamily, formatFloat(opts.FontSize), formatFloat(opts.LineHeight))
+
+	return html.EscapeString(b.String())
+}
+
+// writeRow writes a row's spans, grouping the consecutive spans of one link into
+// a single anchor.
+func writeRow(b *strings.Builder, row []svg.Span) {
+	for i := 0; i < len(row); {
+		target := row[i].Hyperlink
+
+		end := i + 1
+		for end < len(row) && row[end].Hyperlink == target {
+			end++
+		}
+
+		href, linked := safeLink(target)
+		if linked {
+			fmt.Fprintf(b, `<a href="%s" style="color:inherit;text-decoration:none">`, html.EscapeString(href))
+		}
+
+		for j := i; j < end; j++ {
+			writeSpan(b, &row[j])
+		}
+
+		if linked {
+			b.WriteString("</a>")
+		}
+
+		i = end
+	}
+}
+
+func writeSpan(b *strings.Builder, span *svg.Span) {
+	style := spanStyle(span)
+	text := html.EscapeString(span.Text.Text)
+
+	if style == "" {
+		b.WriteString(text)
+		return
+	}
+
+	fmt.Fprintf(b, `<span style="%s">%s</span>`, html.EscapeString(style), text)
+}
+
+func spanStyle(span *svg.Span) string {
+	var b strings.Builder
+
+	writeColor(&b, "color", span.Fill)
+	writeColor(&b, "background-color", span.Background)
+
+	if span.Bold() {
+		b.WriteString(";font-weight:bold")
+	}
+
+	if span.Italic() {
+		b.WriteString(";font-style:italic")
+	}
+
+	if decoration := textDecoration(&span.Text); decoration != "" {
+		b.WriteString(";text-decoration:")
+		b.WriteString(decoration)
+	}
+
+	if span.Dim() {
+		b.WriteString(";opacity:0.6")
+	}
+
+	return strings.TrimPrefix(b.String(), ";")
+}
+
+func textDecoration(text *svg.Text) string {
+	var parts []string
+
+	if text.Underline() {
+		parts = append(parts, "underline")
+	}
+
+	if text.Overline() {
+		parts = append(parts, "overline")
+	}
+
+	if text.Strikethrough() {
+		parts = append(parts, "line-through")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func writeColor(b *strings.Builder, property string, rgb *color.RGB) {
+	if rgb == nil {
+		return
+	}
+
+	fmt.Fprintf(b, ";%s:#%02x%02x%02x", property, rgb.R, rgb.G, rgb.B)
+}
+
+// safeLink returns target when it's a link worth writing, see linkSchemes.
+func safeLink(target string) (string, bool) {
+	if target == "" {
+		return "", false
+	}
+
+	parsed, err := url.Parse(target)
+	if err != nil || !linkSchemes[strings.ToLower(parsed.Scheme)] {
+		return "", false
+	}
+
+	return target, true
+}
+
+func formatFloat(v float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
@@ -0,0 +1,110 @@
+package html
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testOptions() svg.Options {
+	return svg.Options{
+		FontFamily: "monospace",
+		FontSize:   10,
+		LineHeight: 20,
+		Columns:    20,
+	}
+}
+
+// body returns the first row of an encoded block, without the cursor the layout
+// glues onto the end of the prompt.
+func body(doc string) string {
+	doc = doc[strings.Index(doc, ">")+1:]
+	row, _, _ := strings.Cut(doc, "\n")
+
+	return strings.TrimSuffix(row, `<span style="color:#ffffff">_</span>`)
+}
+
+func TestEncode(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Expected string
+		Runs     []terminal.Run
+	}{
+		{
+			Case:     "colors",
+			Runs:     []terminal.Run{{Text: "~", Cells: 1, ForegroundSource: "#ff0000", BackgroundSource: "#0000ff"}},
+			Expected: `<span style="color:#ff0000;background-color:#0000ff">~</span>`,
+		},
+		{
+			Case:     "escaped text",
+			Runs:     []terminal.Run{{Text: "<b>&", Cells: 4, ForegroundSource: "#ff0000"}},
+			Expected: `<span style="color:#ff0000">&lt;b&gt;&amp;</span>`,
+		},
+		{
+			Case: "gradient cells keep their own color",
+			Runs: []terminal.Run{
+				{Text: "a", Cells: 1, ForegroundRGB: &color.RGB{R: 255}},
+				{Text: "b", Cells: 1, ForegroundRGB: &color.RGB{B: 255}},
+			},
+			Expected: `<span style="color:#ff0000">a</span><span style="color:#0000ff">b</span>`,
+		},
+		{
+			Case: "attributes",
+			Runs: []terminal.Run{
+				{Text: "a", Cells: 1, ForegroundSource: "#ff0000", Attributes: [8]uint8{1, 1, 0, 1, 1, 1}},
+			},
+			Expected: `<span style="color:#ff0000;font-weight:bold;font-style:italic;text-decoration:underline line-through;opacity:0.6">a</span>`,
+		},
+		{
+			Case: "a link spanning runs is one anchor",
+			Runs: []terminal.Run{
+				{Text: "d", Cells: 1, ForegroundSource: "#ff0000", Hyperlink: "https://ohmyposh.dev?a=1&b=2"},
+				{Text: "ocs", Cells: 3, ForegroundSource: "#0000ff", Hyperlink: "https://ohmyposh.dev?a=1&b=2"},
+				{Text: " ", Cells: 1, ForegroundSource: "#0000ff"},
+			},
+			Expected: `<a href="https://ohmyposh.dev?a=1&amp;b=2" style="color:inherit;text-decoration:none">` +
+				`<span style="color:#ff0000">d</span><span style="color:#0000ff">ocs</span></a><span style="color:#0000ff"> </span>`,
+		},
+		{
+			Case:     "a file link",
+			Runs:     []terminal.Run{{Text: "~/dev", Cells: 5, ForegroundSource: "#ff0000", Hyperlink: "file:///home/jan/dev"}},
+			Expected: `<a href="file:///home/jan/dev" style="color:inherit;text-decoration:none"><span style="color:#ff0000">~/dev</span></a>`,
+		},
+		{
+			Case:     "a script is no link",
+			Runs:     []terminal.Run{{Text: "x", Cells: 1, ForegroundSource: "#ff0000", Hyperlink: "javascript:alert(1)"}},
+			Expected: `<span style="color:#ff0000">x</span>`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			doc := Encode([][]terminal.Run{tc.Runs}, testOptions())
+			assert.Equal(t, tc.Expected, body(doc))
+		})
+	}
+}
+
+func TestEncodeBlock(t *testing.T) {
+	rows := [][]terminal.Run{
+		{{Text: "~", Cells: 1, ForegroundSource: "#ff0000"}},
+		{{Text: "❯", Cells: 1, ForegroundSource: "#00ff00"}},
+	}
+
+	background := color.RGB{R: 0x10, G: 0x20, B: 0x30}
+	opts := testOptions()
+	opts.TerminalBackground = &background
+
+	doc := Encode(rows, opts)
+
+	assert.True(t, strings.HasPrefix(doc, `<pre style="margin:0;padding:1em;white-space:pre;overflow-x:auto;`+
+		`background-color:#102030;color:#ffffff;font-family:monospace;font-size:10px;line-height:20px">`))
+	assert.True(t, strings.HasSuffix(doc, "</pre>\n"))
+	assert.Contains(t, doc, "\n"+`<span style="color:#00ff00">❯</span>`, "every row is a line of its own")
+	assert.Contains(t, doc, "ohmyposh.dev", "laid out like the SVG, watermark included")
+}
@@ -69,10 +69,17 @@ type Engine struct {
 	// cursorRow/cursorRun locate the end of the primary prompt's own output
 	// within capturedRows — where the shell leaves the cursor. -1 means
 	// unset; see markCursorAnchor/CursorAnchor.
-	cursorRow   int
-	cursorRun   int
-	Plain       bool
-	forceRender bool
+	cursorRow int
+	cursorRun int
+	// capturedFrames/transientRuns keep what every StreamPrimary render
+	// captured, see CapturedFrames.
+	capturedFrames []CapturedFrame
+	transientRuns  []terminal.Run
+	Plain          bool
+	forceRender    bool
+	// SeparateRPrompt keeps the rprompt block out of the primary prompt for a
+	// caller that renders it on its own through RPrompt, see serve's protocol.
+	SeparateRPrompt bool
 }
 
 const (
@@ -103,6 +110,17 @@ func (e *Engine) string() string {
 	return txt
 }
 
+// shellIntegration reports whether the prompts carry the shell integration
+// marks. Elvish only interprets color sequences in a prompt, its init script
+// writes the marks to the terminal from its hooks instead.
+func (e *Engine) shellIntegration() bool {
+	if !e.Config.ShellIntegration {
+		return false
+	}
+
+	return e.Env.Shell() != shell.ELVISH
+}
+
 func (e *Engine) canWriteRightBlock(length int, rprompt bool) (int, bool) {
 	if rprompt && (e.rprompt == "") {
 		return 0, false
@@ -187,6 +205,12 @@ func (e *Engine) getNewline() string {
 		return terminal.LineBreak()
 	}
 
+	// tcsh splits command substitution output on newlines, it expands
+	// the escaped form when printing the prompt instead.
+	if e.Env.Shell() == shell.TCSH {
+		return `\n`
+	}
+
 	return newline
 }
 
@@ -935,6 +959,7 @@ func newEngine(cfg *config.Config, env runtime.Environment) *Engine {
 	flags := env.Flags()
 
 	template.Init(env, cfg.Var, cfg.Maps)
+	template.Extend(cfg.TemplateExtensions())
 
 	flags.HasExtra = cfg.DebugPrompt != nil ||
 		cfg.SecondaryPrompt != nil ||
@@ -70,7 +70,7 @@ func (e *Engine) ExtraPrompt(promptType ExtraPromptType) string {
 	// prompt these marks can't go through e.write/e.string - append them to
 	// str directly instead.
 	var shellIntegrationStart, shellIntegrationEnd string
-	if promptType == Transient && e.Config.ShellIntegration {
+	if promptType == Transient && e.shellIntegration() {
 		exitCode, _ := e.Env.StatusCodes()
 		shellIntegrationStart = terminal.CommandFinished(exitCode, e.Env.Flags().NoExitCode) + terminal.PromptStart()
 		shellIntegrationEnd = terminal.CommandStart()
@@ -81,6 +81,11 @@ func (e *Engine) ExtraPrompt(promptType ExtraPromptType) string {
 	terminal.SetColors(background, foreground)
 	terminal.Write(background, foreground, promptText)
 
+	// captured before String, which resets the run stream (see captureBlockRuns)
+	if promptType == Transient {
+		e.transientRuns = e.captureBlockRuns()
+	}
+
 	str, length := terminal.String()
 	str = shellIntegrationStart + str
 
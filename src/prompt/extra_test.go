@@ -237,6 +237,25 @@ func TestExtraPromptTransientPWSHNewline(t *testing.T) {
 	assert.Equal(t, expected, got)
 }
 
+func TestExtraPromptTransientTcshNewline(t *testing.T) {
+	env := setupExtraPromptTest(t, shell.TCSH, &runtime.Flags{PromptCount: 2})
+	env.On("TerminalWidth").Return(20, nil)
+	env.On("CursorPosition").Return(2, 1)
+
+	engine := &Engine{
+		Config: &config.Config{
+			TransientPrompt: &config.Segment{
+				Template: "L>",
+				Newline:  true,
+			},
+		},
+		Env: env,
+	}
+
+	// the prompt expands the escaped newline, a real one would split the prompt into words
+	assert.Equal(t, `\nL>`, engine.ExtraPrompt(Transient))
+}
+
 func TestExtraPromptTransientFish(t *testing.T) {
 	cases := []struct {
 		TerminalErr   error
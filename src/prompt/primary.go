@@ -6,6 +6,7 @@ import (
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cache"
 	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments"
 	"github.com/jandedobbeleer/oh-my-posh/src/shell"
 	"github.com/jandedobbeleer/oh-my-posh/src/template"
 	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
@@ -32,12 +33,14 @@ func (e *Engine) primaryInternal(fromCache bool) string {
 		// Warp doesn't support RPROMPT so we need to write it manually
 		if e.isWarp() {
 			e.writePrimaryRightPrompt()
+			e.writeCommandStart()
 			prompt := fmt.Sprintf("PS1=%s", shell.QuotePosixStr(e.string()))
 			return prompt
 		}
 
+		e.writeCommandStart()
 		prompt := fmt.Sprintf("PS1=%s", shell.QuotePosixStr(e.string()))
-		prompt += fmt.Sprintf("\nRPROMPT=%s", shell.QuotePosixStr(e.rprompt))
+		prompt += fmt.Sprintf("\nRPROMPT=%s", shell.QuotePosixStr(e.rpromptMark()+e.rprompt))
 
 		return prompt
 	default:
@@ -48,15 +51,77 @@ func (e *Engine) primaryInternal(fromCache bool) string {
 		e.writePrimaryRightPrompt()
 	}
 
+	e.writeCommandStart()
+
 	return e.string()
 }
 
+// PrimaryVIMode renders the primary prompt Primary rendered again, the way it
+// reads once the shell switches to the vi mode keymap. Only the vimode
+// segments change mode: every segment is rendered from the data Primary
+// executed it with, nothing runs twice and the environment is left alone.
+func (e *Engine) PrimaryVIMode(keymap string) string {
+	type viMode struct {
+		segment *config.Segment
+		writer  *segments.VIMode
+		keymap  string
+		enabled bool
+		text    string
+	}
+
+	var switched []viMode
+
+	for _, block := range e.Config.Blocks {
+		for _, segment := range block.Segments {
+			writer, ok := segment.Writer().(*segments.VIMode)
+			// a segment without a keymap never got as far as asking for one
+			if !ok || len(writer.Keymap) == 0 {
+				continue
+			}
+
+			switched = append(switched, viMode{
+				segment: segment,
+				writer:  writer,
+				keymap:  writer.Keymap,
+				enabled: segment.Enabled,
+				text:    segment.Text(),
+			})
+
+			writer.SetKeymap(keymap)
+			// the template may only print something in this mode
+			segment.Enabled = true
+		}
+	}
+
+	defer func() {
+		for _, mode := range switched {
+			mode.writer.SetKeymap(mode.keymap)
+			mode.segment.Enabled = mode.enabled
+			mode.segment.SetText(mode.text)
+		}
+	}()
+
+	e.allBlocks = e.Config.Blocks
+
+	return e.renderFromBlocks()
+}
+
 func (e *Engine) writePrimaryPrompt(needsPrimaryRPrompt bool) {
 	e.writePrimaryPromptInternal(needsPrimaryRPrompt, false)
+	e.writeCommandStart()
+}
+
+// writeCommandStart marks where the command line begins, after everything the
+// prompt writes: a right prompt written inline comes before it, or terminals
+// would take it for part of the command.
+func (e *Engine) writeCommandStart() {
+	if e.shellIntegration() {
+		e.write(terminal.CommandStart())
+	}
 }
 
 func (e *Engine) writePrimaryPromptInternal(needsPrimaryRPrompt, fromCache bool) {
-	if e.Config.ShellIntegration {
+	if e.shellIntegration() {
 		exitCode, _ := e.Env.StatusCodes()
 		e.write(terminal.CommandFinished(exitCode, e.Env.Flags().NoExitCode))
 		e.write(terminal.PromptStart())
@@ -170,10 +235,6 @@ func (e *Engine) writePrimaryPromptInternal(needsPrimaryRPrompt, fromCache bool)
 		}
 	}
 
-	if e.Config.ShellIntegration {
-		e.write(terminal.CommandStart())
-	}
-
 	e.pwd()
 }
 
@@ -182,9 +243,16 @@ func (e *Engine) needsPrimaryRightPrompt() bool {
 		return true
 	}
 
+	if e.SeparateRPrompt {
+		return false
+	}
+
 	switch e.Env.Shell() {
 	case shell.PWSH, shell.GENERIC, shell.ZSH:
 		return true
+	case shell.BASH:
+		// ble.sh draws the right prompt itself, see shell.Features.Bash
+		return len(e.Env.Getenv("BLE_SESSION_ID")) == 0
 	default:
 		return false
 	}
@@ -196,9 +264,14 @@ func (e *Engine) writePrimaryRightPrompt() {
 		return
 	}
 
-	e.write(terminal.SaveCursorPosition())
-	e.write(strings.Repeat(" ", space))
-	e.write(e.rprompt)
-	e.write(terminal.RestoreCursorPosition())
+	rprompt := terminal.SaveCursorPosition() + strings.Repeat(" ", space) + e.rpromptMark() + e.rprompt + terminal.RestoreCursorPosition()
+
+	// readline must not count the right prompt, nor the padding leading up to
+	// it, towards the width of PS1; the cursor ends up where it started anyway
+	if e.Env.Shell() == shell.BASH {
+		rprompt = shell.BashNonPrinting(rprompt)
+	}
+
+	e.write(rprompt)
 	e.appendCapturedRuns(gapRun(space), e.rpromptRuns)
 }
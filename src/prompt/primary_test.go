@@ -1,11 +1,13 @@
 package prompt
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cache"
 	"github.com/jandedobbeleer/oh-my-posh/src/config"
 	"github.com/jandedobbeleer/oh-my-posh/src/maps"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
 	"github.com/jandedobbeleer/oh-my-posh/src/shell"
 	"github.com/jandedobbeleer/oh-my-posh/src/template"
@@ -70,3 +72,173 @@ func TestCursorStyleTemplate(t *testing.T) {
 		assert.Equal(t, tc.Expected, got, tc.Case)
 	}
 }
+
+func TestWritePrimaryRightPrompt(t *testing.T) {
+	cases := []struct {
+		Case             string
+		Shell            string
+		Expected         string
+		ShellIntegration bool
+	}{
+		{Case: "PWSH", Shell: shell.PWSH, Expected: "\x1b7" + "     " + "right" + "\x1b8"},
+		{Case: "PWSH with shell integration", Shell: shell.PWSH, ShellIntegration: true, Expected: "\x1b7" + "     " + "\x1b]133;P;k=r\aright" + "\x1b8"},
+		{Case: "Bash", Shell: shell.BASH, Expected: "\\[\x1b7" + "     " + "\x1b[31mright" + "\x1b8\\]"},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Shell").Return(tc.Shell)
+		env.On("TerminalWidth").Return(20, nil)
+
+		terminal.Init(tc.Shell)
+
+		rprompt := "right"
+		if tc.Shell == shell.BASH {
+			rprompt = "\\[\x1b[31m\\]right"
+		}
+
+		engine := &Engine{
+			Env:                  env,
+			Config:               &config.Config{ShellIntegration: tc.ShellIntegration},
+			rprompt:              rprompt,
+			rpromptLength:        5,
+			currentLineLength:    10,
+			RPromptBreathingRoom: 1,
+		}
+
+		engine.writePrimaryRightPrompt()
+
+		assert.Equal(t, tc.Expected, engine.string(), tc.Case)
+	}
+}
+
+func TestNeedsPrimaryRightPromptBash(t *testing.T) {
+	cases := []struct {
+		Case         string
+		BLESessionID string
+		Expected     bool
+	}{
+		{Case: "plain bash", Expected: true},
+		{Case: "ble.sh", BLESessionID: "1234", Expected: false},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Flags").Return(&runtime.Flags{})
+		env.On("Shell").Return(shell.BASH)
+		env.On("Getenv", "BLE_SESSION_ID").Return(tc.BLESessionID)
+
+		engine := &Engine{Env: env}
+
+		assert.Equal(t, tc.Expected, engine.needsPrimaryRightPrompt(), tc.Case)
+	}
+}
+
+func TestShellIntegrationMarks(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Shell    string
+		Expected string
+	}{
+		{Case: "generic", Shell: shell.GENERIC, Expected: "\x1b]133;D;2\a\x1b]133;A\a\x1b]133;B\a"},
+		{Case: "elvish prints the marks from its hooks", Shell: shell.ELVISH},
+		{Case: "xonsh writes the marks as zero width", Shell: shell.XONSH, Expected: "\x01\x1b]133;D;2\a\x02\x01\x1b]133;A\a\x02\x01\x1b]133;B\a\x02"},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Shell").Return(tc.Shell)
+		env.On("StatusCodes").Return(2, "2")
+		env.On("Flags").Return(&runtime.Flags{})
+
+		template.Cache = &cache.Template{
+			SimpleTemplate: cache.SimpleTemplate{
+				Shell: tc.Shell,
+			},
+			Segments: maps.NewConcurrent[any](),
+		}
+		template.Init(env, nil, nil)
+
+		terminal.Init(tc.Shell)
+
+		engine := &Engine{
+			Env: env,
+			Config: &config.Config{
+				ShellIntegration: true,
+			},
+		}
+
+		engine.writePrimaryPrompt(false)
+
+		assert.Equal(t, tc.Expected, engine.string(), tc.Case)
+	}
+}
+
+func TestCommandStartAfterRightPrompt(t *testing.T) {
+	env := &runtime.Terminal{}
+	env.Init(&runtime.Flags{
+		Shell:         shell.BASH,
+		TerminalWidth: 60,
+		IsPrimary:     true,
+	})
+
+	cfg := &config.Config{
+		ShellIntegration: true,
+		Blocks: []*config.Block{
+			{
+				Type:      config.Prompt,
+				Alignment: config.Left,
+				Segments: []*config.Segment{
+					{Type: "text", Template: "left"},
+				},
+			},
+			{
+				Type: config.RPrompt,
+				Segments: []*config.Segment{
+					{Type: "text", Template: "right"},
+				},
+			},
+		},
+	}
+
+	primary := newEngine(cfg, env).Primary()
+
+	rprompt := strings.Index(primary, "right")
+	commandStart := strings.Index(primary, terminal.CommandStart())
+
+	assert.Positive(t, rprompt)
+	assert.Greater(t, commandStart, rprompt, "the command starts after the right prompt")
+	assert.True(t, strings.HasSuffix(primary, terminal.CommandStart()))
+}
+
+func TestPrimaryVIMode(t *testing.T) {
+	t.Setenv("POSH_VI_MODE", "viins")
+
+	env := &runtime.Terminal{}
+	env.Init(&runtime.Flags{
+		Shell:     shell.BASH,
+		IsPrimary: true,
+		Plain:     true,
+	})
+
+	cfg := &config.Config{
+		Blocks: []*config.Block{
+			{
+				Type:      config.Prompt,
+				Alignment: config.Left,
+				Segments: []*config.Segment{
+					{Type: "text", Template: "path "},
+					{Type: config.VIMODE, Template: "[{{ .Mode }}]"},
+					{Type: config.VIMODE, Template: `{{ if eq .Mode "normal" }}!{{ end }}`},
+				},
+			},
+		},
+	}
+
+	eng := newEngine(cfg, env)
+
+	assert.Equal(t, "path [insert]", eng.Primary())
+	assert.Equal(t, "path [normal]!", eng.PrimaryVIMode("vicmd"), "a segment only printing in a mode shows up in it")
+	assert.Equal(t, "path [insert]", eng.PrimaryVIMode("viins"), "the segments are back in the mode Primary rendered")
+	assert.Equal(t, "viins", env.Getenv("POSH_VI_MODE"))
+}
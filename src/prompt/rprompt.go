@@ -5,6 +5,7 @@ import (
 	"github.com/jandedobbeleer/oh-my-posh/src/config"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 	"github.com/jandedobbeleer/oh-my-posh/src/shell"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
 )
 
 const (
@@ -47,5 +48,15 @@ func (e *Engine) RPrompt() string {
 		cache.Set(cache.Session, RPromptLengthKey, e.rpromptLength, cache.INFINITE)
 	}
 
-	return text
+	return e.rpromptMark() + text
+}
+
+// rpromptMark marks the start of the right prompt when shell integration is
+// enabled, so the terminal can tell it apart from the command line.
+func (e *Engine) rpromptMark() string {
+	if !e.shellIntegration() {
+		return ""
+	}
+
+	return terminal.RPromptStart()
 }
@@ -17,6 +17,52 @@ func (e *Engine) CapturedRuns() [][]terminal.Run {
 	return e.capturedRows
 }
 
+// CapturedFrame is what one StreamPrimary render captured: the rows
+// CapturedRuns returned right after it, and the cursor anchor CursorAnchor
+// returned, with Cursor false when there was none.
+type CapturedFrame struct {
+	Rows      [][]terminal.Run
+	CursorRow int
+	CursorRun int
+	Cursor    bool
+}
+
+// CapturedFrames returns one CapturedFrame per primary prompt record
+// StreamPrimary sent, in order, when terminal.CaptureRuns was true for the
+// cycle. The producer goroutine renders the next update over the same rows
+// as soon as a segment completes, so CapturedRuns can't be read while the
+// cycle runs; this is only safe to read once its channel is closed.
+func (e *Engine) CapturedFrames() []CapturedFrame {
+	return e.capturedFrames
+}
+
+// CapturedTransientRuns returns the runs of the last transient prompt
+// rendered while terminal.CaptureRuns was true, its left template only.
+func (e *Engine) CapturedTransientRuns() []terminal.Run {
+	return e.transientRuns
+}
+
+// captureFrame appends the current capture to capturedFrames.
+func (e *Engine) captureFrame() {
+	if !terminal.CaptureRuns {
+		return
+	}
+
+	rows := make([][]terminal.Run, 0, len(e.capturedRows))
+	for _, row := range e.capturedRows {
+		rows = append(rows, slices.Clone(row))
+	}
+
+	row, run, ok := e.CursorAnchor()
+
+	e.capturedFrames = append(e.capturedFrames, CapturedFrame{
+		Rows:      rows,
+		CursorRow: row,
+		CursorRun: run,
+		Cursor:    ok,
+	})
+}
+
 // startRunCapture (re)initializes the row accumulator at the start of a
 // render. A no-op unless terminal.CaptureRuns is set, so repeated renders off
 // the same Engine with capture disabled never pay for the reset.
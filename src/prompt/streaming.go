@@ -23,6 +23,7 @@ func (e *Engine) StreamPrimary() <-chan string {
 	e.allBlocks = e.Config.Blocks
 	e.abort = make(chan struct{})
 	e.done = make(chan struct{})
+	e.capturedFrames = nil
 
 	out := make(chan string, 10)
 
@@ -96,7 +97,10 @@ func (e *Engine) StreamPrimary() <-chan string {
 		}
 
 		// Render and send initial prompt with pending segments
-		if !sendRecord(e.Primary()) {
+		primary := e.Primary()
+		e.captureFrame()
+
+		if !sendRecord(primary) {
 			return
 		}
 
@@ -132,7 +136,10 @@ func (e *Engine) StreamPrimary() <-chan string {
 					continue
 				}
 
-				if !sendRecord(e.renderFromBlocks()) {
+				update := e.renderFromBlocks()
+				e.captureFrame()
+
+				if !sendRecord(update) {
 					return
 				}
 
@@ -178,6 +185,20 @@ func (e *Engine) Abort() {
 	}
 }
 
+// Aborted reports whether Abort was called on the active StreamPrimary cycle.
+func (e *Engine) Aborted() bool {
+	if e.abort == nil {
+		return false
+	}
+
+	select {
+	case <-e.abort:
+		return true
+	default:
+		return false
+	}
+}
+
 func (e *Engine) countPendingSegments() int {
 	count := 0
 	e.pendingSegments.Range(func(_, _ any) bool {
@@ -0,0 +1,321 @@
+package raster
+
+import (
+	"image"
+	stdcolor "image/color"
+	"image/draw"
+	"math"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+const (
+	// dimAlpha matches the opacity svg gives a dim run.
+	dimAlpha = 0x99
+
+	// controlCenter is how far above its baseline svg centers a window
+	// control glyph, as a share of its size (see svg's controlBaselineY).
+	controlCenter = 0.35
+
+	// kappa places the control points of a cubic Bézier that approximates a
+	// quarter circle.
+	kappa = 0.5522847498
+)
+
+// painter is the svg.Painter that fills an image: every shape arrives in the
+// layout's own units and is multiplied by scale on the way in.
+type painter struct {
+	img     *image.RGBA
+	scale   float64
+	opts    *Options
+	regular font.Face
+	bold    font.Face
+}
+
+func newPainter(opts *Options) (*painter, error) {
+	size := opts.FontSize * opts.Scale
+
+	regular, err := newFace(opts.Font, size)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &painter{
+		scale:   opts.Scale,
+		opts:    opts,
+		regular: regular,
+	}
+
+	if opts.BoldFont == nil {
+		return p, nil
+	}
+
+	if p.bold, err = newFace(opts.BoldFont, size); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func newFace(f *opentype.Font, size float64) (font.Face, error) {
+	// At 72 DPI a point is a pixel, so Size is the em size in image pixels.
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingNone,
+	})
+}
+
+func (p *painter) Begin(canvas svg.Canvas) {
+	width := int(math.Ceil(canvas.Width * p.scale))
+	height := int(math.Ceil(canvas.Height * p.scale))
+
+	p.img = image.NewRGBA(image.Rect(0, 0, width, height))
+}
+
+// Window draws the header and content panes, the border and the controls.
+// The controls are drawn as shapes rather than glyphs: the font the caller
+// picked for the prompt need not have "−", "▢" or "×" at all.
+func (p *painter) Window(window svg.Window) {
+	s := p.scale
+	width, height := window.Width*s, window.Height*s
+	corner, title := window.Corner*s, window.TitleOffset*s
+
+	p.fillPath(toNRGBA(window.Header, 0xff), func(z *vector.Rasterizer) {
+		roundedRect(z, 0, 0, width, title, corner, 0)
+	})
+
+	p.fillPath(toNRGBA(window.Content, 0xff), func(z *vector.Rasterizer) {
+		roundedRect(z, 0, title, width, height, 0, corner)
+	})
+
+	// svg strokes the border centered on a rect inset by half its width, so it
+	// runs from the canvas edge inwards by one stroke width.
+	stroke := window.StrokeWidth * s
+	outer := p.mask(func(z *vector.Rasterizer) {
+		roundedRect(z, 0, 0, width, height, corner+stroke/2, corner+stroke/2)
+	})
+	inner := p.mask(func(z *vector.Rasterizer) {
+		roundedRect(z, stroke, stroke, width-stroke, height-stroke, corner-stroke/2, corner-stroke/2)
+	})
+
+	for i := range outer.Pix {
+		outer.Pix[i] = uint8(uint16(outer.Pix[i]) * uint16(0xff-inner.Pix[i]) / 0xff)
+	}
+
+	draw.DrawMask(p.img, p.img.Bounds(), image.NewUniform(toNRGBA(window.Stroke, 0xff)), image.Point{}, outer, image.Point{}, draw.Over)
+
+	for i := range window.Controls {
+		p.control(&window.Controls[i])
+	}
+}
+
+// control draws a minimize bar, a maximize square or a close cross, sized to
+// the glyph svg would have written.
+func (p *painter) control(control *svg.Control) {
+	s := p.scale
+	cx := control.X * s
+	cy := (control.Baseline - control.Size*controlCenter) * s
+	half := control.Size * 0.3 * s
+	line := max(control.Size*0.08*s, 1)
+	fill := toNRGBA(control.Fill, 0xff)
+
+	switch control.Label {
+	case "−":
+		p.fillPath(fill, func(z *vector.Rasterizer) {
+			rect(z, cx-half, cy-line/2, cx+half, cy+line/2)
+		})
+	case "▢":
+		p.fillPath(fill, func(z *vector.Rasterizer) {
+			rect(z, cx-half, cy-half, cx+half, cy-half+line)
+			rect(z, cx-half, cy+half-line, cx+half, cy+half)
+			rect(z, cx-half, cy-half+line, cx-half+line, cy+half-line)
+			rect(z, cx+half-line, cy-half+line, cx+half, cy+half-line)
+		})
+	case "×":
+		p.fillPath(fill, func(z *vector.Rasterizer) {
+			bar(z, cx-half, cy-half, cx+half, cy+half, line)
+			bar(z, cx-half, cy+half, cx+half, cy-half, line)
+		})
+	}
+}
+
+// Rect snaps a background to whole pixels, so two adjacent segments share an
+// edge exactly instead of leaving an anti-aliased seam between them.
+func (p *painter) Rect(rect svg.Rect) {
+	r := image.Rect(
+		p.snap(rect.X), p.snap(rect.Y),
+		p.snap(rect.X+rect.Width), p.snap(rect.Y+rect.Height),
+	)
+
+	draw.Draw(p.img, r, image.NewUniform(toNRGBA(rect.Fill, 0xff)), image.Point{}, draw.Src)
+}
+
+// Text draws every rune at the start of its own cell, the same grid the SVG's
+// textLength pins its glyphs to. A zero width rune, such as a combining mark,
+// is drawn on the cell of the rune it attaches to. Italic is drawn upright,
+// there's no italic face to draw it with.
+func (p *painter) Text(text svg.Text) {
+	// svg leaves the fill off a run with no resolved foreground, which SVG
+	// paints black.
+	rgb := color.RGB{}
+	if text.Fill != nil {
+		rgb = *text.Fill
+	}
+
+	alpha := uint8(0xff)
+	if text.Dim() {
+		alpha = dimAlpha
+	}
+
+	src := image.NewUniform(toNRGBA(rgb, alpha))
+
+	face := p.regular
+	embolden := false
+
+	if text.Bold() {
+		if p.bold != nil {
+			face = p.bold
+		} else {
+			embolden = true
+		}
+	}
+
+	cellWidth := text.Width / float64(text.Cells)
+	drawer := font.Drawer{Dst: p.img, Src: src, Face: face}
+	x := text.X
+	cell := 0
+
+	for _, r := range text.Text {
+		cells := terminal.VisibleCells(string(r))
+		if cells > 0 {
+			x = text.X + float64(cell)*cellWidth
+			cell += cells
+		}
+
+		glyph := string(r)
+
+		drawer.Dot = p.point(x, text.Baseline)
+		drawer.DrawString(glyph)
+
+		if embolden {
+			drawer.Dot = p.point(x, text.Baseline)
+			drawer.Dot.X += fixed.I(1)
+			drawer.DrawString(glyph)
+		}
+	}
+
+	p.decorate(&text, src)
+}
+
+// decorate draws the underline, overline and strikethrough across the whole
+// run, one line each.
+func (p *painter) decorate(text *svg.Text, src image.Image) {
+	fontSize := p.opts.FontSize
+	thickness := max(fontSize/16, 1/p.scale)
+
+	var lines []float64
+
+	if text.Underline() {
+		lines = append(lines, text.Baseline+fontSize*0.12)
+	}
+
+	if text.Overline() {
+		lines = append(lines, text.Baseline-p.opts.FillAscent+thickness)
+	}
+
+	if text.Strikethrough() {
+		lines = append(lines, text.Baseline-fontSize*0.3)
+	}
+
+	for _, y := range lines {
+		r := image.Rect(p.snap(text.X), p.snap(y-thickness/2), p.snap(text.X+text.Width), p.snap(y+thickness/2))
+		if r.Dy() == 0 {
+			r.Max.Y++
+		}
+
+		draw.Draw(p.img, r, src, image.Point{}, draw.Over)
+	}
+}
+
+func (p *painter) snap(v float64) int {
+	return int(math.Round(v * p.scale))
+}
+
+func (p *painter) point(x, y float64) fixed.Point26_6 {
+	return fixed.Point26_6{
+		X: fixed.Int26_6(math.Round(x * p.scale * 64)),
+		Y: fixed.Int26_6(math.Round(y * p.scale * 64)),
+	}
+}
+
+// fillPath fills the area path traces, in image pixels, with c.
+func (p *painter) fillPath(c stdcolor.NRGBA, path func(z *vector.Rasterizer)) {
+	bounds := p.img.Bounds()
+	z := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+
+	path(z)
+	z.Draw(p.img, bounds, image.NewUniform(c), image.Point{})
+}
+
+// mask rasterizes path into a coverage mask the size of the image.
+func (p *painter) mask(path func(z *vector.Rasterizer)) *image.Alpha {
+	bounds := p.img.Bounds()
+	z := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	mask := image.NewAlpha(bounds)
+
+	path(z)
+	z.Draw(mask, bounds, image.Opaque, image.Point{})
+
+	return mask
+}
+
+// roundedRect traces a rectangle clockwise, rounding its top corners by top
+// and its bottom corners by bottom.
+func roundedRect(z *vector.Rasterizer, x0, y0, x1, y1, top, bottom float64) {
+	top, bottom = max(top, 0), max(bottom, 0)
+
+	z.MoveTo(float32(x0+top), float32(y0))
+	z.LineTo(float32(x1-top), float32(y0))
+	corner(z, x1-top, y0, x1, y0, x1, y0+top)
+	z.LineTo(float32(x1), float32(y1-bottom))
+	corner(z, x1, y1-bottom, x1, y1, x1-bottom, y1)
+	z.LineTo(float32(x0+bottom), float32(y1))
+	corner(z, x0+bottom, y1, x0, y1, x0, y1-bottom)
+	z.LineTo(float32(x0), float32(y0+top))
+	corner(z, x0, y0+top, x0, y0, x0+top, y0)
+	z.ClosePath()
+}
+
+// corner rounds the corner at (cx, cy) from (ax, ay), the current pen
+// position, to (bx, by).
+func corner(z *vector.Rasterizer, ax, ay, cx, cy, bx, by float64) {
+	z.CubeTo(
+		float32(ax+kappa*(cx-ax)), float32(ay+kappa*(cy-ay)),
+		float32(bx+kappa*(cx-bx)), float32(by+kappa*(cy-by)),
+		float32(bx), float32(by),
+	)
+}
+
+func rect(z *vector.Rasterizer, x0, y0, x1, y1 float64) {
+	roundedRect(z, x0, y0, x1, y1, 0, 0)
+}
+
+// bar traces a line from (x0, y0) to (x1, y1), width wide.
+func bar(z *vector.Rasterizer, x0, y0, x1, y1, width float64) {
+	length := math.Hypot(x1-x0, y1-y0)
+	nx, ny := (y0-y1)/length*width/2, (x1-x0)/length*width/2
+
+	z.MoveTo(float32(x0+nx), float32(y0+ny))
+	z.LineTo(float32(x1+nx), float32(y1+ny))
+	z.LineTo(float32(x1-nx), float32(y1-ny))
+	z.LineTo(float32(x0-nx), float32(y0-ny))
+	z.ClosePath()
+}
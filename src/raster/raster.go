@@ -0,0 +1,162 @@
+// Package raster renders a captured terminal.Run stream to a PNG image. It is
+// a sibling of package svg rather than a converter of its output: both lay the
+// same Run stream out through svg.Paint, and this package fills pixels with
+// the resulting shapes using a TTF/OTF font the caller supplies, so exporting
+// an image needs no browser, no system font lookup and no external tool.
+package raster
+
+import (
+	"bytes"
+	"errors"
+	stdcolor "image/color"
+	"image/png"
+	"math"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Options configures the raster canvas. The embedded svg.Options lays it out
+// exactly like an SVG export; the font metrics it leaves at zero are taken
+// from Font itself instead of svg's Hack Nerd Font defaults, since unlike the
+// SVG case the font drawing the glyphs is known here.
+type Options struct {
+	svg.Options
+
+	// Font draws every glyph. It is required.
+	Font *sfnt.Font
+	// BoldFont draws bold runs. When nil, bold is emboldened from Font by
+	// drawing each glyph a second time, one pixel to the right.
+	BoldFont *sfnt.Font
+	// Scale is the number of image pixels per unit of the layout, which is
+	// laid out in svg.Options' own units (16 at the default FontSize). Zero
+	// falls back to defaultScale.
+	Scale float64
+}
+
+const (
+	// defaultScale renders at twice the layout size, so the image stays sharp
+	// on a high density display or when pasted into a slide.
+	defaultScale = 2.0
+
+	// lineSpacing is the line spacing svg's own default line height applies
+	// on top of the font's height, see svg.defaultLineHeight.
+	lineSpacing = 1.2
+
+	// powerlineSeparator is the glyph whose ink box bounds a segment's
+	// background, see svg.Options.FillAscent.
+	powerlineSeparator = '\ue0b0'
+)
+
+var errNoFont = errors.New("a TTF or OTF font is required to render an image")
+
+// ParseFont parses TTF or OTF font data.
+func ParseFont(data []byte) (*sfnt.Font, error) {
+	return opentype.Parse(data)
+}
+
+// Encode renders rows of runs — one []terminal.Run per output row, as
+// prompt.Engine.CapturedRuns returns — into a PNG image.
+//
+// Encode takes opts by value, like svg.Encode does and for the same reason.
+//
+//nolint:gocritic
+func Encode(rows [][]terminal.Run, opts Options) ([]byte, error) {
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := newPainter(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	svg.Paint(rows, opts.Options, p)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, p.img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// withDefaults fills the metrics left at zero from the font's own tables: the
+// advance of 'M' for the cell width, its line height times lineSpacing, and
+// the ink box of U+E0B0 for the background fill where the font has one, its
+// ascent and descent otherwise.
+func (o *Options) withDefaults() (Options, error) {
+	out := *o
+
+	if out.Font == nil {
+		return out, errNoFont
+	}
+
+	if out.Scale <= 0 {
+		out.Scale = defaultScale
+	}
+
+	if out.FontSize == 0 {
+		out.FontSize = svg.DefaultFontSize
+	}
+
+	var buf sfnt.Buffer
+
+	ppem := fixed.Int26_6(math.Round(out.FontSize * 64))
+
+	metrics, err := out.Font.Metrics(&buf, ppem, font.HintingNone)
+	if err != nil {
+		return out, err
+	}
+
+	if out.CellWidth == 0 {
+		index, err := out.Font.GlyphIndex(&buf, 'M')
+		if err != nil {
+			return out, err
+		}
+
+		advance, err := out.Font.GlyphAdvance(&buf, index, ppem, font.HintingNone)
+		if err != nil {
+			return out, err
+		}
+
+		out.CellWidth = fromFixed(advance)
+	}
+
+	if out.LineHeight == 0 {
+		out.LineHeight = fromFixed(metrics.Height) * lineSpacing
+	}
+
+	ascent, descent := fromFixed(metrics.Ascent), fromFixed(metrics.Descent)
+
+	if index, err := out.Font.GlyphIndex(&buf, powerlineSeparator); err == nil && index != 0 {
+		if bounds, _, err := out.Font.GlyphBounds(&buf, index, ppem, font.HintingNone); err == nil {
+			ascent, descent = -fromFixed(bounds.Min.Y), fromFixed(bounds.Max.Y)
+		}
+	}
+
+	if out.FillAscent == 0 {
+		out.FillAscent = ascent
+	}
+
+	if out.FillDescent == 0 {
+		out.FillDescent = descent
+	}
+
+	return out, nil
+}
+
+func fromFixed(v fixed.Int26_6) float64 {
+	return float64(v) / 64
+}
+
+func toNRGBA(rgb color.RGB, alpha uint8) stdcolor.NRGBA {
+	return stdcolor.NRGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: alpha}
+}
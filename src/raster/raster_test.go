@@ -0,0 +1,154 @@
+package raster
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/image/font/gofont/gomono"
+)
+
+func testFont(t *testing.T) Options {
+	t.Helper()
+
+	f, err := ParseFont(gomono.TTF)
+	require.NoError(t, err)
+
+	return Options{
+		Options: svg.Options{Columns: 20},
+		Font:    f,
+	}
+}
+
+func TestEncode(t *testing.T) {
+	red := color.RGB{R: 0xff}
+	rows := [][]terminal.Run{{
+		{Text: " ab ", Cells: 4, ForegroundSource: "#ffffff", BackgroundSource: "#ff0000", BackgroundRGB: &red},
+	}}
+
+	cases := []struct {
+		Case  string
+		Scale float64
+	}{
+		{Case: "default scale", Scale: 0},
+		{Case: "scale 1", Scale: 1},
+		{Case: "scale 3", Scale: 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			opts := testFont(t)
+			opts.Scale = tc.Scale
+
+			data, err := Encode(rows, opts)
+			require.NoError(t, err)
+
+			img, err := png.Decode(bytes.NewReader(data))
+			require.NoError(t, err)
+
+			resolved, err := opts.withDefaults()
+			require.NoError(t, err)
+
+			// the canvas is the svg one, multiplied by the scale
+			var canvas svg.Canvas
+			svg.Paint(rows, resolved.Options, &recorder{canvas: &canvas})
+
+			assert.Equal(t, int(math.Ceil(canvas.Width*resolved.Scale)), img.Bounds().Dx())
+			assert.Equal(t, int(math.Ceil(canvas.Height*resolved.Scale)), img.Bounds().Dy())
+
+			// the background of the run's first cell, a space, is the segment's own
+			var rect svg.Rect
+			svg.Paint(rows, resolved.Options, &recorder{canvas: &canvas, rect: &rect})
+
+			x := int((rect.X + resolved.CellWidth/2) * resolved.Scale)
+			y := int((rect.Y + rect.Height/2) * resolved.Scale)
+			r, g, b, _ := img.At(x, y).RGBA()
+			assert.Equal(t, [3]uint32{0xffff, 0, 0}, [3]uint32{r, g, b})
+
+			// the glyphs leave some white ink in the cells after it
+			assert.True(t, hasInk(img, rect, resolved), "expected glyph ink in the run's cells")
+		})
+	}
+}
+
+func TestEncodeWithoutFont(t *testing.T) {
+	_, err := Encode(nil, Options{})
+	assert.ErrorIs(t, err, errNoFont)
+}
+
+func TestWithDefaults(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Options  svg.Options
+		Expected svg.Options
+	}{
+		{
+			Case:    "metrics from the font",
+			Options: svg.Options{},
+			// Go Mono has no U+E0B0, so the fill box is its ascent and descent
+			Expected: svg.Options{FontSize: 16, CellWidth: 9.609375, LineHeight: 22.2, FillAscent: 15.125, FillDescent: 3.375},
+		},
+		{
+			Case:     "explicit metrics win",
+			Options:  svg.Options{FontSize: 20, CellWidth: 12, LineHeight: 30, FillAscent: 18, FillDescent: 6},
+			Expected: svg.Options{FontSize: 20, CellWidth: 12, LineHeight: 30, FillAscent: 18, FillDescent: 6},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			opts := testFont(t)
+			opts.Options = tc.Options
+
+			got, err := opts.withDefaults()
+			require.NoError(t, err)
+
+			assert.Equal(t, defaultScale, got.Scale)
+			assert.Equal(t, tc.Expected.FontSize, got.FontSize)
+			assert.InDelta(t, tc.Expected.CellWidth, got.CellWidth, 0.001)
+			assert.InDelta(t, tc.Expected.LineHeight, got.LineHeight, 0.001)
+			assert.InDelta(t, tc.Expected.FillAscent, got.FillAscent, 0.001)
+			assert.InDelta(t, tc.Expected.FillDescent, got.FillDescent, 0.001)
+		})
+	}
+}
+
+// recorder keeps the canvas and the first rect svg.Paint lays out.
+type recorder struct {
+	canvas *svg.Canvas
+	rect   *svg.Rect
+}
+
+func (r *recorder) Begin(canvas svg.Canvas) { *r.canvas = canvas }
+func (r *recorder) Window(svg.Window)       {}
+func (r *recorder) Text(svg.Text)           {}
+
+func (r *recorder) Rect(rect svg.Rect) {
+	if r.rect != nil && r.rect.Width == 0 {
+		*r.rect = rect
+	}
+}
+
+func hasInk(img image.Image, rect svg.Rect, opts Options) bool {
+	x0, x1 := int((rect.X+opts.CellWidth)*opts.Scale), int((rect.X+rect.Width)*opts.Scale)
+	y0, y1 := int(rect.Y*opts.Scale), int((rect.Y+rect.Height)*opts.Scale)
+
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			if _, g, _, _ := img.At(x, y).RGBA(); g > 0x8000 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
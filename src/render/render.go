@@ -14,9 +14,13 @@
 package render
 
 import (
+	"fmt"
+
 	"github.com/jandedobbeleer/oh-my-posh/src/color"
 	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/html"
 	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
+	"github.com/jandedobbeleer/oh-my-posh/src/raster"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 	"github.com/jandedobbeleer/oh-my-posh/src/shell"
 	"github.com/jandedobbeleer/oh-my-posh/src/svg"
@@ -72,6 +76,46 @@ const rpromptBreathingRoom = 20
 // shared setup, so every caller (the CLI image command, the wasm
 // entrypoint) sets it at its own call site instead.
 func Config(cfg *config.Config, terminalWidth int, resetTemplateCache bool, applyData func(*runtime.Flags) error) (*prompt.Engine, error) {
+	eng, err := Engine(cfg, terminalWidth, resetTemplateCache, applyData)
+	if err != nil {
+		return nil, err
+	}
+
+	eng.Primary()
+
+	return eng, nil
+}
+
+// Timeline replays a recorded session: it renders cfg once for every frame of
+// data's Timeline, in order, and returns the engines, each with its own primary
+// prompt already rendered. applyData applies one frame onto the flags, the
+// role Config's own applyData plays for a single snapshot. Every frame starts
+// from a fresh template cache, so one frame's state never leaks into the next.
+func Timeline(cfg *config.Config, terminalWidth int, data *config.Data, applyData func(*runtime.Flags, *config.Data) error) ([]*prompt.Engine, error) {
+	timeline := data.Timeline()
+	engines := make([]*prompt.Engine, 0, len(timeline))
+
+	for i := range timeline {
+		frame := &timeline[i].Data
+
+		eng, err := Config(cfg, terminalWidth, true, func(flags *runtime.Flags) error {
+			return applyData(flags, frame)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render frame %d: %w", i, err)
+		}
+
+		engines = append(engines, eng)
+	}
+
+	return engines, nil
+}
+
+// Engine is Config without the closing eng.Primary() call, for a caller that
+// renders the prompt its own way - the animated export drives
+// eng.StreamPrimary() instead, after its applyData turned
+// runtime.Flags.Streaming on.
+func Engine(cfg *config.Config, terminalWidth int, resetTemplateCache bool, applyData func(*runtime.Flags) error) (*prompt.Engine, error) {
 	flags := &runtime.Flags{
 		ConfigPath:    cfg.Source,
 		Shell:         shell.GENERIC,
@@ -92,6 +136,7 @@ func Config(cfg *config.Config, terminalWidth int, resetTemplateCache bool, appl
 	}
 
 	template.Init(env, cfg.Var, cfg.Maps)
+	template.Extend(cfg.TemplateExtensions())
 
 	// set sane defaults for things we don't print/need while rendering for export
 	cfg.ConsoleTitleTemplate = ""
@@ -108,8 +153,6 @@ func Config(cfg *config.Config, terminalWidth int, resetTemplateCache bool, appl
 		RPromptBreathingRoom: rpromptBreathingRoom,
 	}
 
-	eng.Primary()
-
 	return eng, nil
 }
 
@@ -191,7 +234,13 @@ func SVGOptions(fontFamily string, columns int, metrics FontMetrics) svg.Options
 		opts.AccentBackground = accentBg
 	}
 
-	if bg, ok := svg.ResolveStaticRGB(terminal.BackgroundColor, true, &opts); ok {
+	// the config's terminal_background wins over the one the terminal reported
+	background := terminal.BackgroundColor
+	if len(background) == 0 && template.Cache != nil {
+		background = color.Ansi(template.Cache.TerminalBackground)
+	}
+
+	if bg, ok := svg.ResolveStaticRGB(background, true, &opts); ok {
 		opts.TerminalBackground = bg
 	}
 
@@ -217,3 +266,51 @@ func SVG(eng *prompt.Engine, opts svg.Options) string {
 
 	return svg.Encode(rows, opts)
 }
+
+// HTML is SVG's counterpart for a <pre> block: the same CapturedRuns/CursorAnchor
+// glue, feeding html.Encode instead, with the same options laying it out.
+//
+//nolint:gocritic
+func HTML(eng *prompt.Engine, opts svg.Options) string {
+	rows := eng.CapturedRuns()
+
+	if row, run, ok := eng.CursorAnchor(); ok {
+		opts.Cursor = &svg.Cursor{Row: row, Run: run}
+	}
+
+	return html.Encode(rows, opts)
+}
+
+// Runs is SVG's counterpart for a host drawing the prompt itself: the captured
+// rows, their colors resolved by the same options (see svg.Resolve, spans[i][j]
+// is rows[i][j]), where the cursor goes, and the options with every default
+// filled in for the colors behind the runs.
+//
+//nolint:gocritic
+func Runs(eng *prompt.Engine, opts svg.Options) (rows [][]terminal.Run, spans [][]svg.Span, cursor *svg.Cursor, resolved svg.Options) {
+	rows = eng.CapturedRuns()
+
+	if row, run, ok := eng.CursorAnchor(); ok {
+		cursor = &svg.Cursor{Row: row, Run: run}
+	}
+
+	spans, resolved = svg.Resolve(rows, opts)
+
+	return rows, spans, cursor, resolved
+}
+
+// PNG is SVG's raster counterpart: the same CapturedRuns/CursorAnchor glue,
+// feeding raster.Encode instead, so a PNG export lays out exactly like the SVG
+// one. opts.Options is typically built by SVGOptions too; the font that draws
+// the glyphs is the caller's to load, since the CLI reads it from a file.
+//
+//nolint:gocritic
+func PNG(eng *prompt.Engine, opts raster.Options) ([]byte, error) {
+	rows := eng.CapturedRuns()
+
+	if row, run, ok := eng.CursorAnchor(); ok {
+		opts.Cursor = &svg.Cursor{Row: row, Run: run}
+	}
+
+	return raster.Encode(rows, opts)
+}
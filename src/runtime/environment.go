@@ -77,6 +77,7 @@ type Environment interface {
 	ConvertToWindowsPath(input string) string
 	Connection(connectionType ConnectionType) (*Connection, error)
 	CursorPosition() (row, col int)
+	TerminalBackground() string
 	SystemInfo() (*SystemInfo, error)
 }
 
@@ -113,6 +114,11 @@ type Flags struct {
 	Force         bool
 	Streaming     bool
 	Interrupted   bool
+	// QueryTerminal lets the command ask the terminal for what it doesn't
+	// know otherwise, like its background color. Only a one-shot the init
+	// script runs in the shell's foreground has the terminal's input to
+	// itself, every prompt renders while the line editor may read it.
+	QueryTerminal bool
 	// DataOnly cuts this environment off from the machine: every method that
 	// would read a file, list a directory, resolve a symlink, run a command,
 	// make a request or read an OS variable answers empty or errDataOnly (see
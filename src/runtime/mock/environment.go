@@ -262,6 +262,11 @@ func (env *Environment) CursorPosition() (int, int) {
 	return args.Int(0), args.Int(1)
 }
 
+func (env *Environment) TerminalBackground() string {
+	args := env.Called()
+	return args.String(0)
+}
+
 func (env *Environment) SystemInfo() (*runtime.SystemInfo, error) {
 	args := env.Called()
 	return args.Get(0).(*runtime.SystemInfo), args.Error(1)
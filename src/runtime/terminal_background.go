@@ -0,0 +1,162 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+)
+
+const (
+	// queryBackground asks for the background color, OSC 11, and then for the
+	// primary device attributes, DA1. Every terminal answers DA1, and answers
+	// in order, so its reply closes the exchange: a terminal that doesn't
+	// support OSC 11 costs a round trip instead of the full timeout.
+	queryBackground = "\x1b]11;?\x1b\\\x1b[c"
+
+	backgroundQueryTimeout = 200 * time.Millisecond
+
+	// queryDrainTimeout is how long a reply that misses its timeout is still
+	// read, rather than left for the shell to find on the command line.
+	queryDrainTimeout = time.Second
+)
+
+// errTerminalBusy is a query that wasn't asked because the terminal's input
+// belongs to someone else right now, the next prompt can try again.
+var errTerminalBusy = errors.New("the terminal is busy")
+
+// TerminalBackground is the background color of the terminal the prompt is
+// printed in, as #rrggbb, or empty when it is unknown. The terminal is asked
+// once per session, the answer - or the lack of one - is kept in the session
+// cache. Only Flags.QueryTerminal asks, set by `get background`, which the
+// init script runs before the first prompt: a render runs while the shell or
+// the line editor reads from the terminal.
+func (term *Terminal) TerminalBackground() string {
+	defer log.Trace(time.Now())
+
+	if term.CmdFlags != nil && term.CmdFlags.DataOnly {
+		return ""
+	}
+
+	if background, found := cache.Get[string](cache.Session, cache.TERMINALBACKGROUNDCACHE); found {
+		return background
+	}
+
+	if term.CmdFlags == nil || !term.CmdFlags.QueryTerminal || term.CmdFlags.Streaming {
+		return ""
+	}
+
+	reply, err := queryTerminal(queryBackground, backgroundQueryTimeout)
+	if errors.Is(err, errTerminalBusy) {
+		log.Debug("terminal busy, not asking for its background")
+		return ""
+	}
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	background, _ := parseBackgroundReply(reply)
+	cache.Set(cache.Session, cache.TERMINALBACKGROUNDCACHE, background, cache.INFINITE)
+
+	return background
+}
+
+// parseBackgroundReply reads the color out of a terminal's OSC 11 reply,
+// ESC ] 11 ; rgb:RRRR/GGGG/BBBB followed by BEL or ST. A channel holds one to
+// four hex digits, scaled to its own width.
+func parseBackgroundReply(reply string) (string, bool) {
+	_, after, found := strings.Cut(reply, "\x1b]11;rgb:")
+	if !found {
+		return "", false
+	}
+
+	end := strings.IndexAny(after, "\x07\x1b")
+	if end < 0 {
+		return "", false
+	}
+
+	channels := strings.Split(after[:end], "/")
+	if len(channels) != 3 {
+		return "", false
+	}
+
+	var rgb [3]uint64
+
+	for i, channel := range channels {
+		if len(channel) == 0 || len(channel) > 4 {
+			return "", false
+		}
+
+		value, err := strconv.ParseUint(channel, 16, 16)
+		if err != nil {
+			return "", false
+		}
+
+		maximum := uint64(1)<<(4*len(channel)) - 1
+		rgb[i] = (value*255 + maximum/2) / maximum
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]), true
+}
+
+// hasDeviceAttributes tells whether reply holds the DA1 reply, ESC [ ? ... c.
+func hasDeviceAttributes(reply string) bool {
+	_, attributes, found := strings.Cut(reply, "\x1b[?")
+	return found && strings.Contains(attributes, "c")
+}
+
+// splitReply separates the escape sequences a terminal answers with from
+// what the user typed in the meantime. An OSC sequence ends with BEL or ST,
+// a CSI one with its final byte. Anything else, a lone ESC included, was
+// typed.
+func splitReply(input string) (reply, typed string) {
+	var replyBuilder, typedBuilder strings.Builder
+
+	for len(input) > 0 {
+		length := sequenceLength(input)
+		if length == 0 {
+			typedBuilder.WriteByte(input[0])
+			input = input[1:]
+			continue
+		}
+
+		replyBuilder.WriteString(input[:length])
+		input = input[length:]
+	}
+
+	return replyBuilder.String(), typedBuilder.String()
+}
+
+// sequenceLength is the length of the OSC or CSI sequence input starts with,
+// or 0 when it doesn't start with a complete one.
+func sequenceLength(input string) int {
+	if len(input) < 2 || input[0] != '\x1b' {
+		return 0
+	}
+
+	switch input[1] {
+	case ']':
+		for i := 2; i < len(input); i++ {
+			if input[i] == '\x07' {
+				return i + 1
+			}
+
+			if input[i] == '\x1b' && i+1 < len(input) && input[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	case '[':
+		for i := 2; i < len(input); i++ {
+			if input[i] >= 0x40 && input[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	}
+
+	return 0
+}
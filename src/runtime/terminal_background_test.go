@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBackgroundReply(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Reply    string
+		Expected string
+		OK       bool
+	}{
+		{Case: "16 bit channels, ST", Reply: "\x1b]11;rgb:1e1e/1e1e/2e2e\x1b\\\x1b[?62;22c", Expected: "#1e1e2e", OK: true},
+		{Case: "16 bit channels, BEL", Reply: "\x1b]11;rgb:ffff/ffff/ffff\x07\x1b[?1;2c", Expected: "#ffffff", OK: true},
+		{Case: "8 bit channels", Reply: "\x1b]11;rgb:fd/f6/e3\x1b\\", Expected: "#fdf6e3", OK: true},
+		{Case: "4 bit channels", Reply: "\x1b]11;rgb:f/0/8\x07", Expected: "#ff0088", OK: true},
+		{Case: "12 bit channels", Reply: "\x1b]11;rgb:800/000/fff\x07", Expected: "#8000ff", OK: true},
+		{Case: "unsupported, only DA1", Reply: "\x1b[?62;22c"},
+		{Case: "no reply"},
+		{Case: "cut short", Reply: "\x1b]11;rgb:ffff/ff"},
+		{Case: "two channels", Reply: "\x1b]11;rgb:ffff/ffff\x07"},
+		{Case: "not hex", Reply: "\x1b]11;rgb:zz/00/00\x07"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			background, ok := parseBackgroundReply(tc.Reply)
+			assert.Equal(t, tc.OK, ok)
+			assert.Equal(t, tc.Expected, background)
+		})
+	}
+}
+
+func TestSplitReply(t *testing.T) {
+	cases := []struct {
+		Case  string
+		Input string
+		Reply string
+		Typed string
+	}{
+		{Case: "reply only", Input: "\x1b]11;rgb:0/0/0\x1b\\\x1b[?62c", Reply: "\x1b]11;rgb:0/0/0\x1b\\\x1b[?62c"},
+		{Case: "typed before the reply", Input: "ls\x1b]11;rgb:0/0/0\x07\x1b[?62c", Reply: "\x1b]11;rgb:0/0/0\x07\x1b[?62c", Typed: "ls"},
+		{Case: "typed in between", Input: "\x1b]11;rgb:0/0/0\x07cd\x1b[?62c", Reply: "\x1b]11;rgb:0/0/0\x07\x1b[?62c", Typed: "cd"},
+		{Case: "lone escape", Input: "\x1b\x1b[?62c", Reply: "\x1b[?62c", Typed: "\x1b"},
+		{Case: "cut short", Input: "\x1b]11;rgb:0", Typed: "\x1b]11;rgb:0"},
+		{Case: "nothing"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			reply, typed := splitReply(tc.Input)
+			assert.Equal(t, tc.Reply, reply)
+			assert.Equal(t, tc.Typed, typed)
+		})
+	}
+}
+
+func TestTerminalBackgroundOnlyQueriesWhenAllowed(t *testing.T) {
+	cases := []struct {
+		Flags *Flags
+		Case  string
+	}{
+		{Case: "no flags"},
+		{Case: "not allowed", Flags: &Flags{Type: PRIMARY}},
+		{Case: "streaming", Flags: &Flags{Type: PRIMARY, QueryTerminal: true, Streaming: true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			t.Setenv("OMP_CACHE_DIR", t.TempDir())
+			cache.Init("bash", cache.NewSession)
+
+			term := &Terminal{CmdFlags: tc.Flags}
+			assert.Empty(t, term.TerminalBackground())
+
+			_, found := cache.Get[string](cache.Session, cache.TERMINALBACKGROUNDCACHE)
+			assert.False(t, found, "a render that doesn't ask must not decide for the session")
+		})
+	}
+}
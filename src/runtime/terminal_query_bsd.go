@@ -0,0 +1,12 @@
+//go:build darwin || freebsd || netbsd || openbsd || dragonfly
+
+package runtime
+
+import "golang.org/x/sys/unix"
+
+const (
+	ioctlGetTermios = unix.TIOCGETA
+	ioctlSetTermios = unix.TIOCSETA
+	// FIONREAD, which x/sys doesn't define on every BSD
+	ioctlInputPending = 0x4004667f
+)
@@ -0,0 +1,9 @@
+package runtime
+
+import "golang.org/x/sys/unix"
+
+const (
+	ioctlGetTermios   = unix.TCGETS
+	ioctlSetTermios   = unix.TCSETS
+	ioctlInputPending = unix.TIOCINQ
+)
@@ -0,0 +1,120 @@
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+const backgroundReply = "\x1b]11;rgb:1e1e/1e1e/2e2e\x1b\\\x1b[?62;22c"
+
+// openPty opens a pseudo terminal, the terminal side to answer from and the
+// tty side to query.
+func openPty(t *testing.T) (terminal, tty *os.File) {
+	t.Helper()
+
+	terminal, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = terminal.Close() })
+
+	fd := int(terminal.Fd())
+	require.NoError(t, unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0))
+
+	index, err := unix.IoctlGetInt(fd, unix.TIOCGPTN)
+	require.NoError(t, err)
+
+	tty, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", index), os.O_RDWR|unix.O_NOCTTY, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tty.Close() })
+
+	return terminal, tty
+}
+
+// answer reads the query off the terminal side and writes reply after delay.
+func answer(terminal *os.File, query, reply string, delay time.Duration) {
+	go func() {
+		buffer := make([]byte, len(query))
+		for read := 0; read < len(query); {
+			n, err := terminal.Read(buffer[read:])
+			if err != nil {
+				return
+			}
+
+			read += n
+		}
+
+		if reply == "" {
+			return
+		}
+
+		time.Sleep(delay)
+		_, _ = terminal.WriteString(reply)
+	}()
+}
+
+func pendingInput(t *testing.T, tty *os.File) int {
+	t.Helper()
+
+	pending, err := unix.IoctlGetInt(int(tty.Fd()), ioctlInputPending)
+	require.NoError(t, err)
+
+	return pending
+}
+
+func TestExchangeQuery(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Reply    string
+		Expected string
+		Delay    time.Duration
+	}{
+		{Case: "answered in time", Reply: backgroundReply, Expected: backgroundReply},
+		{Case: "late reply is read until its end", Reply: backgroundReply, Expected: backgroundReply, Delay: 300 * time.Millisecond},
+		{Case: "only DA1", Reply: "\x1b[?62;22c", Expected: "\x1b[?62;22c"},
+		{Case: "no reply"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			terminal, tty := openPty(t)
+			answer(terminal, queryBackground, tc.Reply, tc.Delay)
+
+			start := time.Now()
+			reply, err := exchangeQuery(tty, queryBackground, 50*time.Millisecond, time.Second)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.Expected, reply)
+			assert.Zero(t, pendingInput(t, tty), "nothing of the reply may be left for the shell")
+
+			if tc.Reply != "" {
+				assert.Less(t, time.Since(start), time.Second, "a complete reply ends the exchange")
+			}
+		})
+	}
+}
+
+func TestExchangeQueryTypeAhead(t *testing.T) {
+	terminal, tty := openPty(t)
+
+	_, err := terminal.WriteString("ls")
+	require.NoError(t, err)
+
+	// the line discipline has queued the input once it echoes it
+	echo := make([]byte, 2)
+	_, err = io.ReadFull(terminal, echo)
+	require.NoError(t, err)
+
+	_, err = exchangeQuery(tty, queryBackground, 50*time.Millisecond, time.Second)
+	assert.ErrorIs(t, err, errTerminalBusy)
+
+	buffer := make([]byte, 2)
+	require.NoError(t, unix.SetNonblock(int(tty.Fd()), true))
+	n, _ := tty.Read(buffer)
+	assert.Equal(t, "ls", string(buffer[:n]), "the type-ahead must be left for the shell")
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+
+package runtime
+
+import "time"
+
+// queryTerminal has no terminal to ask on Windows, where the console isn't
+// switched to raw input the unix way, nor on js/wasm, where there is no
+// terminal at all. The background stays unknown.
+func queryTerminal(_ string, _ time.Duration) (string, error) {
+	return "", nil
+}
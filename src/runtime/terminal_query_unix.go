@@ -0,0 +1,108 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package runtime
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// queryTerminal writes query to the controlling terminal and returns what it
+// answers, see exchangeQuery. Without a controlling terminal, such as in a
+// pipeline, there is nothing to ask. A process outside the terminal's
+// foreground process group, such as a render in the background, doesn't own
+// its input and would be stopped for changing its mode: that is errTerminalBusy.
+func queryTerminal(query string, timeout time.Duration) (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return "", nil
+	}
+
+	defer tty.Close()
+
+	fd := int(tty.Fd())
+
+	foreground, err := unix.IoctlGetInt(fd, unix.TIOCGPGRP)
+	if err != nil {
+		return "", nil
+	}
+
+	if foreground != unix.Getpgrp() {
+		return "", errTerminalBusy
+	}
+
+	return exchangeQuery(tty, query, timeout, queryDrainTimeout)
+}
+
+// exchangeQuery writes query to tty and reads the answer until the reply to
+// the DA1 query that closes every query. The terminal is switched to
+// non-canonical mode without echo for the exchange, so the reply neither
+// waits for a newline nor shows up on screen, and is restored after.
+//
+// A reply that misses the timeout still arrives, and would end up on the
+// command line once the terminal is restored. The answer is read until its
+// end regardless, for at most drain longer, for terminals that never answer.
+//
+// The exchange reads what the user types as well: input that is already
+// pending makes it errTerminalBusy without asking, the terminal is left alone
+// then. There is no handing input back (Linux reserves TIOCSTI for privileged
+// processes since 6.2), anything typed during the round trip itself is lost.
+func exchangeQuery(tty *os.File, query string, timeout, drain time.Duration) (string, error) {
+	fd := int(tty.Fd())
+
+	original, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return "", nil
+	}
+
+	raw := *original
+	raw.Lflag &^= unix.ICANON | unix.ECHO
+	raw.Cc[unix.VMIN] = 0
+	// a read returns after a tenth of a second without input
+	raw.Cc[unix.VTIME] = 1
+
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &raw); err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = unix.IoctlSetTermios(fd, ioctlSetTermios, original)
+	}()
+
+	// only counted once the terminal no longer waits for a full line
+	if pending, err := unix.IoctlGetInt(fd, ioctlInputPending); err != nil || pending > 0 {
+		return "", errTerminalBusy
+	}
+
+	if _, err := tty.WriteString(query); err != nil {
+		return "", err
+	}
+
+	var input strings.Builder
+
+	buffer := make([]byte, 256)
+	deadline := time.Now().Add(timeout + drain)
+
+	for time.Now().Before(deadline) {
+		// a read that times out without input is an EOF
+		n, err := tty.Read(buffer)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return input.String(), err
+		}
+
+		input.Write(buffer[:n])
+
+		if hasDeviceAttributes(input.String()) {
+			break
+		}
+	}
+
+	reply, _ := splitReply(input.String())
+
+	return reply, nil
+}
@@ -0,0 +1,339 @@
+// Package screen lays a prompt session out on a virtual terminal: the primary
+// prompt and its rprompt, a command typed at the cursor, the prompt collapsing
+// into the transient prompt once that command is accepted, and the fresh prompt
+// drawn below it. What's left is the grid a real terminal of the same size would
+// show, cursor included, which makes the questions an image can't answer easy to
+// see: where the cursor ends up, and whether a command runs into the rprompt.
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+)
+
+// layer is what wrote a cell.
+type layer int
+
+const (
+	blank layer = iota
+	left
+	right
+	typed
+)
+
+type cell struct {
+	text  string
+	layer layer
+}
+
+// Overlap is a span of a row where the typed command overwrote the right prompt,
+// Start and End both inclusive and starting at 0, like Row.
+type Overlap struct {
+	Row   int
+	Start int
+	End   int
+}
+
+// Screen is the grid a terminal shows once a prompt session is drawn onto it.
+type Screen struct {
+	lines    [][]cell
+	overlaps []Overlap
+	width    int
+	height   int
+	row      int
+	col      int
+}
+
+// New returns an empty screen of width columns. Once height rows are filled, the
+// oldest scroll out of view; a height of 0 grows the screen to fit instead.
+func New(width, height int) *Screen {
+	s := &Screen{
+		width:  max(width, 1),
+		height: max(height, 0),
+	}
+
+	s.lines = [][]cell{s.line()}
+
+	return s
+}
+
+// Preview draws eng's session onto a screen of width by height: the primary prompt,
+// command typed at its cursor and accepted, and the prompt that follows it. eng must
+// be fresh and built with terminal.CaptureRuns set (see render.Engine), as Preview
+// renders the primary and transient prompts itself.
+func Preview(eng *prompt.Engine, width, height int, command string) *Screen {
+	eng.Primary()
+
+	rows := eng.CapturedRuns()
+
+	var cursor *svg.Cursor
+	if row, run, ok := eng.CursorAnchor(); ok {
+		cursor = &svg.Cursor{Row: row, Run: run}
+	}
+
+	s := New(width, height)
+
+	start := s.row
+	s.DrawPrompt(rows, cursor)
+	s.Type(command)
+
+	if eng.Config.TransientPrompt != nil {
+		eng.ExtraPrompt(prompt.Transient)
+		s.Collapse(start, eng.CapturedTransientRuns())
+		s.Type(command)
+	}
+
+	s.Accept()
+	s.DrawPrompt(rows, cursor)
+
+	return s
+}
+
+// DrawPrompt draws rows from the start of the cursor's line and leaves the cursor at
+// the anchor, or after the last row when cursor is nil. Everything after the anchor
+// on its row is the right prompt, the cells a command typed there can run into.
+// A row wider than the screen is fitted the way an exported image fits it, see
+// svg.FitRow.
+func (s *Screen) DrawPrompt(rows [][]terminal.Run, cursor *svg.Cursor) {
+	s.col = 0
+
+	anchorRow, anchorCol := -1, 0
+
+	for i, row := range rows {
+		if i > 0 {
+			s.newline()
+		}
+
+		split := -1
+		if cursor != nil && cursor.Row == i {
+			split = cellsBefore(row, cursor.Run)
+		}
+
+		written := 0
+
+		for j, fitted := range svg.FitRow(row, s.width) {
+			if j > 0 {
+				s.newline()
+			}
+
+			for k := range fitted {
+				if written == split {
+					anchorRow, anchorCol = s.row, s.col
+				}
+
+				kind := left
+				if split >= 0 && written >= split {
+					kind = right
+				}
+
+				s.write(fitted[k].Text, kind)
+				written += fitted[k].Cells
+			}
+		}
+
+		if written == split {
+			anchorRow, anchorCol = s.row, s.col
+		}
+	}
+
+	if anchorRow >= 0 {
+		s.row, s.col = anchorRow, anchorCol
+	}
+}
+
+// Type writes text at the cursor the way a terminal echoes it, overwriting whatever
+// is there and wrapping at the right edge.
+func (s *Screen) Type(text string) {
+	s.write(text, typed)
+}
+
+// Collapse erases everything from line start down and draws the transient prompt
+// there instead, leaving the cursor right after it.
+func (s *Screen) Collapse(start int, runs []terminal.Run) {
+	start = min(max(start, 0), len(s.lines)-1)
+	s.lines = s.lines[:start+1]
+	s.lines[start] = s.line()
+	s.row, s.col = start, 0
+
+	for i := range runs {
+		s.write(runs[i].Text, left)
+	}
+}
+
+// Accept moves the cursor to the start of the line below the last one written, where
+// the shell draws its next prompt.
+func (s *Screen) Accept() {
+	s.row = len(s.lines) - 1
+	s.newline()
+}
+
+// Lines returns the visible rows, padded to the screen's width.
+func (s *Screen) Lines() []string {
+	top, rows := s.viewport()
+	lines := make([]string, 0, rows)
+
+	for i := range rows {
+		var b strings.Builder
+
+		if top+i < len(s.lines) {
+			for _, c := range s.lines[top+i] {
+				b.WriteString(c.text)
+			}
+		} else {
+			b.WriteString(strings.Repeat(" ", s.width))
+		}
+
+		lines = append(lines, b.String())
+	}
+
+	return lines
+}
+
+// Cursor returns the cursor's visible row and column, both starting at 0.
+func (s *Screen) Cursor() (row, col int) {
+	top, _ := s.viewport()
+	return s.row - top, s.col
+}
+
+// Overlaps returns the spans, in visible rows, where the typed command overwrote the
+// right prompt.
+func (s *Screen) Overlaps() []Overlap {
+	top, rows := s.viewport()
+	overlaps := make([]Overlap, 0, len(s.overlaps))
+
+	for _, overlap := range s.overlaps {
+		overlap.Row -= top
+		if overlap.Row < 0 || overlap.Row >= rows {
+			continue
+		}
+
+		overlaps = append(overlaps, overlap)
+	}
+
+	return overlaps
+}
+
+// String frames the visible rows and reports the cursor below them, counting rows and
+// columns from 1 like a terminal does.
+func (s *Screen) String() string {
+	var b strings.Builder
+
+	border := strings.Repeat("─", s.width)
+
+	b.WriteString("┌" + border + "┐\n")
+
+	for _, line := range s.Lines() {
+		b.WriteString("│" + line + "│\n")
+	}
+
+	b.WriteString("└" + border + "┘\n")
+
+	row, col := s.Cursor()
+	fmt.Fprintf(&b, "cursor: row %d, column %d\n", row+1, col+1)
+
+	for _, overlap := range s.Overlaps() {
+		fmt.Fprintf(&b, "overlap: row %d, columns %d-%d, the command runs into the right prompt\n",
+			overlap.Row+1, overlap.Start+1, overlap.End+1)
+	}
+
+	return b.String()
+}
+
+// viewport returns the first visible line and how many rows are visible. A fixed
+// height keeps the cursor's line on screen, scrolling the oldest lines out.
+func (s *Screen) viewport() (top, rows int) {
+	if s.height == 0 {
+		return 0, len(s.lines)
+	}
+
+	return max(len(s.lines)-s.height, 0), s.height
+}
+
+func (s *Screen) write(text string, kind layer) {
+	for _, r := range text {
+		if r == '\n' {
+			s.newline()
+			continue
+		}
+
+		glyph := string(r)
+		cells := terminal.VisibleCells(glyph)
+
+		// a combining mark joins the glyph before it
+		if cells == 0 {
+			if s.col > 0 {
+				s.lines[s.row][s.col-1].text += glyph
+			}
+
+			continue
+		}
+
+		if s.col+cells > s.width {
+			s.newline()
+		}
+
+		s.set(glyph, kind)
+
+		// the second cell of a wide glyph is drawn by the first
+		for range cells - 1 {
+			s.set("", kind)
+		}
+	}
+}
+
+func (s *Screen) set(text string, kind layer) {
+	current := &s.lines[s.row][s.col]
+
+	if kind == typed && current.layer == right && strings.TrimSpace(current.text) != "" {
+		s.overlap(s.row, s.col)
+	}
+
+	*current = cell{text: text, layer: kind}
+	s.col++
+}
+
+// overlap extends the last span when col continues it, or starts a new one.
+func (s *Screen) overlap(row, col int) {
+	if n := len(s.overlaps); n > 0 {
+		last := &s.overlaps[n-1]
+		if last.Row == row && last.End == col-1 {
+			last.End = col
+			return
+		}
+	}
+
+	s.overlaps = append(s.overlaps, Overlap{Row: row, Start: col, End: col})
+}
+
+func (s *Screen) newline() {
+	s.row++
+	s.col = 0
+
+	if s.row == len(s.lines) {
+		s.lines = append(s.lines, s.line())
+	}
+}
+
+func (s *Screen) line() []cell {
+	line := make([]cell, s.width)
+	for i := range line {
+		line[i] = cell{text: " "}
+	}
+
+	return line
+}
+
+// cellsBefore sums the cells of the runs in front of run.
+func cellsBefore(row []terminal.Run, run int) int {
+	cells := 0
+
+	for i := range min(run, len(row)) {
+		cells += row[i].Cells
+	}
+
+	return cells
+}
@@ -0,0 +1,209 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/svg"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func run(text string) terminal.Run {
+	return terminal.Run{Text: text, Cells: terminal.VisibleCells(text), ForegroundSource: color.Ansi("red")}
+}
+
+func gap(cells int) terminal.Run {
+	return terminal.Run{Text: strings.Repeat(" ", cells), Cells: cells}
+}
+
+func trimmed(s *Screen) []string {
+	lines := s.Lines()
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+
+	return lines
+}
+
+func TestDrawPrompt(t *testing.T) {
+	cases := []struct {
+		Cursor   *svg.Cursor
+		Case     string
+		Rows     [][]terminal.Run
+		Expected []string
+		Row      int
+		Col      int
+	}{
+		{
+			Case:     "cursor at the end without an anchor",
+			Rows:     [][]terminal.Run{{run("~/dev")}, {run("❯ ")}},
+			Expected: []string{"~/dev", "❯"},
+			Row:      1,
+			Col:      2,
+		},
+		{
+			Case:     "cursor at the anchor, in front of the rprompt",
+			Rows:     [][]terminal.Run{{run("❯ "), gap(4), run("12:00")}},
+			Cursor:   &svg.Cursor{Row: 0, Run: 1},
+			Expected: []string{"❯     12:00"},
+			Row:      0,
+			Col:      2,
+		},
+		{
+			Case:     "cursor on an earlier row",
+			Rows:     [][]terminal.Run{{run("❯ ")}, {run("below")}},
+			Cursor:   &svg.Cursor{Row: 0, Run: 1},
+			Expected: []string{"❯", "below"},
+			Row:      0,
+			Col:      2,
+		},
+		{
+			Case:     "a row wider than the screen wraps",
+			Rows:     [][]terminal.Run{{run("~/dev/"), run("oh-my-posh ")}},
+			Expected: []string{"~/dev/oh-my-", "posh"},
+			Row:      1,
+			Col:      5,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			s := New(12, 0)
+			s.DrawPrompt(tc.Rows, tc.Cursor)
+
+			assert.Equal(t, tc.Expected, trimmed(s))
+
+			row, col := s.Cursor()
+			assert.Equal(t, tc.Row, row, "cursor row")
+			assert.Equal(t, tc.Col, col, "cursor column")
+		})
+	}
+}
+
+func TestTypeOverlapsRPrompt(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Command  string
+		Expected []Overlap
+	}{
+		{Case: "short command", Command: "ls"},
+		{Case: "into the gap only", Command: "ls -la"},
+		{Case: "into the rprompt", Command: "git status", Expected: []Overlap{{Row: 0, Start: 8, End: 11}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			s := New(14, 0)
+			s.DrawPrompt([][]terminal.Run{{run("❯ "), gap(6), run("12:00")}}, &svg.Cursor{Row: 0, Run: 1})
+			s.Type(tc.Command)
+
+			assert.Equal(t, tc.Expected, nilIfEmpty(s.Overlaps()))
+		})
+	}
+}
+
+func nilIfEmpty(overlaps []Overlap) []Overlap {
+	if len(overlaps) == 0 {
+		return nil
+	}
+
+	return overlaps
+}
+
+func TestTypeWraps(t *testing.T) {
+	s := New(6, 0)
+	s.DrawPrompt([][]terminal.Run{{run("❯ ")}}, nil)
+	s.Type("echo hi")
+
+	assert.Equal(t, []string{"❯ echo", " hi"}, trimmed(s))
+
+	row, col := s.Cursor()
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 3, col)
+}
+
+func TestTypeWideGlyph(t *testing.T) {
+	s := New(4, 0)
+	s.Type("a日b")
+
+	assert.Equal(t, []string{"a日b"}, s.Lines())
+
+	_, col := s.Cursor()
+	assert.Equal(t, 4, col)
+}
+
+func TestSession(t *testing.T) {
+	prompt := [][]terminal.Run{{run("~/dev")}, {run("❯ ")}}
+
+	cases := []struct {
+		Case      string
+		Transient []terminal.Run
+		Expected  []string
+		Height    int
+		Row       int
+	}{
+		{
+			Case:     "prompt stays",
+			Expected: []string{"~/dev", "❯ ls", "~/dev", "❯"},
+			Row:      3,
+		},
+		{
+			Case:      "prompt collapses into the transient prompt",
+			Transient: []terminal.Run{run("> ")},
+			Expected:  []string{"> ls", "~/dev", "❯"},
+			Row:       2,
+		},
+		{
+			Case:     "oldest lines scroll out of view",
+			Height:   2,
+			Expected: []string{"~/dev", "❯"},
+			Row:      1,
+		},
+		{
+			Case:     "a taller screen leaves the rows below blank",
+			Height:   6,
+			Expected: []string{"~/dev", "❯ ls", "~/dev", "❯", "", ""},
+			Row:      3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			s := New(10, tc.Height)
+			s.DrawPrompt(prompt, nil)
+			s.Type("ls")
+
+			if tc.Transient != nil {
+				s.Collapse(0, tc.Transient)
+				s.Type("ls")
+			}
+
+			s.Accept()
+			s.DrawPrompt(prompt, nil)
+
+			assert.Equal(t, tc.Expected, trimmed(s))
+
+			row, col := s.Cursor()
+			assert.Equal(t, tc.Row, row, "cursor row")
+			assert.Equal(t, 2, col, "cursor column")
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	s := New(14, 0)
+	s.DrawPrompt([][]terminal.Run{{run("❯ "), gap(6), run("12:00")}}, &svg.Cursor{Row: 0, Run: 1})
+	s.Type("git status")
+
+	expected := `┌──────────────┐
+│❯ git status0 │
+└──────────────┘
+cursor: row 1, column 13
+overlap: row 1, columns 9-12, the command runs into the right prompt
+`
+
+	assert.Equal(t, expected, s.String())
+}
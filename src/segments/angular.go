@@ -2,12 +2,18 @@ package segments
 
 import (
 	"path/filepath"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type Angular struct {
 	Language
 }
 
+func init() {
+	options.Declare[Angular]()
+}
+
 func (a *Angular) Template() string {
 	return languageTemplate
 }
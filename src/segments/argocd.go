@@ -8,6 +8,7 @@ import (
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cmdflag"
 	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 	yaml "go.yaml.in/yaml/v3"
 )
 
@@ -36,6 +37,10 @@ type Argocd struct {
 	ArgocdContext
 }
 
+func init() {
+	options.Declare[Argocd]()
+}
+
 func (a *Argocd) Template() string {
 	return NameTemplate
 }
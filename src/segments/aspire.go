@@ -18,6 +18,17 @@ type Aspire struct {
 	Running     bool
 }
 
+func init() {
+	options.Declare[Aspire](
+		options.Declaration{
+			Name:        FetchRunning,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Query aspire ps to determine whether the resolved AppHost is running.",
+		},
+	)
+}
+
 var aspireAppHostFiles = []string{
 	"apphost.cs",
 	"apphost.ts",
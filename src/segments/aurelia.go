@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Aurelia struct {
 	Language
 }
 
+func init() {
+	options.Declare[Aurelia]()
+}
+
 func (a *Aurelia) Template() string {
 	return languageTemplate
 }
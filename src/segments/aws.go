@@ -27,6 +27,17 @@ type Aws struct {
 	AccessKeyID string
 }
 
+func init() {
+	options.Declare[Aws](
+		options.Declaration{
+			Name:        options.DisplayDefault,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Display the segment when default user or not.",
+		},
+	)
+}
+
 const (
 	defaultStr = "default"
 
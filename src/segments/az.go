@@ -16,6 +16,19 @@ type Az struct {
 	AzureSubscription
 }
 
+func init() {
+	options.Declare[Az](
+		options.Declaration{
+			Name:    Source,
+			Type:    options.StringType,
+			Default: FirstMatch,
+			Description: "Sources to get subscription information from. Can be any of the following values, joined by | to loop multiple sources " +
+				"for context. cli: fetch the information from the CLI config; pwsh: fetch the information from the PowerShell Module " +
+				"config.",
+		},
+	)
+}
+
 const (
 	Source options.Option = "source"
 
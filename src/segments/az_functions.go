@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type AzFunc struct {
 	Language
 }
 
+func init() {
+	options.Declare[AzFunc]()
+}
+
 func (az *AzFunc) Template() string {
 	return languageTemplate
 }
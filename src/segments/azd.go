@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type Azd struct {
@@ -13,6 +14,17 @@ type Azd struct {
 	AzdConfig
 }
 
+func init() {
+	options.Declare[Azd](
+		options.Declaration{
+			Name:        LanguageFolders,
+			Type:        options.StringArrayType,
+			Default:     []string{".azure"},
+			Description: "The folders to look for when determining if the current directory is an azd project.",
+		},
+	)
+}
+
 type AzdConfig struct {
 	DefaultEnvironment string `json:"defaultEnvironment"`
 	Version            int    `json:"version"`
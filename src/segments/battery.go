@@ -12,6 +12,41 @@ type Battery struct {
 	battery.Info
 }
 
+func init() {
+	options.Declare[Battery](
+		options.Declaration{
+			Name:        ChargedIcon,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Text/icon to display when fully charged.",
+		},
+		options.Declaration{
+			Name:        ChargingIcon,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Text/icon to display when charging.",
+		},
+		options.Declaration{
+			Name:        DischargingIcon,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Text/icon to display when discharging.",
+		},
+		options.Declaration{
+			Name:        options.DisplayError,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Show the error context when failing to retrieve the battery information.",
+		},
+		options.Declaration{
+			Name:        NotChargingIcon,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Text/icon to display when on AC power.",
+		},
+	)
+}
+
 const (
 	ChargingIcon    options.Option = "charging_icon"
 	DischargingIcon options.Option = "discharging_icon"
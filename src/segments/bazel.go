@@ -7,6 +7,17 @@ type Bazel struct {
 	Language
 }
 
+func init() {
+	options.Declare[Bazel](
+		options.Declaration{
+			Name:        Icon,
+			Type:        options.StringType,
+			Default:     "\ue63a",
+			Description: "The icon representing Bazel's logo.",
+		},
+	)
+}
+
 const (
 	Icon options.Option = "icon"
 )
@@ -27,6 +27,119 @@ type Brewfather struct {
 	DaysBottled    uint
 }
 
+func init() {
+	options.Declare[Brewfather](
+		options.Declaration{
+			Name:        APIKey,
+			Type:        options.TemplateType,
+			Default:     "",
+			Description: "Provided by Brewfather's Generate API Key settings option.",
+		},
+		options.Declaration{
+			Name:        BFArchivedStatusIcon,
+			Type:        options.StringType,
+			Default:     "\uF187",
+			Description: "Available in template as .StatusIcon.",
+		},
+		options.Declaration{
+			Name:        BFBatchID,
+			Type:        options.TemplateType,
+			Default:     "",
+			Description: "At the end of the URL when viewing the batch on the Brewfather site.",
+		},
+		options.Declaration{
+			Name:        BFBrewingStatusIcon,
+			Type:        options.StringType,
+			Default:     "\uF7DE",
+			Description: "Available in template as .StatusIcon.",
+		},
+		options.Declaration{
+			Name:        BFCompletedStatusIcon,
+			Type:        options.StringType,
+			Default:     "\uF7A5",
+			Description: "Available in template as .StatusIcon.",
+		},
+		options.Declaration{
+			Name:        BFConditioningStatusIcon,
+			Type:        options.StringType,
+			Default:     "\uE372",
+			Description: "Available in template as .StatusIcon.",
+		},
+		options.Declaration{
+			Name:        BFDayIcon,
+			Type:        options.StringType,
+			Default:     "d",
+			Description: "Appended to a number to indicate days, e.g. 25d.",
+		},
+		options.Declaration{
+			Name:        BFDoubleDownIcon,
+			Type:        options.StringType,
+			Default:     "↓↓",
+			Description: "Delta between this and prior temperature reading is very large negative (> -4C by default), available in template as .TemperatureTrend.",
+		},
+		options.Declaration{
+			Name:        BFDoubleUpIcon,
+			Type:        options.StringType,
+			Default:     "↑↑",
+			Description: "Delta between this and prior temperature reading is very high (> 4C by default), available in template as .TemperatureTrend.",
+		},
+		options.Declaration{
+			Name:        BFFermentingStatusIcon,
+			Type:        options.StringType,
+			Default:     "\uF499",
+			Description: "Available in template as .StatusIcon.",
+		},
+		options.Declaration{
+			Name:        BFFlatIcon,
+			Type:        options.StringType,
+			Default:     "→",
+			Description: "Delta between this and prior temperature and this temperature reading (< +-0.5C change), available in template as .TemperatureTrend.",
+		},
+		options.Declaration{
+			Name:        BFFortyFiveDownIcon,
+			Type:        options.StringType,
+			Default:     "↘",
+			Description: "Delta between this and prior temperature reading is negative (-0.5C > delta > -2C by default), available in template as .TemperatureTrend.",
+		},
+		options.Declaration{
+			Name:        BFFortyFiveUpIcon,
+			Type:        options.StringType,
+			Default:     "↗",
+			Description: "Delta between this and prior temperature reading is positive (0.5C < delta < 2C by default), available in template as .TemperatureTrend.",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+		options.Declaration{
+			Name:        BFPlanningStatusIcon,
+			Type:        options.StringType,
+			Default:     "\uF8EA",
+			Description: "Available in template as .StatusIcon.",
+		},
+		options.Declaration{
+			Name:        BFSingleDownIcon,
+			Type:        options.StringType,
+			Default:     "↓",
+			Description: "Delta between this and prior temperature reading is large negative (-2C > delta > -4C by default), available in template as .TemperatureTrend.",
+		},
+		options.Declaration{
+			Name:        BFSingleUpIcon,
+			Type:        options.StringType,
+			Default:     "↑",
+			Description: "Delta between this and prior temperature reading is high (2C < delta < 4C by default), available in template as .TemperatureTrend.",
+		},
+		options.Declaration{
+			Name:        BFUserID,
+			Type:        options.TemplateType,
+			Default:     "",
+			Description: "Provided by Brewfather's Generate API Key settings option.",
+		},
+	)
+}
+
 const (
 	BFUserID  options.Option = "user_id"
 	BFBatchID options.Option = "batch_id"
@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Buf struct {
 	Language
 }
 
+func init() {
+	options.Declare[Buf]()
+}
+
 func (b *Buf) Template() string {
 	return languageTemplate
 }
@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Bun struct {
 	Language
 }
 
+func init() {
+	options.Declare[Bun]()
+}
+
 func (b *Bun) Template() string {
 	return languageTemplate
 }
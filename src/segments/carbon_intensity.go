@@ -16,6 +16,17 @@ type CarbonIntensity struct {
 	CarbonIntensityData
 }
 
+func init() {
+	options.Declare[CarbonIntensity](
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+	)
+}
+
 type CarbonIntensityResponse struct {
 	Data []CarbonIntensityPeriod `json:"data"`
 }
@@ -1,10 +1,16 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Cds struct {
 	Language
 	HasDependency bool
 }
 
+func init() {
+	options.Declare[Cds]()
+}
+
 func (c *Cds) Template() string {
 	return languageTemplate
 }
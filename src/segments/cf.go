@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Cf struct {
 	Language
 }
 
+func init() {
+	options.Declare[Cf]()
+}
+
 func (c *Cf) Template() string {
 	return languageTemplate
 }
@@ -13,6 +13,24 @@ type CfTarget struct {
 	CfTargetDetails
 }
 
+func init() {
+	options.Declare[CfTarget](
+		options.Declaration{
+			Name:        DisplayMode,
+			Type:        options.StringType,
+			Default:     DisplayModeAlways,
+			Description: "Determines whether the segment is displayed always or only if a file matching the extensions are present in the current folder.",
+			Values:      []string{"always", "files"},
+		},
+		options.Declaration{
+			Name:        options.Files,
+			Type:        options.StringArrayType,
+			Default:     []string{"manifest.yml"},
+			Description: "The files to display in the segment.",
+		},
+	)
+}
+
 type CfTargetDetails struct {
 	URL   string
 	User  string
@@ -18,6 +18,23 @@ type Claude struct {
 	ClaudeData
 }
 
+func init() {
+	options.Declare[Claude](
+		options.Declaration{
+			Name:        gaugeMarkedChar,
+			Type:        options.StringType,
+			Default:     "▰",
+			Description: "The character used for the filled part of the token usage gauge.",
+		},
+		options.Declaration{
+			Name:        gaugeUnmarkedChar,
+			Type:        options.StringType,
+			Default:     "▱",
+			Description: "The character used for the empty part of the token usage gauge.",
+		},
+	)
+}
+
 type ClaudeData struct {
 	Effort            *ClaudeEffort       `json:"effort"`
 	Worktree          *ClaudeWorktree     `json:"worktree"`
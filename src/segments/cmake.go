@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Cmake struct {
 	Language
 }
 
+func init() {
+	options.Declare[Cmake]()
+}
+
 func (c *Cmake) Template() string {
 	return languageTemplate
 }
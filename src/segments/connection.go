@@ -13,6 +13,17 @@ type Connection struct {
 	runtime.Connection
 }
 
+func init() {
+	options.Declare[Connection](
+		options.Declaration{
+			Name:        Type,
+			Type:        options.StringType,
+			Default:     "wifi|ethernet",
+			Description: "The connection types to display, joined by |: wifi, ethernet, cellular or bluetooth.",
+		},
+	)
+}
+
 const (
 	Type options.Option = "type"
 )
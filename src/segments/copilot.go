@@ -27,6 +27,17 @@ type Copilot struct {
 	Chat            CopilotUsage `json:"chat"`
 }
 
+func init() {
+	options.Declare[Copilot](
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+	)
+}
+
 const (
 	copilotAPIURL = "https://api.github.com/copilot_internal/user"
 )
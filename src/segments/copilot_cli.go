@@ -3,6 +3,7 @@ package segments
 import (
 	"github.com/jandedobbeleer/oh-my-posh/src/cache"
 	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 	"github.com/jandedobbeleer/oh-my-posh/src/text"
 )
 
@@ -13,6 +14,23 @@ type CopilotCLI struct {
 	CopilotCLIData
 }
 
+func init() {
+	options.Declare[CopilotCLI](
+		options.Declaration{
+			Name:        gaugeMarkedChar,
+			Type:        options.StringType,
+			Default:     "▰",
+			Description: "The character used for the filled part of the token usage gauge.",
+		},
+		options.Declaration{
+			Name:        gaugeUnmarkedChar,
+			Type:        options.StringType,
+			Default:     "▱",
+			Description: "The character used for the empty part of the token usage gauge.",
+		},
+	)
+}
+
 type CopilotCLIData struct {
 	Model          AIModel                 `json:"model"`
 	Workspace      CopilotCLIWorkspace     `json:"workspace"`
@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Deno struct {
 	Language
 }
 
+func init() {
+	options.Declare[Deno]()
+}
+
 func (d *Deno) Template() string {
 	return languageTemplate
 }
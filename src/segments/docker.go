@@ -29,6 +29,41 @@ type Docker struct {
 	Containers []Container
 }
 
+func init() {
+	options.Declare[Docker](
+		options.Declaration{
+			Name:        DisplayMode,
+			Type:        options.StringType,
+			Default:     DisplayModeContext,
+			Description: "Determines whether the segment is displayed always or only if a file matching the extensions are present in the current folder.",
+			Values:      []string{"files", "context", "environment"},
+		},
+		options.Declaration{
+			Name:        DockerCommand,
+			Type:        options.StringType,
+			Default:     "docker",
+			Description: "Command used to call Docker.",
+		},
+		options.Declaration{
+			Name:        LanguageExtensions,
+			Type:        options.StringArrayType,
+			Description: "The files to look for when determining if the current directory holds a docker context.",
+		},
+		options.Declaration{
+			Name:        FetchContext,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Fetch the Docker context.",
+		},
+		options.Declaration{
+			Name:        Filter,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "A filter expression passed to docker ps, using the same syntax as the Docker CLI's --filter flag.",
+		},
+	)
+}
+
 type Container struct {
 	ID      string
 	Image   string
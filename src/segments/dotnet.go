@@ -23,6 +23,17 @@ type Dotnet struct {
 	Unsupported bool
 }
 
+func init() {
+	options.Declare[Dotnet](
+		options.Declaration{
+			Name:        FetchSDKVersion,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Fetch the .NET SDK version used in global.json.",
+		},
+	)
+}
+
 func (d *Dotnet) Template() string {
 	return " {{ if .Unsupported }}\uf071{{ else }}{{ .Full }}{{ end }} "
 }
@@ -1,6 +1,10 @@
 package segments
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+)
 
 type DvcStatus struct {
 	ScmStatus
@@ -28,6 +32,17 @@ type Dvc struct {
 	Scm
 }
 
+func init() {
+	options.Declare[Dvc](
+		options.Declaration{
+			Name:        StatusFormats,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Override the status format for a specific change. Example: {\"Added\": \"Added: %d\"}.",
+		},
+	)
+}
+
 func (d *Dvc) Template() string {
 	return "  {{ .Status.String }} "
 }
@@ -15,6 +15,30 @@ type Executiontime struct {
 	Ms          int64
 }
 
+func init() {
+	options.Declare[Executiontime](
+		options.Declaration{
+			Name:        options.AlwaysEnabled,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Always show the duration.",
+		},
+		options.Declaration{
+			Name:        options.Style,
+			Type:        options.StringType,
+			Default:     "austin",
+			Description: "The style in which the time will be displayed.",
+			Values:      []string{"austin", "roundrock", "dallas", "galveston", "galvestonms", "houston", "amarillo", "round", "lucky7", "iso8601", "iso8601ms"},
+		},
+		options.Declaration{
+			Name:        ThresholdProperty,
+			Type:        options.Float64Type,
+			Default:     500,
+			Description: "Minimum duration (milliseconds) required to enable this segment.",
+		},
+	)
+}
+
 type DurationStyle string
 
 const (
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 const (
@@ -19,6 +20,10 @@ type Firebase struct {
 	Project string
 }
 
+func init() {
+	options.Declare[Firebase]()
+}
+
 type FirebaseData struct {
 	ActiveProject map[string]string `json:"activeProjects"`
 }
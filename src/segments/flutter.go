@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Flutter struct {
 	Language
 }
 
+func init() {
+	options.Declare[Flutter]()
+}
+
 func (f *Flutter) Template() string {
 	return languageTemplate
 }
@@ -1,6 +1,10 @@
 package segments
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+)
 
 type FossilStatus struct {
 	ScmStatus
@@ -31,6 +35,10 @@ type Fossil struct {
 	Scm
 }
 
+func init() {
+	options.Declare[Fossil]()
+}
+
 func (f *Fossil) Template() string {
 	return " \ue725 {{.Branch}} {{.Status.String}} "
 }
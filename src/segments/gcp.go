@@ -8,6 +8,7 @@ import (
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/ini"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 const (
@@ -23,6 +24,10 @@ type Gcp struct {
 	ActiveConfig string
 }
 
+func init() {
+	options.Declare[Gcp]()
+}
+
 func (g *Gcp) Template() string {
 	return " {{ .Project }} "
 }
@@ -151,6 +151,204 @@ type Git struct {
 	UpstreamGone     bool
 }
 
+func init() {
+	options.Declare[Git](
+		options.Declaration{
+			Name:        AzureDevOpsIcon,
+			Type:        options.StringType,
+			Default:     "\uebe8",
+			Description: "Icon/text to display when the upstream is Azure DevOps.",
+		},
+		options.Declaration{
+			Name:        BitbucketIcon,
+			Type:        options.StringType,
+			Default:     "\uf171",
+			Description: "Icon/text to display when the upstream is Bitbucket.",
+		},
+		options.Declaration{
+			Name:        BranchAheadIcon,
+			Type:        options.StringType,
+			Default:     "\u2191",
+			Description: "The icon to display when the local branch is ahead of its remote.",
+		},
+		options.Declaration{
+			Name:        BranchBehindIcon,
+			Type:        options.StringType,
+			Default:     "\u2193",
+			Description: "The icon to display when the local branch is behind its remote.",
+		},
+		options.Declaration{
+			Name:        BranchGoneIcon,
+			Type:        options.StringType,
+			Default:     "\u2262",
+			Description: "The icon to display when there's no remote branch.",
+		},
+		options.Declaration{
+			Name:        BranchIcon,
+			Type:        options.StringType,
+			Default:     "\uE0A0",
+			Description: "The icon to use in front of the git branch name.",
+		},
+		options.Declaration{
+			Name:        BranchIdenticalIcon,
+			Type:        options.StringType,
+			Default:     "\u2261",
+			Description: "The icon to display when remote and local are identical.",
+		},
+		options.Declaration{
+			Name:        CherryPickIcon,
+			Type:        options.StringType,
+			Default:     "\uE29B ",
+			Description: "Icon/text to display before the context when doing a cherry-pick.",
+		},
+		options.Declaration{
+			Name:        CodebergIcon,
+			Type:        options.StringType,
+			Default:     "\uf330",
+			Description: "Icon/text to display when the upstream is Codeberg.",
+		},
+		options.Declaration{
+			Name:        CodeCommit,
+			Type:        options.StringType,
+			Default:     "\uf270",
+			Description: "Icon/text to display when the upstream is CodeCommit.",
+		},
+		options.Declaration{
+			Name:        CommitIcon,
+			Type:        options.StringType,
+			Default:     "\uF417",
+			Description: "Icon/text to display before the commit context (detached HEAD)",
+		},
+		options.Declaration{
+			Name:        DisableWithJJ,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Disable the git segment when there's a .jj directory in the parent file path.",
+		},
+		options.Declaration{
+			Name:        FetchBareInfo,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Fetch info when in a bare repo or not.",
+		},
+		options.Declaration{
+			Name:        FetchPushStatus,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Display the push-remote ahead/behind information or not.",
+		},
+		options.Declaration{
+			Name:        FetchStatus,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Display the local changes or not.",
+		},
+		options.Declaration{
+			Name:        FetchUpstreamIcon,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Display upstream icon or not.",
+		},
+		options.Declaration{
+			Name:        FetchUser,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Fetch the current configured user for the repository.",
+		},
+		options.Declaration{
+			Name:        GitIcon,
+			Type:        options.StringType,
+			Default:     "\uE5FB ",
+			Description: "Icon/text to display when the upstream is not known/mapped.",
+		},
+		options.Declaration{
+			Name:        GithubIcon,
+			Type:        options.StringType,
+			Default:     "\uf408",
+			Description: "Icon/text to display when the upstream is GitHub.",
+		},
+		options.Declaration{
+			Name:        GitlabIcon,
+			Type:        options.StringType,
+			Default:     "\uf296",
+			Description: "Icon/text to display when the upstream is GitLab.",
+		},
+		options.Declaration{
+			Name:        IgnoreStatus,
+			Type:        options.StringArrayType,
+			Default:     []string{},
+			Description: "Skip fetching status for repositories matching these patterns, using the same matching logic as exclude_folders.",
+		},
+		options.Declaration{
+			Name:        IgnoreSubmodules,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Ignore changes to submodules when looking for changes.",
+		},
+		options.Declaration{
+			Name:        MergeIcon,
+			Type:        options.StringType,
+			Default:     "\uE727 ",
+			Description: "Icon/text to display before the merge context.",
+		},
+		options.Declaration{
+			Name:        NativeStatus,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Fetch the status information using the built-in engine instead of the git CLI (experimental, falls back to git automatically).",
+		},
+		options.Declaration{
+			Name:        NoCommitsIcon,
+			Type:        options.StringType,
+			Default:     "\U000F0095 ",
+			Description: "Icon/text to display when there are no commits in the repo.",
+		},
+		options.Declaration{
+			Name:        RebaseIcon,
+			Type:        options.StringType,
+			Default:     "\uE728 ",
+			Description: "Icon/text to display before the context when in a rebase.",
+		},
+		options.Declaration{
+			Name:        RevertIcon,
+			Type:        options.StringType,
+			Default:     "\uF0E2 ",
+			Description: "Icon/text to display before the context when doing a revert.",
+		},
+		options.Declaration{
+			Name:        Source,
+			Type:        options.StringType,
+			Default:     Cli,
+			Description: "The source to fetch the status from, cli or pwsh (posh-git).",
+		},
+		options.Declaration{
+			Name:        StatusFormats,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Override the status format for a specific change. Example: {\"Added\": \"Added: %d\"}.",
+		},
+		options.Declaration{
+			Name:        TagIcon,
+			Type:        options.StringType,
+			Default:     "\uF412",
+			Description: "Icon/text to display before the tag context.",
+		},
+		options.Declaration{
+			Name:        UntrackedModes,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Set the untracked files mode for a repository.",
+		},
+		options.Declaration{
+			Name:    UpstreamIcons,
+			Type:    options.KeyValueMapType,
+			Default: map[string]string{},
+			Description: "A key, value map representing the remote URL (or a part of that URL) and icon to use in case the upstream URL contains " +
+				"the key. These get precedence over the standard icons.",
+		},
+	)
+}
+
 func (g *Git) Template() string {
 	return " {{ .HEAD }}{{if .BranchStatus }} {{ .BranchStatus }}{{ end }}{{ if .Working.Changed }} \uF044 {{ .Working.String }}{{ end }}{{ if and (.Staging.Changed) (.Working.Changed) }} |{{ end }}{{ if .Staging.Changed }} \uF046 {{ .Staging.String }}{{ end }} " //nolint: lll
 }
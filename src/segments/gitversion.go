@@ -2,6 +2,8 @@ package segments
 
 import (
 	"encoding/json"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type GitVersionInfo struct {
@@ -46,6 +48,10 @@ type GitVersion struct {
 	GitVersionInfo
 }
 
+func init() {
+	options.Declare[GitVersion]()
+}
+
 func (n *GitVersion) Template() string {
 	return " {{ .MajorMinorPatch }} "
 }
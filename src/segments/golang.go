@@ -11,6 +11,23 @@ type Golang struct {
 	Language
 }
 
+func init() {
+	options.Declare[Golang](
+		options.Declaration{
+			Name:        ParseModFile,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Parse go.mod file instead of calling out to go to improve performance.",
+		},
+		options.Declaration{
+			Name:        ParseWorkFile,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Parse the go.work file instead of go.mod to determine the version.",
+		},
+	)
+}
+
 const (
 	ParseModFile  options.Option = "parse_mod_file"
 	ParseWorkFile options.Option = "parse_work_file"
@@ -2,6 +2,7 @@ package segments
 
 import (
 	"github.com/jandedobbeleer/oh-my-posh/src/regex"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 const (
@@ -16,6 +17,10 @@ type Gradle struct {
 	Language
 }
 
+func init() {
+	options.Declare[Gradle]()
+}
+
 func (g *Gradle) Template() string {
 	return languageTemplate
 }
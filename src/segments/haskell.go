@@ -8,6 +8,18 @@ type Haskell struct {
 	StackGhc bool
 }
 
+func init() {
+	options.Declare[Haskell](
+		options.Declaration{
+			Name:        StackGhcMode,
+			Type:        options.StringType,
+			Default:     "never",
+			Description: "Get the GHC version used by Stack. Will decrease performance. Boolean indicating whether stack ghc was used available in template as .StackGhc.",
+			Values:      []string{"always", "package", "never"},
+		},
+	)
+}
+
 const (
 	StackGhcMode options.Option = "stack_ghc_mode"
 )
@@ -1,11 +1,25 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Helm struct {
 	Base
 
 	Version string
 }
 
+func init() {
+	options.Declare[Helm](
+		options.Declaration{
+			Name:        DisplayMode,
+			Type:        options.StringType,
+			Default:     DisplayModeAlways,
+			Description: "Determines whether the segment is displayed always or only if a file matching the extensions are present in the current folder.",
+			Values:      []string{"always", "files"},
+		},
+	)
+}
+
 func (h *Helm) Enabled() bool {
 	displayMode := h.options.String(DisplayMode, DisplayModeAlways)
 	if displayMode != DisplayModeFiles {
@@ -15,6 +15,29 @@ type HTTP struct {
 	Body map[string]any
 }
 
+func init() {
+	options.Declare[HTTP](
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     10000,
+			Description: "The timeout in milliseconds for the request.",
+		},
+		options.Declaration{
+			Name:        METHOD,
+			Type:        options.StringType,
+			Default:     "GET",
+			Description: "The HTTP method to use.",
+		},
+		options.Declaration{
+			Name:        URL,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "The HTTP URL you want to call, supports templates.",
+		},
+	)
+}
+
 const (
 	METHOD options.Option = "method"
 )
@@ -32,6 +32,23 @@ type IPify struct {
 	IP  string
 }
 
+func init() {
+	options.Declare[IPify](
+		options.Declaration{
+			Name:        options.CacheDuration,
+			Type:        options.StringType,
+			Default:     "24h",
+			Description: "The duration for which the segment is cached, parsed using Go's time.ParseDuration format (e.g. 5m, 1h30m).",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+	)
+}
+
 const (
 	OFFLINE = "OFFLINE"
 )
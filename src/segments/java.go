@@ -2,12 +2,18 @@ package segments
 
 import (
 	"fmt"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type Java struct {
 	Language
 }
 
+func init() {
+	options.Declare[Java]()
+}
+
 func (j *Java) Template() string {
 	return languageTemplate
 }
@@ -45,6 +45,47 @@ type Jujutsu struct {
 	Scm
 }
 
+func init() {
+	options.Declare[Jujutsu](
+		options.Declaration{
+			Name:        AheadIcon,
+			Type:        options.StringType,
+			Default:     "\u21e1",
+			Description: "Icon to separate bookmark name and ahead counter.",
+		},
+		options.Declaration{
+			Name:        ChangeIDMinLen,
+			Type:        options.IntType,
+			Default:     0,
+			Description: "Minimum length of the change ID to display.",
+		},
+		options.Declaration{
+			Name:        FetchAhead,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Fetch working copy # of changes ahead of the nearest bookmark.",
+		},
+		options.Declaration{
+			Name:        FetchStatus,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Display the changes in the working copy.",
+		},
+		options.Declaration{
+			Name:        IgnoreWorkingCopy,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Don't snapshot the working copy, and don't update it.",
+		},
+		options.Declaration{
+			Name:        StatusFormats,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Override the status format for a specific change. Example: {\"Added\": \"Added: %d\"}.",
+		},
+	)
+}
+
 func (jj *Jujutsu) Template() string {
 	return " \uf1fa{{.ChangeID}}{{if .Working.Changed}} \uf044 {{ .Working.String }}{{ end }} "
 }
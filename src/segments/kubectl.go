@@ -23,6 +23,35 @@ type Kubectl struct {
 	dirty   bool
 }
 
+func init() {
+	options.Declare[Kubectl](
+		options.Declaration{
+			Name:        ClusterAliases,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Custom cluster names.",
+		},
+		options.Declaration{
+			Name:        ContextAliases,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Custom context names.",
+		},
+		options.Declaration{
+			Name:        options.DisplayError,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Show the error context when failing to retrieve the kubectl information.",
+		},
+		options.Declaration{
+			Name:        ParseKubeConfig,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Parse kubeconfig files instead of calling out to kubectl to improve performance.",
+		},
+	)
+}
+
 type KubeConfig struct {
 	CurrentContext string `yaml:"current-context"`
 	Contexts       []struct {
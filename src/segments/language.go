@@ -116,6 +116,66 @@ type Language struct {
 	Mismatch           bool
 }
 
+func init() {
+	options.Declare[Language](
+		options.Declaration{
+			Name:        options.CacheDuration,
+			Type:        options.StringType,
+			Default:     "none",
+			Description: "The duration for which the segment is cached, parsed using Go's time.ParseDuration format (e.g. 5m, 1h30m).",
+		},
+		options.Declaration{
+			Name:        DisplayMode,
+			Type:        options.StringType,
+			Default:     DisplayModeFiles,
+			Description: "Determines whether the segment is displayed always or only if a file matching the extensions are present in the current folder.",
+			Values:      []string{"always", "files", "environment", "context"},
+		},
+		options.Declaration{
+			Name:        LanguageExtensions,
+			Type:        options.StringArrayType,
+			Description: "The extensions to look for when determining if the current directory is a project.",
+		},
+		options.Declaration{
+			Name:        options.FetchVersion,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Fetch the version number.",
+		},
+		options.Declaration{
+			Name:        LanguageFolders,
+			Type:        options.StringArrayType,
+			Description: "The folders to look for when determining if a folder is a workspace.",
+		},
+		options.Declaration{
+			Name:        HomeEnabled,
+			Type:        options.BoolType,
+			Description: "Display the segment in the HOME folder.",
+		},
+		options.Declaration{
+			Name:        MissingCommandText,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "The string to display when the command is not available.",
+		},
+		options.Declaration{
+			Name:        LanguageProjectFiles,
+			Type:        options.StringArrayType,
+			Description: "The files to look for in a parent directory when determining if the current directory is part of a project.",
+		},
+		options.Declaration{
+			Name:        Tooling,
+			Type:        options.StringArrayType,
+			Description: "The tooling to use for fetching the version.",
+		},
+		options.Declaration{
+			Name:        options.VersionURLTemplate,
+			Type:        options.StringType,
+			Description: "Template that creates the URL of the version info / release notes.",
+		},
+	)
+}
+
 const (
 	// DisplayMode sets the display mode (always, when_in_context, never)
 	DisplayMode            options.Option = "display_mode"
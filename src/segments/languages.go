@@ -354,6 +354,22 @@ type ConfiguredLanguage struct {
 	Language
 }
 
+func init() {
+	options.Declare[ConfiguredLanguage](
+		options.Declaration{
+			Name:        LanguageName,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Unique identifier for the segment, used as its version cache key.",
+		},
+		options.Declaration{
+			Name:        Tools,
+			Type:        options.AnyType,
+			Description: "The executables that can report this language's version, tried in order until one succeeds.",
+		},
+	)
+}
+
 func (c *ConfiguredLanguage) Template() string {
 	return languageTemplate
 }
@@ -19,6 +19,41 @@ type LastFM struct {
 	Status string
 }
 
+func init() {
+	options.Declare[LastFM](
+		options.Declaration{
+			Name:        APIKey,
+			Type:        options.TemplateType,
+			Default:     ".",
+			Description: "The API key used for the API call (Required)",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+		options.Declaration{
+			Name:        PlayingIcon,
+			Type:        options.StringType,
+			Default:     "\uE602 ",
+			Description: "Text/icon to show when playing.",
+		},
+		options.Declaration{
+			Name:        StoppedIcon,
+			Type:        options.StringType,
+			Default:     "\uF04D ",
+			Description: "Text/icon to show when stopped.",
+		},
+		options.Declaration{
+			Name:        Username,
+			Type:        options.TemplateType,
+			Default:     ".",
+			Description: "The username used for the API call (Required)",
+		},
+	)
+}
+
 const (
 	Username options.Option = "username"
 )
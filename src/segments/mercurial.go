@@ -5,6 +5,7 @@ import (
 
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime/path"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 const (
@@ -42,6 +43,23 @@ type Mercurial struct {
 	IsTip     bool
 }
 
+func init() {
+	options.Declare[Mercurial](
+		options.Declaration{
+			Name:        FetchStatus,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Display the local changes or not.",
+		},
+		options.Declaration{
+			Name:        StatusFormats,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Override the status format for a specific change. Example: {\"Added\": \"Added: %d\"}.",
+		},
+	)
+}
+
 func (hg *Mercurial) Template() string {
 	return "hg {{.Branch}} {{if .LocalCommitNumber}}({{.LocalCommitNumber}}:{{.ChangeSetIDShort}}){{end}}{{range .Bookmarks }} \uf02e {{.}}{{end}}{{range .Tags}} \uf02b {{.}}{{end}}{{if .Working.Changed}} \uf044 {{ .Working.String }}{{ end }}" //nolint: lll
 }
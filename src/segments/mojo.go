@@ -11,6 +11,23 @@ type Mojo struct {
 	Language
 }
 
+func init() {
+	options.Declare[Mojo](
+		options.Declaration{
+			Name:        options.DisplayDefault,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Show the name of the virtualenv when it's default.",
+		},
+		options.Declaration{
+			Name:        FetchVirtualEnv,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Fetch the name of the virtualenv or not.",
+		},
+	)
+}
+
 func (m *Mojo) Template() string {
 	return " {{ if .Error }}{{ .Error }}{{ else }}{{ if .Venv }}{{ .Venv }} {{ end }}{{ .Full }}{{ end }} "
 }
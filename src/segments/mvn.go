@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Mvn struct {
 	Language
 }
 
+func init() {
+	options.Declare[Mvn]()
+}
+
 func (m *Mvn) Enabled() bool {
 	const mvnToolName = "mvn"
 
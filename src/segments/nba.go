@@ -16,6 +16,35 @@ type Nba struct {
 	NBAData
 }
 
+func init() {
+	options.Declare[Nba](
+		options.Declaration{
+			Name:        DaysOffset,
+			Type:        options.IntType,
+			Default:     8,
+			Description: "How many days in advance you wish to see game information for.",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+		options.Declaration{
+			Name:        NBASeason,
+			Type:        options.StringType,
+			Default:     currentNBASeason,
+			Description: "The season to fetch the games for.",
+		},
+		options.Declaration{
+			Name:        TeamName,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Tri-code for the NBA team you want to get data for.",
+		},
+	)
+}
+
 type NBAData struct {
 	HomeTeam       string
 	AwayTeam       string
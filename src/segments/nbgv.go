@@ -2,6 +2,8 @@ package segments
 
 import (
 	"encoding/json"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type Nbgv struct {
@@ -10,6 +12,10 @@ type Nbgv struct {
 	VersionInfo
 }
 
+func init() {
+	options.Declare[Nbgv]()
+}
+
 type VersionInfo struct {
 	Version                      string `json:"Version"`
 	AssemblyVersion              string `json:"AssemblyVersion"`
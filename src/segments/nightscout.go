@@ -17,6 +17,71 @@ type Nightscout struct {
 	NightscoutData
 }
 
+func init() {
+	options.Declare[Nightscout](
+		options.Declaration{
+			Name:        DoubleDownIcon,
+			Type:        options.StringType,
+			Default:     "↓↓",
+			Description: "Icon to display when the glucose level drops rapidly.",
+		},
+		options.Declaration{
+			Name:        DoubleUpIcon,
+			Type:        options.StringType,
+			Default:     "↑↑",
+			Description: "Icon to display when the glucose level rises rapidly.",
+		},
+		options.Declaration{
+			Name:        FlatIcon,
+			Type:        options.StringType,
+			Default:     "→",
+			Description: "Icon to display when the glucose level is stable.",
+		},
+		options.Declaration{
+			Name:        FortyFiveDownIcon,
+			Type:        options.StringType,
+			Default:     "↘",
+			Description: "Icon to display when the glucose level drops slowly.",
+		},
+		options.Declaration{
+			Name:        FortyFiveUpIcon,
+			Type:        options.StringType,
+			Default:     "↗",
+			Description: "Icon to display when the glucose level rises slowly.",
+		},
+		options.Declaration{
+			Name:        Headers,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "A key, value map of Headers to send with the request.",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+		options.Declaration{
+			Name:        SingleDownIcon,
+			Type:        options.StringType,
+			Default:     "↓",
+			Description: "Icon to display when the glucose level drops.",
+		},
+		options.Declaration{
+			Name:        SingleUpIcon,
+			Type:        options.StringType,
+			Default:     "↑",
+			Description: "Icon to display when the glucose level rises.",
+		},
+		options.Declaration{
+			Name:        URL,
+			Type:        options.TemplateType,
+			Default:     "",
+			Description: "The URL to the Nightscout API.",
+		},
+	)
+}
+
 const (
 	// Your complete Nightscout URL and APIKey like this
 	URL     options.Option = "url"
@@ -3,6 +3,8 @@ package segments
 import (
 	"path/filepath"
 	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 const (
@@ -15,6 +17,10 @@ type NixShell struct {
 	Type string
 }
 
+func init() {
+	options.Declare[NixShell]()
+}
+
 func (n *NixShell) Template() string {
 	return "via {{ .Type }}-shell"
 }
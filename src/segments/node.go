@@ -15,6 +15,41 @@ type Node struct {
 	Language
 }
 
+func init() {
+	options.Declare[Node](
+		options.Declaration{
+			Name:        BunIcon,
+			Type:        options.StringType,
+			Default:     "\ue76f",
+			Description: "Icon/text to use for Bun.",
+		},
+		options.Declaration{
+			Name:        FetchPackageManager,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Assigns the Yarn or NPM icon to .PackageManagerIcon.",
+		},
+		options.Declaration{
+			Name:        NPMIcon,
+			Type:        options.StringType,
+			Default:     "\ue71e",
+			Description: "Icon/text to use for NPM.",
+		},
+		options.Declaration{
+			Name:        PnpmIcon,
+			Type:        options.StringType,
+			Default:     "\ue865",
+			Description: "Icon/text to use for PNPM.",
+		},
+		options.Declaration{
+			Name:        YarnIcon,
+			Type:        options.StringType,
+			Default:     "\ue6a7",
+			Description: "Icon/text to use for Yarn.",
+		},
+	)
+}
+
 const (
 	PnpmIcon            options.Option = "pnpm_icon"
 	YarnIcon            options.Option = "yarn_icon"
@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Npm struct {
 	Language
 }
 
+func init() {
+	options.Declare[Npm]()
+}
+
 func (n *Npm) Enabled() bool {
 	n.extensions = []string{fileName, "package-lock.json"}
 	n.tooling = map[string]*cmd{
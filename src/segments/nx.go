@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Nx struct {
 	Language
 }
 
+func init() {
+	options.Declare[Nx]()
+}
+
 func (a *Nx) Template() string {
 	return languageTemplate
 }
@@ -0,0 +1,248 @@
+package options
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Type is the kind of value an option holds, named after the Provider method
+// reading it.
+type Type string
+
+const (
+	StringType      Type = "string"
+	TemplateType    Type = "template"
+	ColorType       Type = "color"
+	BoolType        Type = "bool"
+	IntType         Type = "int"
+	Float64Type     Type = "float64"
+	KeyValueMapType Type = "key_value_map"
+	StringArrayType Type = "string_array"
+	AnyType         Type = "any"
+)
+
+// Additional declares the options a segment reads by a name only known at
+// runtime, like the os segment's icon per distro: every option not declared
+// by name is checked against it instead of being unknown.
+const Additional Option = "*"
+
+// Declaration describes an option a segment accepts.
+type Declaration struct {
+	Default     any
+	Name        Option
+	Type        Type
+	Description string
+	// Values, when set, lists the only values the option accepts.
+	Values []string
+}
+
+// declarations is only written from init, so reading it needs no locking.
+var declarations = map[reflect.Type][]Declaration{}
+
+// Declare registers the options a segment writer reads, call it from the
+// init of the file defining the writer. A writer embedding another one (like
+// every language embeds Language) accepts the embedded writer's options too,
+// so only declare what the writer itself adds. Declaring no options at all is
+// meaningful: it marks every option set on the segment as unknown.
+func Declare[T any](options ...Declaration) {
+	declarations[reflect.TypeFor[T]()] = options
+}
+
+// Declarations returns the options a writer accepts, sorted by name with
+// Additional last, and whether it declared them at all.
+func Declarations(writer any) ([]Declaration, bool) {
+	if writer == nil {
+		return nil, false
+	}
+
+	byName := map[Option]Declaration{}
+
+	declared := collectDeclarations(reflect.TypeOf(writer), byName)
+	if !declared {
+		return nil, false
+	}
+
+	result := make([]Declaration, 0, len(byName))
+	for _, declaration := range byName {
+		result = append(result, declaration)
+	}
+
+	slices.SortFunc(result, func(a, b Declaration) int {
+		if a.Name == Additional || b.Name == Additional {
+			return boolCompare(a.Name == Additional, b.Name == Additional)
+		}
+
+		return strings.Compare(string(a.Name), string(b.Name))
+	})
+
+	return result, true
+}
+
+func boolCompare(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// collectDeclarations adds the declarations of t and of the types it
+// embeds, the outer type's declaration winning like a promoted field would.
+func collectDeclarations(t reflect.Type, byName map[Option]Declaration) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	own, declared := declarations[t]
+
+	for _, declaration := range own {
+		byName[declaration.Name] = declaration
+	}
+
+	if t.Kind() != reflect.Struct {
+		return declared
+	}
+
+	for field := range t.Fields() {
+		if !field.Anonymous {
+			continue
+		}
+
+		embedded := map[Option]Declaration{}
+		if !collectDeclarations(field.Type, embedded) {
+			continue
+		}
+
+		declared = true
+
+		for name, declaration := range embedded {
+			if _, ok := byName[name]; !ok {
+				byName[name] = declaration
+			}
+		}
+	}
+
+	return declared
+}
+
+// Validate checks every option in m against the declarations, returning an
+// error per option that isn't declared or holds a value the segment can't
+// read: the Provider methods fall back to the default for those, so the
+// value would be silently ignored.
+func Validate(declarations []Declaration, m Map) map[Option]error {
+	errs := map[Option]error{}
+
+	byName := make(map[Option]Declaration, len(declarations))
+	for _, declaration := range declarations {
+		byName[declaration.Name] = declaration
+	}
+
+	for name, value := range m {
+		declaration, ok := byName[name]
+		if !ok {
+			declaration, ok = byName[Additional]
+		}
+
+		if !ok {
+			errs[name] = fmt.Errorf("unknown option %s", name)
+			continue
+		}
+
+		if err := declaration.Check(value); err != nil {
+			errs[name] = fmt.Errorf("option %s: %w", name, err)
+		}
+	}
+
+	return errs
+}
+
+// Check reports whether the value is one the option's Provider method reads,
+// and one of its allowed values when it has any.
+func (d Declaration) Check(value any) error {
+	if !d.Type.accepts(value) {
+		return fmt.Errorf("expected %s, got %T", d.Type, value)
+	}
+
+	if len(d.Values) == 0 {
+		return nil
+	}
+
+	if slices.Contains(d.Values, fmt.Sprint(value)) {
+		return nil
+	}
+
+	return fmt.Errorf("expected one of %s, got %v", strings.Join(d.Values, ", "), value)
+}
+
+func (t Type) accepts(value any) bool {
+	switch t {
+	case StringType, TemplateType, ColorType:
+		switch value.(type) {
+		case string, bool, int, int64, uint64, float64:
+			return true
+		default:
+			return false
+		}
+	case BoolType:
+		_, ok := value.(bool)
+		return ok
+	case IntType, Float64Type:
+		switch value.(type) {
+		case int, int64, uint64, float64:
+			return true
+		default:
+			return false
+		}
+	case KeyValueMapType:
+		return isKeyValueMap(value)
+	case StringArrayType:
+		switch value.(type) {
+		case []any, []string:
+			return true
+		default:
+			return false
+		}
+	default:
+		return true
+	}
+}
+
+// isKeyValueMap mirrors parseKeyValueArray: maps of strings, or a list of
+// key/value pairs.
+func isKeyValueMap(value any) bool {
+	switch v := value.(type) {
+	case map[string]string:
+		return true
+	case map[string]any:
+		return stringValues(v)
+	case map[any]any:
+		return stringValues(v)
+	case Map:
+		return stringValues(v)
+	case []any:
+		for _, pair := range v {
+			if len(ParseStringArray(pair)) != 2 {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+func stringValues[K comparable](m map[K]any) bool {
+	for _, value := range m {
+		if _, ok := value.(string); !ok {
+			return false
+		}
+	}
+
+	return true
+}
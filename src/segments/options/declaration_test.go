@@ -0,0 +1,102 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type declaredBase struct{}
+
+type declaredWriter struct {
+	declaredBase
+}
+
+type undeclaredWriter struct{}
+
+const (
+	Depth   Option = "depth"
+	Enabled Option = "enabled"
+)
+
+func init() {
+	Declare[declaredBase](
+		Declaration{Name: Style, Type: StringType, Default: "full"},
+		Declaration{Name: Depth, Type: IntType, Default: 1},
+	)
+
+	Declare[declaredWriter](
+		Declaration{Name: Style, Type: StringType, Default: "folder", Values: []string{"folder", "full"}},
+		Declaration{Name: Enabled, Type: BoolType},
+	)
+}
+
+func TestDeclarations(t *testing.T) {
+	declarations, ok := Declarations(&declaredWriter{})
+	assert.True(t, ok)
+	assert.Equal(t, []Declaration{
+		{Name: Depth, Type: IntType, Default: 1},
+		{Name: Enabled, Type: BoolType},
+		{Name: Style, Type: StringType, Default: "folder", Values: []string{"folder", "full"}},
+	}, declarations, "embedded declarations are inherited, the outer one wins")
+
+	_, ok = Declarations(&undeclaredWriter{})
+	assert.False(t, ok)
+
+	_, ok = Declarations(nil)
+	assert.False(t, ok)
+}
+
+func TestValidate(t *testing.T) {
+	declarations := []Declaration{
+		{Name: Style, Type: StringType, Values: []string{"folder", "full"}},
+		{Name: Depth, Type: IntType},
+		{Name: Enabled, Type: BoolType},
+		{Name: "mapped", Type: KeyValueMapType},
+		{Name: Files, Type: StringArrayType},
+		{Name: "template", Type: TemplateType},
+		{Name: "color", Type: ColorType},
+	}
+
+	cases := []struct {
+		Options  Map
+		Case     string
+		Expected []Option
+	}{
+		{Case: "empty", Options: Map{}},
+		{Case: "valid", Options: Map{Style: "full", Depth: float64(2), Enabled: true}},
+		{Case: "unknown", Options: Map{"colour": "red"}, Expected: []Option{"colour"}},
+		{Case: "wrong type", Options: Map{Enabled: "yes", Depth: "two"}, Expected: []Option{Depth, Enabled}},
+		{Case: "not an allowed value", Options: Map{Style: "letter"}, Expected: []Option{Style}},
+		{Case: "scalar as string", Options: Map{"template": int64(3)}},
+		{Case: "scalar as color", Options: Map{"color": float64(1)}},
+		{Case: "list as color", Options: Map{"color": []any{"red"}}, Expected: []Option{"color"}},
+		{Case: "key value map", Options: Map{"mapped": map[string]any{"main": "m"}}},
+		{Case: "key value pairs", Options: Map{"mapped": []any{[]any{"main", "m"}}}},
+		{Case: "invalid key value map", Options: Map{"mapped": map[string]any{"main": 1}}, Expected: []Option{"mapped"}},
+		{Case: "string array", Options: Map{Files: []any{"go.mod"}}},
+		{Case: "string as string array", Options: Map{Files: "go.mod"}, Expected: []Option{Files}},
+	}
+
+	for _, tc := range cases {
+		errs := Validate(declarations, tc.Options)
+
+		var invalid []Option
+		for name := range errs {
+			invalid = append(invalid, name)
+		}
+
+		assert.ElementsMatch(t, tc.Expected, invalid, tc.Case)
+	}
+}
+
+func TestValidateAdditional(t *testing.T) {
+	declarations := []Declaration{
+		{Name: Style, Type: StringType},
+		{Name: Additional, Type: StringArrayType},
+	}
+
+	errs := Validate(declarations, Map{Style: "full", "node_files": []any{"package.json"}, "go_files": "go.mod"})
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs, Option("go_files"))
+}
@@ -193,6 +193,7 @@ func TestTemplate(t *testing.T) {
 	env.On("Platform").Return("ubuntu")
 	env.On("User").Return("testuser")
 	env.On("Host").Return("testhost", nil)
+	env.On("TerminalBackground").Return("")
 
 	// Initialize template package
 	template.Init(env, nil, nil)
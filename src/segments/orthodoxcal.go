@@ -15,6 +15,24 @@ type OrthodoxCal struct {
 	orthodoxCalResponse
 }
 
+func init() {
+	options.Declare[OrthodoxCal](
+		options.Declaration{
+			Name:        OrthodoxCalType,
+			Type:        options.StringType,
+			Default:     "gregorian",
+			Description: "Calendar type: gregorian or julian.",
+			Values:      []string{"gregorian", "julian"},
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+	)
+}
+
 const (
 	// Calendar type: "gregorian" (default) or "julian".
 	OrthodoxCalType options.Option = "calendar"
@@ -11,6 +11,46 @@ type Os struct {
 	Icon string
 }
 
+func init() {
+	options.Declare[Os](
+		options.Declaration{
+			Name:        Android,
+			Type:        options.StringType,
+			Default:     "\ue70e",
+			Description: "The icon to use for Android.",
+		},
+		options.Declaration{
+			Name:        DisplayDistroName,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Display the distro name or icon or not.",
+		},
+		options.Declaration{
+			Name:        Linux,
+			Type:        options.StringType,
+			Default:     "\uF17C",
+			Description: "Icon/text to use for Linux.",
+		},
+		options.Declaration{
+			Name:        MacOS,
+			Type:        options.StringType,
+			Default:     "\uF179",
+			Description: "Icon/text to use for macOS.",
+		},
+		options.Declaration{
+			Name:        Windows,
+			Type:        options.StringType,
+			Default:     "\uE62A",
+			Description: "Icon/text to use for Windows.",
+		},
+		options.Declaration{
+			Name:        options.Additional,
+			Type:        options.StringType,
+			Description: "The icon to display for a platform or Linux distribution, keyed by its name, like ubuntu or arch.",
+		},
+	)
+}
+
 const (
 	MacOS             options.Option = "macos"
 	Linux             options.Option = "linux"
@@ -21,6 +21,37 @@ type Owm struct {
 	Temperature int
 }
 
+func init() {
+	options.Declare[Owm](
+		options.Declaration{
+			Name:        APIKey,
+			Type:        options.TemplateType,
+			Default:     "",
+			Description: "The API key used for the API call. Required.",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+		options.Declaration{
+			Name:    Location,
+			Type:    options.TemplateType,
+			Default: "",
+			Description: "The location to use for the API call, only used if valid coordinates aren't given. Format as " +
+				"<City>,<STATE>,<COUNTRY_CODE>, using the ISO 3166 state or country code.",
+		},
+		options.Declaration{
+			Name:        Units,
+			Type:        options.StringType,
+			Default:     "standard",
+			Description: "Units of measurement. Available values are standard (kelvin), metric (celsius), and imperial (fahrenheit). Default is standard.",
+			Values:      []string{"standard", "metric", "imperial"},
+		},
+	)
+}
+
 const (
 	APIKey           options.Option = "api_key"
 	Location         options.Option = "location"
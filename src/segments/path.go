@@ -62,6 +62,152 @@ type Path struct {
 	cygPath         bool
 }
 
+func init() {
+	options.Declare[Path](
+		options.Declaration{
+			Name:        Cycle,
+			Type:        options.StringArrayType,
+			Default:     []string{},
+			Description: "Color overrides to use to cycle through and color the path per folder",
+		},
+		options.Declaration{
+			Name:        CycleFolderSeparator,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Colorize the folder_separator_icon as well when using a cycle.",
+		},
+		options.Declaration{
+			Name:        DirLength,
+			Type:        options.IntType,
+			Default:     1,
+			Description: "The length of the directory name to display in fish style.",
+		},
+		options.Declaration{
+			Name:        DisplayCygpath,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Display the Cygwin (Linux) style path using cygpath -u $PWD.",
+		},
+		options.Declaration{
+			Name:        DisplayRoot,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Display the root directory (/) on Unix systems.",
+		},
+		options.Declaration{
+			Name:        EdgeFormat,
+			Type:        options.StringType,
+			Description: "Golang string format to apply to the start and end folder.",
+		},
+		options.Declaration{
+			Name:        FolderFormat,
+			Type:        options.StringType,
+			Default:     "%s",
+			Description: "Golang string format to apply to the folder name.",
+		},
+		options.Declaration{
+			Name:        FolderIcon,
+			Type:        options.StringType,
+			Default:     "..",
+			Description: "The icon to use as a folder indication.",
+		},
+		options.Declaration{
+			Name:        FolderSeparatorIcon,
+			Type:        options.StringType,
+			Description: "The symbol to use as a separator between folders.",
+		},
+		options.Declaration{
+			Name:        FolderSeparatorTemplate,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "The path which is split will be separated by this template.",
+		},
+		options.Declaration{
+			Name:        FullLengthDirs,
+			Type:        options.IntType,
+			Default:     1,
+			Description: "Indicates how many full length directory names should be displayed in fish style.",
+		},
+		options.Declaration{
+			Name:        GitDirFormat,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Golang string format to apply to the .git folder.",
+		},
+		options.Declaration{
+			Name:        HideRootLocation,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Hides the root location, when using agnoster_short style, if it doesn't fit in the last max_depth folders.",
+		},
+		options.Declaration{
+			Name:        HomeIcon,
+			Type:        options.StringType,
+			Default:     "~",
+			Description: "The icon to display when at $HOME.",
+		},
+		options.Declaration{
+			Name:        LeftFormat,
+			Type:        options.StringType,
+			Description: "Will default to whatever edge_format is set to.",
+		},
+		options.Declaration{
+			Name:        MappedLocations,
+			Type:        options.KeyValueMapType,
+			Description: "Custom glyph/text for specific paths.",
+		},
+		options.Declaration{
+			Name:        MappedLocationsEnabled,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Replace known locations in the path with the replacements before applying the style.",
+		},
+		options.Declaration{
+			Name:    MappedLocationsRegexExpand,
+			Type:    options.BoolType,
+			Default: false,
+			Description: "When a mapped_locations key uses the re: prefix, expand $1, ${name}, etc. in the replacement value using the full regex " +
+				"match instead of only substituting the first capture group.",
+		},
+		options.Declaration{
+			Name:        MaxDepth,
+			Type:        options.IntType,
+			Default:     1,
+			Description: "Maximum path depth to display without shortening.",
+		},
+		options.Declaration{
+			Name:        MaxWidth,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Maximum path width to display for powerlevel style.",
+		},
+		options.Declaration{
+			Name:        MixedThreshold,
+			Type:        options.Float64Type,
+			Default:     4,
+			Description: "The maximum length of a path segment that will be displayed when using mixed style.",
+		},
+		options.Declaration{
+			Name:        RightFormat,
+			Type:        options.StringType,
+			Description: "Will default to whatever edge_format is set to.",
+		},
+		options.Declaration{
+			Name:        options.Style,
+			Type:        options.StringType,
+			Default:     Agnoster,
+			Description: "How to display the current path.",
+			Values:      []string{"agnoster", "agnoster_full", "agnoster_short", "agnoster_left", "short", "full", "folder", "mixed", "letter", "unique", "powerlevel", "fish"},
+		},
+		options.Declaration{
+			Name:        WindowsRegistryIcon,
+			Type:        options.StringType,
+			Default:     "\uF013",
+			Description: "The icon to display when in the Windows registry.",
+		},
+	)
+}
+
 const (
 	FolderSeparatorIcon     options.Option = "folder_separator_icon"
 	FolderSeparatorTemplate options.Option = "folder_separator_template"
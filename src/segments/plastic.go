@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/regex"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type PlasticStatus struct {
@@ -34,6 +35,41 @@ type Plastic struct {
 	MergePending bool
 }
 
+func init() {
+	options.Declare[Plastic](
+		options.Declaration{
+			Name:        BranchIcon,
+			Type:        options.StringType,
+			Default:     "\uE0A0",
+			Description: "The icon to use in front of the selector branch name.",
+		},
+		options.Declaration{
+			Name:        CommitIcon,
+			Type:        options.StringType,
+			Default:     "\uF417",
+			Description: "Icon/text to display before the selector changeset.",
+		},
+		options.Declaration{
+			Name:        FetchStatus,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Display the local changes or not.",
+		},
+		options.Declaration{
+			Name:        StatusFormats,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Override the status format for a specific change. Example: {\"Added\": \"Added: %d\"}.",
+		},
+		options.Declaration{
+			Name:        TagIcon,
+			Type:        options.StringType,
+			Default:     "\uF412",
+			Description: "Icon/text to display before the selector label.",
+		},
+	)
+}
+
 func (p *Plastic) Template() string {
 	return " {{ .Selector }} "
 }
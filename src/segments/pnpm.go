@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Pnpm struct {
 	Language
 }
 
+func init() {
+	options.Declare[Pnpm]()
+}
+
 func (n *Pnpm) Enabled() bool {
 	n.extensions = []string{fileName, "pnpm-lock.yaml"}
 	n.tooling = map[string]*cmd{
@@ -70,6 +70,39 @@ type Project struct {
 	projects []*ProjectItem
 }
 
+func init() {
+	options.Declare[Project](
+		options.Declaration{
+			Name:        options.AlwaysEnabled,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Always show the segment.",
+		},
+		options.Declaration{
+			Name:        Priority,
+			Type:        options.StringArrayType,
+			Description: "Reorder the project types to check first, using the .Type values (e.g. node, php, cargo)",
+		},
+		options.Declaration{
+			Name:        ResolveTargetFromSolution,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "When a .sln/.slnx is matched, scan subdirectories for project files to extract TargetFramework.",
+		},
+		options.Declaration{
+			Name:        SolutionSearchDepth,
+			Type:        options.IntType,
+			Default:     2,
+			Description: "Maximum directory depth to scan for project files when resolving from a solution.",
+		},
+		options.Declaration{
+			Name:        options.Additional,
+			Type:        options.StringArrayType,
+			Description: "The files to look for per project type, keyed as <type>_files, like node_files.",
+		},
+	)
+}
+
 func (n *Project) Enabled() bool {
 	n.projects = []*ProjectItem{
 		{
@@ -36,6 +36,23 @@ type Pulumi struct {
 	Backend
 }
 
+func init() {
+	options.Declare[Pulumi](
+		options.Declaration{
+			Name:        FetchAbout,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Fetch the URL and user for the current stack.",
+		},
+		options.Declaration{
+			Name:        FetchStack,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Fetch the current pulumi stack or not.",
+		},
+	)
+}
+
 type Backend struct {
 	URL  string `json:"url"`
 	User string `json:"user"`
@@ -17,6 +17,38 @@ type Python struct {
 	Language
 }
 
+func init() {
+	options.Declare[Python](
+		options.Declaration{
+			Name: DefaultVenvNames,
+			Type: options.StringArrayType,
+			Default: []string{
+				".venv",
+				"venv",
+			},
+			Description: "Names to replace when folder_name_fallback is true.",
+		},
+		options.Declaration{
+			Name:        options.DisplayDefault,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Show the name of the virtualenv when it's default.",
+		},
+		options.Declaration{
+			Name:        FetchVirtualEnv,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Fetch the name of the virtualenv or not.",
+		},
+		options.Declaration{
+			Name:        FolderNameFallback,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Replace virtual environment names in default_venv_names list with parent folder name.",
+		},
+	)
+}
+
 const (
 	FetchVirtualEnv      options.Option = "fetch_virtual_env"
 	UsePythonVersionFile options.Option = "use_python_version_file"
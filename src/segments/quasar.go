@@ -23,6 +23,17 @@ type Quasar struct {
 	HasVite bool
 }
 
+func init() {
+	options.Declare[Quasar](
+		options.Declaration{
+			Name:        FetchDependencies,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Fetch the vite and @quasar/app-vite dependency information or not.",
+		},
+	)
+}
+
 func (q *Quasar) Enabled() bool {
 	const quasarToolName = "quasar"
 
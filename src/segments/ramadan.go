@@ -26,6 +26,65 @@ type Ramadan struct {
 	Fasting       bool
 }
 
+func init() {
+	options.Declare[Ramadan](
+		options.Declaration{
+			Name:        RamadanCity,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "City name for location lookup (takes precedence over latitude/longitude)",
+		},
+		options.Declaration{
+			Name:        RamadanCountry,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Country name or ISO 3166 alpha-2 code (required when using city)",
+		},
+		options.Declaration{
+			Name:        RamadanFirstRozaDate,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Override first day of Ramadan as YYYY-MM-DD for local moon sighting.",
+		},
+		options.Declaration{
+			Name:        RamadanHideOutside,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Hide the segment when not in Ramadan.",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for HTTP request timeouts.",
+		},
+		options.Declaration{
+			Name:        RamadanLatitude,
+			Type:        options.Float64Type,
+			Default:     0,
+			Description: "Latitude for prayer time calculation.",
+		},
+		options.Declaration{
+			Name:        RamadanLongitude,
+			Type:        options.Float64Type,
+			Default:     0,
+			Description: "Longitude for prayer time calculation.",
+		},
+		options.Declaration{
+			Name:        RamadanMethod,
+			Type:        options.IntType,
+			Default:     3,
+			Description: "The prayer time calculation method, identified by a numeric ID from 0 to 23. Each ID corresponds to a different regional authority's calculation rules.",
+		},
+		options.Declaration{
+			Name:        RamadanSchool,
+			Type:        options.IntType,
+			Default:     0,
+			Description: "0 = Shafi (standard), 1 = Hanafi.",
+		},
+	)
+}
+
 const (
 	RamadanLatitude  options.Option = "latitude"
 	RamadanLongitude options.Option = "longitude"
@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type React struct {
 	Language
 }
 
+func init() {
+	options.Declare[React]()
+}
+
 func (r *React) Template() string {
 	return languageTemplate
 }
@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Root struct {
 	Base
 }
 
+func init() {
+	options.Declare[Root]()
+}
+
 func (rt *Root) Template() string {
 	return " \uF0E7 "
 }
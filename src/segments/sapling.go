@@ -5,6 +5,7 @@ import (
 
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime/path"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type SaplingStatus struct {
@@ -55,6 +56,23 @@ type Sapling struct {
 	New bool
 }
 
+func init() {
+	options.Declare[Sapling](
+		options.Declaration{
+			Name:        FetchStatus,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Display the local changes or not.",
+		},
+		options.Declaration{
+			Name:        StatusFormats,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Override the status format for a specific change. Example: {\"Added\": \"Added: %d\"}.",
+		},
+	)
+}
+
 func (sl *Sapling) Template() string {
 	return " {{ if .Bookmark }}\uf097 {{ .Bookmark }}*{{ else }}\ue729 {{ .ShortHash }}{{ end }}{{ if .Working.Changed }} \uf044 {{ .Working.String }}{{ end }} "
 }
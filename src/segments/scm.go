@@ -95,6 +95,28 @@ type Scm struct {
 	nativeFallback  bool
 }
 
+func init() {
+	options.Declare[Scm](
+		options.Declaration{
+			Name:        BranchTemplate,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "The template used for the branch name, supports {{ .Branch }} for the branch name.",
+		},
+		options.Declaration{
+			Name:        MappedBranches,
+			Type:        options.KeyValueMapType,
+			Description: "Custom glyph/text for specific branches.",
+		},
+		options.Declaration{
+			Name:        NativeFallback,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Try to use the WSL 2 native command in a shared Windows drive if the Windows executable is not found.",
+		},
+	)
+}
+
 const (
 	BranchTemplate options.Option = "branch_template"
 )
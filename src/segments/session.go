@@ -3,6 +3,7 @@ package segments
 import (
 	"github.com/jandedobbeleer/oh-my-posh/src/regex"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type Session struct {
@@ -11,6 +12,10 @@ type Session struct {
 	SSHSession bool
 }
 
+func init() {
+	options.Declare[Session]()
+}
+
 func (s *Session) Enabled() bool {
 	s.SSHSession = s.activeSSHSession()
 	return true
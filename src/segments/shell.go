@@ -13,6 +13,16 @@ type Shell struct {
 	Version string
 }
 
+func init() {
+	options.Declare[Shell](
+		options.Declaration{
+			Name:        MappedShellNames,
+			Type:        options.KeyValueMapType,
+			Description: "Mapping of shell identifiers to the glyph/string used for that shell (e.g. bash, zsh, pwsh).",
+		},
+	)
+}
+
 const (
 	MappedShellNames options.Option = "mapped_shell_names"
 )
@@ -21,6 +21,17 @@ type Sitecore struct {
 	CmHost       string
 }
 
+func init() {
+	options.Declare[Sitecore](
+		options.Declaration{
+			Name:        options.DisplayDefault,
+			Type:        options.BoolType,
+			Default:     true,
+			Description: "Display the segment or not when the Sitecore environment name matches `default`",
+		},
+	)
+}
+
 type EndpointConfig struct {
 	Host string `json:"host"`
 }
@@ -8,6 +8,35 @@ type Spotify struct {
 	MusicPlayer
 }
 
+func init() {
+	options.Declare[Spotify](
+		options.Declaration{
+			Name:        AdIcon,
+			Type:        options.StringType,
+			Default:     " ",
+			Description: "Text/icon to show when an advertisement is playing (Windows/WSL)",
+		},
+		options.Declaration{
+			Name:        PausedIcon,
+			Type:        options.StringType,
+			Default:     " ",
+			Description: "Text/icon to show when paused.",
+		},
+		options.Declaration{
+			Name:        PlayingIcon,
+			Type:        options.StringType,
+			Default:     " ",
+			Description: "Text/icon to show when playing.",
+		},
+		options.Declaration{
+			Name:        StoppedIcon,
+			Type:        options.StringType,
+			Default:     " ",
+			Description: "Text/icon to show when stopped.",
+		},
+	)
+}
+
 type MusicPlayer struct {
 	Status string
 	Artist string
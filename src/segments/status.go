@@ -22,6 +22,29 @@ type Status struct {
 	Error   bool
 }
 
+func init() {
+	options.Declare[Status](
+		options.Declaration{
+			Name:        options.AlwaysEnabled,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Always show the status.",
+		},
+		options.Declaration{
+			Name:        StatusSeparator,
+			Type:        options.StringType,
+			Default:     "|",
+			Description: "The separator to use between the status segments.",
+		},
+		options.Declaration{
+			Name:        StatusTemplate,
+			Type:        options.StringType,
+			Default:     "{{ .Code }}",
+			Description: "The template to use for the status segment.",
+		},
+	)
+}
+
 func (s *Status) Template() string {
 	return " {{ .String }} "
 }
@@ -35,6 +35,59 @@ type Strava struct {
 	Authenticate bool
 }
 
+func init() {
+	options.Declare[Strava](
+		options.Declaration{
+			Name:        options.AccessToken,
+			Type:        options.TemplateType,
+			Default:     "",
+			Description: "The initial access token.",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+		options.Declaration{
+			Name:        options.RefreshToken,
+			Type:        options.TemplateType,
+			Default:     "",
+			Description: "The initial refresh token.",
+		},
+		options.Declaration{
+			Name:        RideIcon,
+			Type:        options.StringType,
+			Default:     "\uf206",
+			Description: "Alternative icon for this activity type.",
+		},
+		options.Declaration{
+			Name:        RunIcon,
+			Type:        options.StringType,
+			Default:     "\ue213",
+			Description: "Alternative icon for this activity type.",
+		},
+		options.Declaration{
+			Name:        SkiingIcon,
+			Type:        options.StringType,
+			Default:     "\ue213",
+			Description: "Alternative icon for this activity type.",
+		},
+		options.Declaration{
+			Name:        UnknownActivityIcon,
+			Type:        options.StringType,
+			Default:     "\ue213",
+			Description: "Fallback icon for other activity types.",
+		},
+		options.Declaration{
+			Name:        WorkOutIcon,
+			Type:        options.StringType,
+			Default:     "\ue213",
+			Description: "Alternative icon for this activity type.",
+		},
+	)
+}
+
 const (
 	RideIcon            options.Option = "ride_icon"
 	RunIcon             options.Option = "run_icon"
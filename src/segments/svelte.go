@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Svelte struct {
 	Language
 }
 
+func init() {
+	options.Declare[Svelte]()
+}
+
 func (s *Svelte) Template() string {
 	return languageTemplate
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/regex"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type SvnStatus struct {
@@ -44,6 +45,23 @@ type Svn struct {
 	BaseRev int
 }
 
+func init() {
+	options.Declare[Svn](
+		options.Declaration{
+			Name:        FetchStatus,
+			Type:        options.BoolType,
+			Default:     false,
+			Description: "Display the local changes or not.",
+		},
+		options.Declaration{
+			Name:        StatusFormats,
+			Type:        options.KeyValueMapType,
+			Default:     map[string]string{},
+			Description: "Override the status format for a specific change. Example: {\"Added\": \"Added: %d\"}.",
+		},
+	)
+}
+
 func (s *Svn) Template() string {
 	return " \ue0a0{{.Branch}} r{{.BaseRev}} {{.Working.String}} "
 }
@@ -12,6 +12,17 @@ type SystemInfo struct {
 	Precision int
 }
 
+func init() {
+	options.Declare[SystemInfo](
+		options.Declaration{
+			Name:        Precision,
+			Type:        options.IntType,
+			Default:     2,
+			Description: "Number of decimal places to show.",
+		},
+	)
+}
+
 const (
 	Precision options.Option = "precision"
 )
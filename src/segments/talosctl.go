@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 	yaml "go.yaml.in/yaml/v3"
 )
 
@@ -14,6 +15,10 @@ type TalosCTL struct {
 	Context string `yaml:"context"`
 }
 
+func init() {
+	options.Declare[TalosCTL]()
+}
+
 func (t *TalosCTL) Template() string {
 	return " {{ .Context}} "
 }
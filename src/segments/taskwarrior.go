@@ -20,6 +20,22 @@ type Taskwarrior struct {
 	Commands map[string]string
 }
 
+func init() {
+	options.Declare[Taskwarrior](
+		options.Declaration{
+			Name:        TaskwarriorCommand,
+			Type:        options.StringType,
+			Default:     "task",
+			Description: "The taskwarrior command to use.",
+		},
+		options.Declaration{
+			Name:        TaskwarriorCommands,
+			Type:        options.KeyValueMapType,
+			Description: "Map of name to Taskwarrior arguments; the trimmed stdout of each invocation is exposed as .Commands.<Name> in the template.",
+		},
+	)
+}
+
 func (t *Taskwarrior) Template() string {
 	return " \uf4a0 {{ range $k, $v := .Commands }}{{ $k }}:{{ $v }} {{ end }}"
 }
@@ -2,12 +2,18 @@ package segments
 
 import (
 	"path/filepath"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type Tauri struct {
 	Language
 }
 
+func init() {
+	options.Declare[Tauri]()
+}
+
 func (t *Tauri) Template() string {
 	return languageTemplate
 }
@@ -21,6 +21,24 @@ type Terraform struct {
 	WorkspaceName string
 }
 
+func init() {
+	options.Declare[Terraform](
+		options.Declaration{
+			Name:        Command,
+			Type:        options.StringType,
+			Default:     "terraform",
+			Description: "The terraform command to use.",
+		},
+		options.Declaration{
+			Name:    options.FetchVersion,
+			Type:    options.BoolType,
+			Default: false,
+			Description: "Fetch the version number from tenv (TFENV_TERRAFORM_VERSION / TOFUENV_TOFU_VERSION or .terraform-version / " +
+				".opentofu-version), versions.tf, main.tf or terraform.tfstate.",
+		},
+	)
+}
+
 func (tf *Terraform) Template() string {
 	return " {{ .WorkspaceName }}{{ if .Version }} {{ .Version }}{{ end }} "
 }
@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Text struct {
 	Base
 }
 
+func init() {
+	options.Declare[Text]()
+}
+
 func (t *Text) Template() string {
 	return "  "
 }
@@ -13,6 +13,17 @@ type Time struct {
 	Format      string
 }
 
+func init() {
+	options.Declare[Time](
+		options.Declaration{
+			Name:        TimeFormat,
+			Type:        options.StringType,
+			Default:     "15:04:05",
+			Description: "The Go time layout string used to format the time, such as 15:04:05 or Mon Jan 2 15:04:05 2006.",
+		},
+	)
+}
+
 const (
 	// TimeFormat uses the reference time Mon Jan 2 15:04:05 MST 2006 to show the pattern with which to format the current time
 	TimeFormat options.Option = "time_format"
@@ -14,6 +14,23 @@ type Todoist struct {
 	TaskCount int
 }
 
+func init() {
+	options.Declare[Todoist](
+		options.Declaration{
+			Name:        APIKey,
+			Type:        options.TemplateType,
+			Default:     ".",
+			Description: "API Key (Required)",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+	)
+}
+
 type TasksResponse struct {
 	Results []Task `json:"results"`
 }
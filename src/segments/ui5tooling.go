@@ -1,5 +1,7 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 const UI5ToolingYamlPattern = "*ui5*.y*ml"
 
 type UI5Tooling struct {
@@ -7,6 +9,10 @@ type UI5Tooling struct {
 	HasUI5YamlInParentDir bool
 }
 
+func init() {
+	options.Declare[UI5Tooling]()
+}
+
 func (u *UI5Tooling) Template() string {
 	return languageTemplate
 }
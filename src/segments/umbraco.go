@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type Umbraco struct {
@@ -15,6 +16,10 @@ type Umbraco struct {
 	Modern  bool
 }
 
+func init() {
+	options.Declare[Umbraco]()
+}
+
 type CSProj struct {
 	PackageReferences []struct {
 		Name    string `xml:"include,attr"`
@@ -18,6 +18,17 @@ type Unity struct {
 	CSharpVersion string
 }
 
+func init() {
+	options.Declare[Unity](
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     2000,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+	)
+}
+
 func (u *Unity) Template() string {
 	return " \ue721 {{ .UnityVersion }}{{ if .CSharpVersion }} {{ .CSharpVersion }}{{ end }} "
 }
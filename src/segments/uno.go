@@ -3,6 +3,7 @@ package segments
 import (
 	"encoding/json"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 	"github.com/jandedobbeleer/oh-my-posh/src/text"
 )
 
@@ -16,6 +17,10 @@ type Uno struct {
 	Version string
 }
 
+func init() {
+	options.Declare[Uno]()
+}
+
 func (u *Uno) Template() string {
 	return " {{ .Version }} "
 }
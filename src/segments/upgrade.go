@@ -23,6 +23,23 @@ type Upgrade struct {
 	UpgradeCache
 }
 
+func init() {
+	options.Declare[Upgrade](
+		options.Declaration{
+			Name:        options.CacheDuration,
+			Type:        options.StringType,
+			Default:     "168h",
+			Description: "The duration for which the segment is cached, parsed using Go's time.ParseDuration format (e.g. 5m, 1h30m).",
+		},
+		options.Declaration{
+			Name:        Source,
+			Type:        options.StringType,
+			Default:     "cdn",
+			Description: "The source to check for a new version, cdn or github.",
+		},
+	)
+}
+
 const (
 	UPGRADECACHEKEY = "upgrade_segment"
 )
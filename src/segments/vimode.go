@@ -1,5 +1,7 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 const (
 	poshVIModeEnv = "POSH_VI_MODE"
 )
@@ -11,20 +13,31 @@ type VIMode struct {
 	Keymap string
 }
 
+func init() {
+	options.Declare[VIMode]()
+}
+
 func (v *VIMode) Template() string {
 	return " {{ .Mode }} "
 }
 
 func (v *VIMode) Enabled() bool {
-	v.Keymap = v.env.Getenv(poshVIModeEnv)
-	if v.Keymap == "" {
+	keymap := v.env.Getenv(poshVIModeEnv)
+	if keymap == "" {
 		return false
 	}
 
-	v.Mode = mapVIModeKeymap(v.Keymap)
+	v.SetKeymap(keymap)
 	return true
 }
 
+// SetKeymap switches the segment to keymap, the way it reads once the shell
+// reports that mode.
+func (v *VIMode) SetKeymap(keymap string) {
+	v.Keymap = keymap
+	v.Mode = mapVIModeKeymap(keymap)
+}
+
 func mapVIModeKeymap(keymap string) string {
 	switch keymap {
 	case "main", "viins", "emacs":
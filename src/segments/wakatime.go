@@ -12,6 +12,23 @@ type Wakatime struct {
 	WtData
 }
 
+func init() {
+	options.Declare[Wakatime](
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+		options.Declaration{
+			Name:        URL,
+			Type:        options.TemplateType,
+			Default:     "",
+			Description: "The URL used for the API call. Required.",
+		},
+	)
+}
+
 type wtTotals struct {
 	Text    string  `json:"text"`
 	Seconds float64 `json:"seconds"`
@@ -5,6 +5,7 @@ import (
 	"unicode"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments/options"
 )
 
 type WinGet struct {
@@ -14,6 +15,10 @@ type WinGet struct {
 	UpdateCount int
 }
 
+func init() {
+	options.Declare[WinGet]()
+}
+
 type WinGetPackage struct {
 	Name      string
 	ID        string
@@ -11,6 +11,23 @@ type WindowsRegistry struct {
 	Value string
 }
 
+func init() {
+	options.Declare[WindowsRegistry](
+		options.Declaration{
+			Name:        Fallback,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "Value to display if registry value cannot be retrieved.",
+		},
+		options.Declaration{
+			Name:        RegistryPath,
+			Type:        options.StringType,
+			Default:     "",
+			Description: "The path to the registry key (case insensitive, must use backslashes).  Ending with \\ will retrieve \"(Default)\" key in that path.",
+		},
+	)
+}
+
 const (
 	// full path to the key; if ends in \, gets "(Default)" key in that path
 	RegistryPath options.Option = "path"
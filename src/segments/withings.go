@@ -143,6 +143,29 @@ type Withings struct {
 	Steps      int
 }
 
+func init() {
+	options.Declare[Withings](
+		options.Declaration{
+			Name:        options.AccessToken,
+			Type:        options.TemplateType,
+			Default:     "",
+			Description: "The initial access token.",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     options.DefaultHTTPTimeout,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+		options.Declaration{
+			Name:        options.RefreshToken,
+			Type:        options.TemplateType,
+			Default:     "",
+			Description: "The initial refresh token.",
+		},
+	)
+}
+
 const (
 	WithingsAccessTokenKey  = "withings_access_token"
 	WithingsRefreshTokenKey = "withings_refresh_token"
@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type XMake struct {
 	Language
 }
 
+func init() {
+	options.Declare[XMake]()
+}
+
 func (x *XMake) Template() string {
 	return languageTemplate
 }
@@ -1,9 +1,15 @@
 package segments
 
+import "github.com/jandedobbeleer/oh-my-posh/src/segments/options"
+
 type Yarn struct {
 	Language
 }
 
+func init() {
+	options.Declare[Yarn]()
+}
+
 func (n *Yarn) Template() string {
 	return " \ue6a7 {{.Full}} "
 }
@@ -21,6 +21,41 @@ type Ytm struct {
 	MusicPlayer
 }
 
+func init() {
+	options.Declare[Ytm](
+		options.Declaration{
+			Name:        AdIcon,
+			Type:        options.StringType,
+			Default:     "\ueebb ",
+			Description: "Text/icon to show when an advertisement is playing.",
+		},
+		options.Declaration{
+			Name:        options.HTTPTimeout,
+			Type:        options.IntType,
+			Default:     5000,
+			Description: "Milliseconds to use for http request timeouts.",
+		},
+		options.Declaration{
+			Name:        PausedIcon,
+			Type:        options.StringType,
+			Default:     "\uf04c ",
+			Description: "Text/icon to show when paused.",
+		},
+		options.Declaration{
+			Name:        PlayingIcon,
+			Type:        options.StringType,
+			Default:     "\uf04b ",
+			Description: "Text/icon to show when playing.",
+		},
+		options.Declaration{
+			Name:        StoppedIcon,
+			Type:        options.StringType,
+			Default:     "\uf04d ",
+			Description: "Text/icon to show when stopped.",
+		},
+	)
+}
+
 func (y *Ytm) Template() string {
 	return " {{ .Icon }}{{ if ne .Status \"stopped\" }}{{ .Artist }} - {{ .Track }}{{ end }} "
 }
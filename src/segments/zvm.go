@@ -15,6 +15,17 @@ type Zvm struct {
 	ZigIcon string
 }
 
+func init() {
+	options.Declare[Zvm](
+		options.Declaration{
+			Name:        ZigIcon,
+			Type:        options.StringType,
+			Default:     "ZVM",
+			Description: "Icon to display before the version.",
+		},
+	)
+}
+
 func (z *Zvm) Template() string {
 	return " {{ if .ZigIcon }}{{ .ZigIcon }} {{ end }}{{ .Version }} "
 }
@@ -19,7 +19,10 @@ func (f Features) Bash() Code {
 		return unixUpgrade
 	case Notice:
 		return unixNotice
+	case Background:
+		return unixBackground
 	case RPrompt:
+		// plain bash gets the right prompt as part of PS1
 		if !bashBLEsession {
 			return ""
 		}
@@ -50,7 +53,17 @@ bleopt prompt_ps1_final='$(
         --shell-version="$BASH_VERSION" \
         --escape=false
 )'`
-	case PromptMark, PoshGit, Azure, LineError, Jobs, Tooltips, Async, Streaming, KeyHandlers, VIMode:
+	case Streaming:
+		// without ble.sh there's no way to consume records while readline is
+		// active, the serve daemon renders every prompt in wait mode instead
+		if !bashBLEsession {
+			return ""
+		}
+
+		return "_omp_enable_streaming=1"
+	case VIMode:
+		return "_omp_enable_vimode"
+	case PromptMark, PoshGit, Azure, LineError, Jobs, Tooltips, Async, KeyHandlers:
 		fallthrough
 	default:
 		return ""
@@ -14,7 +14,9 @@ func TestBashFeatures(t *testing.T) {
 _omp_ftcs_marks=1
 "$_omp_executable" upgrade --auto
 "$_omp_executable" notice
-_omp_cursor_positioning=1`
+_omp_cursor_positioning=1
+_omp_enable_vimode
+"$_omp_executable" get background >/dev/null`
 
 	assert.Equal(t, want, got)
 }
@@ -49,7 +51,10 @@ bleopt prompt_rps1='$(
 		--terminal-width="${COLUMNS-0}" \
 		--escape=false
 )'
-_omp_cursor_positioning=1`
+_omp_cursor_positioning=1
+_omp_enable_streaming=1
+_omp_enable_vimode
+"$_omp_executable" get background >/dev/null`
 
 	assert.Equal(t, want, got)
 
@@ -69,3 +74,19 @@ func TestQuotePosixStr(t *testing.T) {
 		assert.Equal(t, tc.expected, QuotePosixStr(tc.str), fmt.Sprintf("QuotePosixStr: %s", tc.str))
 	}
 }
+
+func TestBashNonPrinting(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Text     string
+		Expected string
+	}{
+		{Case: "plain text", Text: "hello", Expected: `\[hello\]`},
+		{Case: "nested markers", Text: "\\[\x1b7\\]right\\[\x1b8\\]", Expected: "\\[\x1b7right\x1b8\\]"},
+		{Case: "escaped backslash", Text: `C:\\[x]`, Expected: `\[C:\\[x]\]`},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.Expected, BashNonPrinting(tc.Text), tc.Case)
+	}
+}
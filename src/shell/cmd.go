@@ -34,7 +34,8 @@ else
 end`
 	case Streaming:
 		return "serve_enabled = true"
-	case PromptMark, PoshGit, Azure, LineError, Jobs, CursorPositioning, Async, KeyHandlers, VIMode:
+	// Windows has no terminal to ask, see runtime.queryTerminal
+	case PromptMark, PoshGit, Azure, LineError, Jobs, CursorPositioning, Async, KeyHandlers, VIMode, Background:
 		fallthrough
 	default:
 		return ""
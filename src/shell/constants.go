@@ -11,6 +11,9 @@ const (
 	ELVISH     = "elvish"
 	XONSH      = "xonsh"
 	YASH       = "yash"
+	OSH        = "osh"
+	YSH        = "ysh"
+	TCSH       = "tcsh"
 	CLAUDE     = "claude"
 	COPILOTCLI = "copilot-cli"
 )
@@ -17,13 +17,29 @@ func quoteElvishStr(str string) string {
 	return fmt.Sprintf("'%s'", strings.ReplaceAll(str, "'", "''"))
 }
 
+// quoteElvishMark quotes a shell integration mark as a double quoted string,
+// spelling out its control characters as escape sequences.
+func quoteElvishMark(mark string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\x1b", `\e`, "\a", `\a`).Replace(mark) + `"`
+}
+
 func (f Features) Elvish() Code {
 	switch f {
 	case Upgrade:
 		return "$_omp_executable upgrade --auto"
 	case Notice:
 		return "$_omp_executable notice"
-	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, CursorPositioning, Tooltips, Transient, FTCSMarks, Async, Streaming, KeyHandlers, VIMode:
+	case Background:
+		return "nop ($_omp_executable get background)"
+	case FTCSMarks:
+		finished := strings.SplitN(CommandFinishedMark, "%s", 2)
+		return Code(fmt.Sprintf("_omp_enable_ftcs_marks %s %s %s",
+			quoteElvishMark(PromptStartMark),
+			quoteElvishMark(finished[0]),
+			quoteElvishMark(finished[1])))
+	case VIMode:
+		return "_omp_enable_vimode"
+	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, CursorPositioning, Tooltips, Transient, Async, Streaming, KeyHandlers:
 		fallthrough
 	default:
 		return ""
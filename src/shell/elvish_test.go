@@ -11,8 +11,11 @@ func TestElvishFeatures(t *testing.T) {
 	got := allFeatures.Lines(ELVISH).String("// these are the features")
 
 	want := `// these are the features
+_omp_enable_ftcs_marks "\e]133;A\a" "\e]133;D" "\a"
 $_omp_executable upgrade --auto
-$_omp_executable notice`
+$_omp_executable notice
+_omp_enable_vimode
+nop ($_omp_executable get background)`
 
 	assert.Equal(t, want, got)
 }
@@ -1,6 +1,9 @@
 package shell
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 type Features uint
 
@@ -22,6 +25,7 @@ const (
 	KeyHandlers
 	VIMode
 	TransientRPrompt
+	Background
 )
 
 func getAllFeatures() []Features {
@@ -32,7 +36,7 @@ func getAllFeatures() []Features {
 		feature := Features(1 << i)
 
 		// Stop when we reach a power of 2 greater than our highest defined feature
-		if feature > TransientRPrompt {
+		if feature > Background {
 			break
 		}
 
@@ -78,6 +82,12 @@ func (f Features) Lines(shell string) Lines {
 			code = feature.Xonsh()
 		case YASH:
 			code = feature.Yash()
+		case OSH:
+			code = feature.Osh()
+		case YSH:
+			code = feature.Ysh()
+		case TCSH:
+			code = feature.Tcsh()
 		}
 
 		if len(code) > 0 {
@@ -91,3 +101,52 @@ func (f Features) Lines(shell string) Lines {
 func (f Features) String() string {
 	return fmt.Sprintf("%b", uint(f))
 }
+
+var featureNames = map[Features]string{
+	Jobs:              "jobs",
+	Azure:             "azure",
+	PoshGit:           "posh_git",
+	LineError:         "line_error",
+	Tooltips:          "tooltips",
+	Transient:         "transient",
+	FTCSMarks:         "ftcs_marks",
+	Upgrade:           "upgrade",
+	Notice:            "notice",
+	PromptMark:        "prompt_mark",
+	RPrompt:           "rprompt",
+	CursorPositioning: "cursor_positioning",
+	Async:             "async",
+	Streaming:         "streaming",
+	KeyHandlers:       "key_handlers",
+	VIMode:            "vi_mode",
+	TransientRPrompt:  "transient_rprompt",
+	Background:        "background",
+}
+
+// Names lists the enabled features by their protocol name, in bit order.
+func (f Features) Names() []string {
+	names := []string{}
+
+	for _, feature := range getAllFeatures() {
+		if f&feature == 0 {
+			continue
+		}
+
+		names = append(names, featureNames[feature])
+	}
+
+	return names
+}
+
+// ParseFeatures is the inverse of Names, unknown names are ignored.
+func ParseFeatures(names []string) Features {
+	var feats Features
+
+	for feature, name := range featureNames {
+		if slices.Contains(names, name) {
+			feats |= feature
+		}
+	}
+
+	return feats
+}
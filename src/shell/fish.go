@@ -29,6 +29,8 @@ func (f Features) Fish() Code {
 		return unixUpgrade
 	case Notice:
 		return unixNotice
+	case Background:
+		return unixBackground
 	case VIMode:
 		return "_omp_enable_vimode"
 	case RPrompt, PoshGit, Azure, LineError, Jobs, Async, KeyHandlers:
@@ -20,7 +20,8 @@ set --global _omp_prompt_mark 1
 set --global _omp_cursor_positioning 1
 set --global _omp_enable_streaming 1
 _omp_enable_vimode
-set --global _omp_transient_rprompt 1`
+set --global _omp_transient_rprompt 1
+"$_omp_executable" get background >/dev/null`
 
 	assert.Equal(t, want, got)
 }
@@ -1,7 +1,22 @@
 package shell
 
+import "strings"
+
+// The semantic prompt marks (OSC 133), terminal.PromptStart and friends wrap
+// them in the shell's Mark format. CommandFinishedMark takes the ;<code>
+// argument, or nothing when there's no exit code.
+const (
+	PromptStartMark     = "\x1b]133;A\007"
+	CommandStartMark    = "\x1b]133;B\007"
+	CommandFinishedMark = "\x1b]133;D%s\007"
+	RPromptStartMark    = "\x1b]133;P;k=r\007"
+)
+
 type Formats struct {
-	Escape     string
+	Escape string
+	// Mark wraps a shell integration mark, Escape unless the shell needs
+	// something else to write the mark as is.
+	Mark       string
 	Left       string
 	Linechange string
 	ClearBelow string
@@ -31,7 +46,7 @@ func GetFormats(shell string) *Formats {
 	var formats *Formats
 
 	switch shell {
-	case BASH, YASH:
+	case BASH, YASH, OSH, YSH:
 		formats = &Formats{
 			Escape:                "\\[%s\\]",
 			Linechange:            "\\[\x1b[%d%s\\]",
@@ -77,6 +92,34 @@ func GetFormats(shell string) *Formats {
 				'%': "%%",
 			},
 		}
+	case TCSH:
+		// the prompt parses backslash sequences like bindkey does, terminate
+		// the OSC sequences with BEL rather than ST (ESC \) for that reason
+		formats = &Formats{
+			Escape:                "%%{%s%%}",
+			Linechange:            "%%{\x1b[%d%s%%}",
+			Left:                  "%%{\x1b[%dD%%}",
+			ClearBelow:            "%{\x1b[0J%}",
+			ClearLine:             "%{\x1b[K%}",
+			SaveCursorPosition:    "%{\x1b7%}",
+			RestoreCursorPosition: "%{\x1b8%}",
+			Title:                 "%%{\x1b]0;%s\007%%}",
+			HyperlinkStart:        "%{\x1b]8;;",
+			HyperlinkCenter:       "\x07%}",
+			HyperlinkEnd:          "%{\x1b]8;;\x07%}",
+			Osc99:                 "%%{\x1b]9;9;%s\x07%%}",
+			Osc7:                  "%%{\x1b]7;file://%s/%s\x07%%}",
+			Osc51:                 "%%{\x1b]51;A%s@%s:%s\x07%%}",
+			ITermCurrentDir:       "%%{\x1b]1337;CurrentDir=%s\x07%%}",
+			ITermRemoteHost:       "%%{\x1b]1337;RemoteHost=%s@%s\x07%%}",
+			EscapeSequences: map[rune]string{
+				'%':  "%%",
+				'\\': `\\`,
+				'!':  `\!`,
+				'^':  `\^`,
+				'\n': `\n`,
+			},
+		}
 	default:
 		formats = &Formats{
 			Escape:                "%s",
@@ -101,5 +144,24 @@ func GetFormats(shell string) *Formats {
 		}
 	}
 
+	formats.Mark = formats.Escape
+
+	// prompt_toolkit only interprets color sequences in a prompt, it writes
+	// anything between \001 and \002 to the terminal as is, as zero width
+	if shell == XONSH {
+		formats.Mark = "\x01%s\x02"
+	}
+
 	return formats
 }
+
+// bashMarkers drops readline's \[ and \] markers, keeping escaped backslashes
+// intact so a literal \\ followed by [ isn't mistaken for one.
+var bashMarkers = strings.NewReplacer(`\\`, `\\`, `\[`, "", `\]`, "")
+
+// BashNonPrinting wraps text in a single \[ \] pair so readline counts it as
+// zero width as a whole. Readline doesn't support nested markers, so the ones
+// already in text are removed first.
+func BashNonPrinting(text string) string {
+	return `\[` + bashMarkers.Replace(text) + `\]`
+}
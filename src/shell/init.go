@@ -55,7 +55,7 @@ func Init(env runtime.Environment, feats Features) string {
 		return recurseInitCommand(env)
 	case NU:
 		return initNu(env, feats)
-	case ZSH, BASH, FISH, CMD, XONSH, YASH:
+	case ZSH, BASH, FISH, CMD, XONSH, YASH, OSH, YSH, TCSH:
 		return generateAndSourceScript(env, feats)
 	default:
 		return fmt.Sprintf(`echo "%s is not supported by Oh My Posh"`, env.Flags().Shell)
@@ -193,6 +193,15 @@ func generateScript(env runtime.Environment, feats Features) string {
 	case YASH:
 		executable = quoteYashStr(executable)
 		script = yashInit
+	case OSH:
+		executable = QuotePosixStr(executable)
+		script = oshInit
+	case YSH:
+		executable = quoteYshStr(executable)
+		script = yshInit
+	case TCSH:
+		executable = quoteTcshStr(executable)
+		script = tcshInit
 	default:
 		return fmt.Sprintf("echo \"No initialization script available for %s\"", env.Flags().Shell)
 	}
@@ -245,8 +254,12 @@ func sourceCommand(env runtime.Environment, scriptPath string, async bool) strin
 	switch env.Flags().Shell {
 	case PWSH:
 		script += fmt.Sprintf("& %s", quotePwshStr(scriptPath))
-	case ZSH, BASH:
+	case ZSH, BASH, OSH:
 		script += fmt.Sprintf("source %s", QuotePosixStr(scriptPath))
+	case YSH:
+		script += fmt.Sprintf("source %s", quoteYshStr(scriptPath))
+	case TCSH:
+		script += fmt.Sprintf("source %s", quoteTcshStr(scriptPath))
 	case XONSH:
 		script += fmt.Sprintf("source %s", quotePythonStr(scriptPath))
 	case FISH:
@@ -316,8 +329,12 @@ func sessionScript(env runtime.Environment) string {
 	switch env.Flags().Shell {
 	case PWSH:
 		return fmt.Sprintf("$env:POSH_SESSION_ID = \"%s\"; $env:POSH_CONFIG = %s;", sessionID, quotePwshStr(config))
-	case ZSH, BASH:
+	case ZSH, BASH, OSH:
 		return fmt.Sprintf("export POSH_SESSION_ID=\"%s\"; export POSH_CONFIG=%s;", sessionID, QuotePosixStr(config))
+	case YSH:
+		return fmt.Sprintf("setglobal ENV.POSH_SESSION_ID = '%s'; setglobal ENV.POSH_CONFIG = %s;", sessionID, quoteYshStr(config))
+	case TCSH:
+		return fmt.Sprintf("setenv POSH_SESSION_ID \"%s\"; setenv POSH_CONFIG %s;", sessionID, quoteTcshStr(config))
 	case YASH:
 		return fmt.Sprintf("export POSH_SESSION_ID=\"%s\"; export POSH_CONFIG=%s;", sessionID, quoteYashStr(config))
 	case XONSH:
@@ -12,12 +12,24 @@ var nuInit string
 func (f Features) Nu() Code {
 	switch f {
 	case Transient:
-		return `$env.TRANSIENT_PROMPT_COMMAND = {|| _omp_get_prompt transient }`
+		return "_omp_enable_transient"
+	case TransientRPrompt:
+		return "_omp_enable_transient_rprompt"
+	case RPrompt:
+		return "_omp_enable_rprompt"
+	case Tooltips:
+		return "enable_poshtooltips"
+	case FTCSMarks:
+		return "_omp_enable_ftcs_marks"
+	case Streaming:
+		return "_omp_enable_streaming"
 	case Upgrade:
 		return "^$_omp_executable upgrade --auto"
 	case Notice:
 		return "^$_omp_executable notice"
-	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, Tooltips, FTCSMarks, CursorPositioning, Async, Streaming, KeyHandlers, VIMode:
+	case Background:
+		return "^$_omp_executable get background | ignore"
+	case PromptMark, PoshGit, Azure, LineError, Jobs, CursorPositioning, Async, KeyHandlers, VIMode:
 		fallthrough
 	default:
 		return ""
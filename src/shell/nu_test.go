@@ -11,9 +11,15 @@ func TestNuFeatures(t *testing.T) {
 	got := allFeatures.Lines(NU).String("// these are the features")
 
 	want := `// these are the features
-$env.TRANSIENT_PROMPT_COMMAND = {|| _omp_get_prompt transient }
+enable_poshtooltips
+_omp_enable_transient
+_omp_enable_ftcs_marks
 ^$_omp_executable upgrade --auto
-^$_omp_executable notice`
+^$_omp_executable notice
+_omp_enable_rprompt
+_omp_enable_streaming
+_omp_enable_transient_rprompt
+^$_omp_executable get background | ignore`
 
 	assert.Equal(t, want, got)
 }
@@ -0,0 +1,25 @@
+package shell
+
+import (
+	_ "embed"
+)
+
+//go:embed scripts/omp.osh
+var oshInit string
+
+func (f Features) Osh() Code {
+	switch f {
+	case FTCSMarks:
+		return unixFTCSMarks
+	case Upgrade:
+		return unixUpgrade
+	case Notice:
+		return unixNotice
+	case Background:
+		return unixBackground
+	case Transient, RPrompt, Tooltips, KeyHandlers, CursorPositioning, Async, Streaming, VIMode, LineError, Jobs, Azure, PoshGit, PromptMark:
+		fallthrough
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,19 @@
+package shell
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOshFeatures(t *testing.T) {
+	got := allFeatures.Lines(OSH).String("// these are the features")
+
+	want := `// these are the features
+_omp_ftcs_marks=1
+"$_omp_executable" upgrade --auto
+"$_omp_executable" notice
+"$_omp_executable" get background >/dev/null`
+
+	assert.Equal(t, want, got)
+}
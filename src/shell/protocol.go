@@ -0,0 +1,66 @@
+package shell
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+)
+
+// ProtocolVersion is the version of the generic integration protocol spoken by
+// oh-my-posh serve. It only changes when an existing client would break.
+const ProtocolVersion = 1
+
+// ProtocolPrompts lists the prompt types a protocol client can negotiate,
+// primary is always rendered.
+var ProtocolPrompts = []string{"primary", "right", "secondary", "transient"}
+
+// Manifest is what init --protocol prints: everything a client needs to start
+// the serve daemon and know what the user's config asks of it.
+type Manifest struct {
+	Env      map[string]string `json:"env"`
+	Shell    string            `json:"shell"`
+	Serve    []string          `json:"serve"`
+	Prompts  []string          `json:"prompts"`
+	Features []string          `json:"features"`
+	Protocol int               `json:"protocol"`
+}
+
+// Protocol renders the manifest for the generic integration as JSON.
+func Protocol(env runtime.Environment, feats Features) string {
+	executable, err := getExecutablePath(env)
+	if err != nil {
+		log.Error(err)
+		executable = "oh-my-posh"
+	}
+
+	flags := env.Flags()
+
+	serve := []string{executable, "serve", fmt.Sprintf("--shell=%s", flags.Shell)}
+	if len(flags.ConfigPath) != 0 {
+		serve = append(serve, fmt.Sprintf("--config=%s", flags.ConfigPath))
+	}
+
+	manifest := &Manifest{
+		Protocol: ProtocolVersion,
+		Shell:    flags.Shell,
+		Serve:    serve,
+		Env: map[string]string{
+			"POSH_SESSION_ID": cache.SessionID(),
+			"POSH_SHELL":      flags.Shell,
+			"POSH_CONFIG":     flags.ConfigPath,
+		},
+		Prompts:  ProtocolPrompts,
+		Features: feats.Names(),
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+
+	return string(data)
+}
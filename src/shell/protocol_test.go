@@ -0,0 +1,61 @@
+package shell
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtocol(t *testing.T) {
+	cases := []struct {
+		Case       string
+		ConfigPath string
+		Features   Features
+		Serve      []string
+		Names      []string
+	}{
+		{
+			Case:     "no config",
+			Features: Transient | Streaming,
+			Serve:    []string{"serve", "--shell=shell"},
+			Names:    []string{"transient", "streaming"},
+		},
+		{
+			Case:       "config",
+			ConfigPath: "/home/jan/omp.json",
+			Features:   FTCSMarks | RPrompt,
+			Serve:      []string{"serve", "--shell=shell", "--config=/home/jan/omp.json"},
+			Names:      []string{"ftcs_marks", "rprompt"},
+		},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Flags").Return(&runtime.Flags{Shell: GENERIC, ConfigPath: tc.ConfigPath})
+		env.On("GOOS").Return(runtime.LINUX)
+
+		var manifest Manifest
+		err := json.Unmarshal([]byte(Protocol(env, tc.Features)), &manifest)
+
+		assert.NoError(t, err, tc.Case)
+		assert.Equal(t, ProtocolVersion, manifest.Protocol, tc.Case)
+		assert.Equal(t, GENERIC, manifest.Shell, tc.Case)
+		assert.Equal(t, tc.Serve, manifest.Serve[1:], tc.Case)
+		assert.Equal(t, tc.Names, manifest.Features, tc.Case)
+		assert.Equal(t, ProtocolPrompts, manifest.Prompts, tc.Case)
+		assert.Equal(t, tc.ConfigPath, manifest.Env["POSH_CONFIG"], tc.Case)
+		assert.NotEmpty(t, manifest.Env["POSH_SESSION_ID"], tc.Case)
+	}
+}
+
+func TestFeatureNames(t *testing.T) {
+	names := allFeatures.Names()
+
+	assert.Len(t, names, len(getAllFeatures())-1, "every feature but async has a name in allFeatures")
+	assert.Equal(t, allFeatures, ParseFeatures(names))
+	assert.Equal(t, Transient, ParseFeatures([]string{"transient", "unknown"}))
+}
@@ -30,6 +30,8 @@ func (f Features) Pwsh() Code {
 		return "& $global:_ompExecutable upgrade --auto"
 	case Notice:
 		return "& $global:_ompExecutable notice"
+	case Background:
+		return "$null = & $global:_ompExecutable get background"
 	case Streaming:
 		return "Enable-PoshStreaming"
 	case KeyHandlers:
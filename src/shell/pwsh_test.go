@@ -11,7 +11,7 @@ import (
 )
 
 var allFeatures = Tooltips | LineError | Transient | Jobs | Azure | PoshGit | FTCSMarks |
-	Upgrade | Notice | PromptMark | RPrompt | CursorPositioning | KeyHandlers | Streaming | VIMode | TransientRPrompt
+	Upgrade | Notice | PromptMark | RPrompt | CursorPositioning | KeyHandlers | Streaming | VIMode | TransientRPrompt | Background
 
 func TestPwshFeatures(t *testing.T) {
 	got := allFeatures.Lines(PWSH).String("")
@@ -28,7 +28,8 @@ $global:_ompFTCSMarks = $true
 & $global:_ompExecutable notice
 Enable-PoshStreaming
 Enable-KeyHandlers
-Enable-PoshVIMode`
+Enable-PoshVIMode
+$null = & $global:_ompExecutable get background`
 
 	assert.Equal(t, want, got)
 }
@@ -18,6 +18,29 @@ _omp_executable=::OMP::
 
 _omp_cursor_positioning=0
 _omp_ftcs_marks=0
+_omp_enable_streaming=0
+_omp_vimode=0
+
+# the lines above the last one of a prompt rendered for vi mode tracking,
+# see _omp_vimode_render
+_omp_vimode_split=0
+_omp_vimode_primary=''
+
+# A persistent `oh-my-posh serve` process renders prompts on request, replacing
+# a process spawn per prompt with an in-memory render. Preserve the fds when
+# the script is re-sourced mid-session so the running daemon is reused.
+_omp_serve_fd_in=${_omp_serve_fd_in:--1}
+_omp_serve_fd_out=${_omp_serve_fd_out:--1}
+_omp_serve_pid=${_omp_serve_pid:-0}
+_omp_serve_cycle=0
+_omp_serve_failures=0
+_omp_serve_primary=''
+# the primary prompt rendered for command mode, see _omp_vimode_render
+_omp_serve_vicmd=''
+# the user's SIGPIPE trap, restored after every request, see _omp_serve_request
+_omp_serve_pipe_trap=''
+# ${!A@} ... ${!_@}: expands to the name of every shell variable, see _omp_serve_env_raw
+_omp_serve_var_prefixes=$(printf '${!%s@} ' {A..Z} {a..z} _)
 
 # start timer on command start
 PS0='${_omp_start_time:0:$((_omp_start_time="$(_omp_milliseconds)",0))}$(_omp_ftcs_command_start)'
@@ -115,28 +138,47 @@ function _omp_get_primary() {
     shopt -u promptvars
     trap 'shopt -s promptvars' RETURN
 
+    if [[ $_omp_vimode_split == 1 ]]; then
+        # already expanded, readline draws the last line
+        printf '%s' "$_omp_vimode_primary"
+        return
+    fi
+
+    local serve_primary=$_omp_serve_primary
+    if [[ $_omp_vimode == 1 && -n $BLE_SESSION_ID ]]; then
+        _omp_ble_vimode
+        # the daemon renders the prompt for insert mode
+        [[ $POSH_VI_MODE != viins ]] && serve_primary=''
+    fi
+
     local prompt
     if shopt -oq posix; then
         prompt='[NOTICE: Oh My Posh prompt is not supported in POSIX mode]\n\u@\h:\w\$ '
+    elif [[ -n $serve_primary ]]; then
+        # rendered by the serve daemon in _omp_hook, or updated by the ble.sh
+        # streaming task since
+        prompt=$serve_primary
     else
-        prompt=$(
-            "$_omp_executable" print primary \
-                --save-cache \
-                --shell=bash \
-                --shell-version="$BASH_VERSION" \
-                --status="$_omp_status" \
-                --pipestatus="${_omp_pipestatus[*]}" \
-                --no-status="$_omp_no_status" \
-                --execution-time="$_omp_execution_time" \
-                --job-count="$_omp_job_count" \
-                --stack-count="$_omp_stack_count" \
-                --terminal-width="${COLUMNS-0}" |
-                tr -d '\0'
-        )
+        prompt=$(_omp_print_primary --save-cache)
     fi
     echo "${prompt@P}"
 }
 
+function _omp_print_primary() {
+    "$_omp_executable" print primary \
+        --shell=bash \
+        --shell-version="$BASH_VERSION" \
+        --status="$_omp_status" \
+        --pipestatus="${_omp_pipestatus[*]}" \
+        --no-status="$_omp_no_status" \
+        --execution-time="$_omp_execution_time" \
+        --job-count="$_omp_job_count" \
+        --stack-count="$_omp_stack_count" \
+        --terminal-width="${COLUMNS-0}" \
+        "$@" |
+        tr -d '\0'
+}
+
 function _omp_get_secondary() {
     # Avoid unexpected expansions when we're generating the prompt below.
     shopt -u promptvars
@@ -149,6 +191,364 @@ function _omp_get_secondary() {
     fi
 }
 
+# === serve daemon ===
+
+function _omp_serve_stop() {
+    # The stderr redirects must be scoped to a group: on a redirection-only
+    # `exec`, bash applies every listed redirection to the shell permanently.
+    [[ $_omp_serve_fd_in -ge 0 ]] && { exec {_omp_serve_fd_in}>&-; } 2>/dev/null
+    [[ $_omp_serve_fd_out -ge 0 ]] && { exec {_omp_serve_fd_out}<&-; } 2>/dev/null
+    _omp_serve_fd_in=-1
+    _omp_serve_fd_out=-1
+}
+
+function _omp_serve_start() {
+    _omp_serve_stop
+
+    # coproc needs bash 4.0, the {var} redirections 4.1
+    if ((BASH_VERSINFO[0] < 4 || (BASH_VERSINFO[0] == 4 && BASH_VERSINFO[1] < 1))); then
+        return 1
+    fi
+
+    # With job control active, bash announces the coproc on the terminal like
+    # any background job ("[1] 12345"). Disabling monitor mode for the spawn
+    # suppresses the notice; as a side effect the daemon starts with
+    # SIGINT/SIGQUIT ignored, so Ctrl+C at the prompt cannot take it down.
+    local monitor=0
+    [[ $- == *m* ]] && monitor=1 && set +m
+
+    # The daemon's stderr must never reach the terminal (a Go panic would
+    # corrupt the display). The outer redirect silences bash's warning about
+    # a previous, not yet reaped daemon still being registered as coproc.
+    {
+        coproc _OMP_SERVE { exec "$_omp_executable" serve --shell=bash 2>/dev/null; }
+    } 2>/dev/null
+
+    ((monitor)) && set -m
+
+    _omp_serve_pid=${_OMP_SERVE_PID:-0}
+    [[ $_omp_serve_pid -gt 0 && ${#_OMP_SERVE[@]} -eq 2 ]] || return 1
+
+    # Duplicate both directions to session fds: bash closes coproc fds in
+    # subshells and drops them as soon as the coproc gets reaped, the
+    # duplicates survive both. Lifetime is governed by these fds - closing
+    # them (or the shell exiting) EOFs the daemon's stdin and it exits.
+    local coproc_out=${_OMP_SERVE[0]} coproc_in=${_OMP_SERVE[1]}
+    exec {_omp_serve_fd_out}<&"$coproc_out" {_omp_serve_fd_in}>&"$coproc_in"
+    exec {coproc_out}<&- {coproc_in}>&-
+
+    # Keep the daemon out of the job table: it must not show up in `jobs` or
+    # inflate the job-count segment.
+    disown "$_omp_serve_pid" 2>/dev/null
+
+    # Reading the trap takes a subshell, do it once per daemon rather than on
+    # every request.
+    _omp_serve_pipe_trap=$(trap -p PIPE)
+
+    return 0
+}
+
+# Returns via REPLY. Any control characters left after the named escapes are
+# stripped - JSON forbids them raw.
+function _omp_serve_escape() {
+    local s=$1
+    s=${s//\\/\\\\}
+    s=${s//\"/\\\"}
+    s=${s//$'\n'/\\n}
+    s=${s//$'\r'/\\r}
+    s=${s//$'\t'/\\t}
+    REPLY=${s//[[:cntrl:]]/}
+}
+
+# Prints the full exported environment as "KEY=VALUE\0" records, terminated
+# by one extra bare NUL (an empty record) - see readEnvBlob on the daemon
+# side. No escaping is needed: env values can never contain a NUL byte. bash
+# variables can't hold one either, hence printing instead of returning.
+#
+# This runs on every prompt, so it must not fork: listing the exported names
+# through $(compgen -e) would cost about as much as the daemon saves. Since
+# bash 4.4 the name expansions in _omp_serve_var_prefixes list every variable
+# and ${name@a} tells the exported ones apart, both without a subshell.
+function _omp_serve_env_raw() {
+    local name names
+    if ((BASH_VERSINFO[0] > 4 || BASH_VERSINFO[1] >= 4)); then
+        eval "names=($_omp_serve_var_prefixes)"
+        for name in "${names[@]}"; do
+            [[ ${!name@a} == *x* ]] && printf '%s=%s\0' "$name" "${!name}"
+        done
+    else
+        for name in $(compgen -e); do
+            printf '%s=%s\0' "$name" "${!name}"
+        done
+    fi
+    printf '\0'
+}
+
+# Arguments: the vi modes to render the primary prompt for as well.
+function _omp_serve_request() {
+    # never pass a possibly-zero pid to kill: `kill -0 0` signals the caller's
+    # own process group and always succeeds
+    [[ $_omp_serve_pid -gt 0 ]] || return 1
+    kill -0 "$_omp_serve_pid" 2>/dev/null || return 1
+
+    ((_omp_serve_cycle++))
+
+    local wait=true
+    [[ $_omp_enable_streaming == 1 ]] && wait=false
+
+    local REPLY
+    _omp_serve_escape "$PWD"
+
+    local json='{"command":"render"'
+    json+=",\"id\":$_omp_serve_cycle"
+    json+=',"shell":"bash"'
+    json+=",\"shell-version\":\"$BASH_VERSION\""
+    json+=",\"status\":$_omp_status"
+    json+=",\"pipestatus\":\"${_omp_pipestatus[*]}\""
+    json+=",\"no-status\":$_omp_no_status"
+    json+=",\"execution-time\":$_omp_execution_time"
+    json+=",\"stack-count\":$_omp_stack_count"
+    json+=",\"terminal-width\":${COLUMNS:-0}"
+    json+=",\"job-count\":$_omp_job_count"
+    json+=",\"pwd\":\"$REPLY\""
+    json+=",\"wait\":$wait"
+    if (($#)); then
+        local modes
+        printf -v modes ',"%s"' "$@"
+        json+=",\"vi-modes\":[${modes#,}]"
+    fi
+    json+='}'
+
+    # A write to a dead daemon's pipe raises SIGPIPE, which terminates bash -
+    # ignore it for the duration of the write so it degrades into the error
+    # path instead, then restore the user's disposition as it was when the
+    # daemon started. The pid check above makes the common case cheap; the
+    # trap covers the race.
+    trap '' PIPE
+
+    # The full environment follows the header, unconditionally - see
+    # _omp_serve_env_raw. Both go through the same fd from the same sequential
+    # writer, so they can never interleave with another request.
+    local written=0
+    { printf '%s\n' "$json" && _omp_serve_env_raw; } >&"$_omp_serve_fd_in" 2>/dev/null && written=1
+
+    if [[ -n $_omp_serve_pipe_trap ]]; then
+        eval "$_omp_serve_pipe_trap"
+    else
+        trap - PIPE
+    fi
+
+    ((written))
+}
+
+# Renders the primary prompt through the daemon into $_omp_serve_primary,
+# and into $_omp_serve_vicmd for command mode when given vicmd as argument.
+# Returns nonzero on failure, in which case _omp_get_primary falls back to
+# print primary.
+#
+# Without streaming the request uses the protocol's wait mode: the reply is
+# the fully resolved primary prompt, one record per vi mode and the
+# transient one. Bash has no way to consume records while readline is active, and
+# unread updates would pile up in the pipe. Under ble.sh with streaming
+# enabled, only the first primary record is awaited here; the updates are
+# consumed by _omp_ble_stream_task.
+function _omp_serve_render() {
+    if [[ $_omp_serve_fd_in -lt 0 ]] && ! _omp_serve_start; then
+        ((_omp_serve_failures++))
+        return 1
+    fi
+
+    if ! _omp_serve_request "$@"; then
+        # The daemon died since the last prompt - restart it once.
+        if ! _omp_serve_start || ! _omp_serve_request "$@"; then
+            ((_omp_serve_failures++))
+            _omp_serve_stop
+            return 1
+        fi
+    fi
+
+    # A wait render resolves every segment, bounded by their own timeouts,
+    # a streamed one replies right away.
+    local timeout=10
+    [[ $_omp_enable_streaming == 1 ]] && timeout=2
+
+    # Stale records from an earlier cycle are discarded by the id check.
+    local record id payload
+    while true; do
+        if ! IFS= read -r -d '' -t "$timeout" -u "$_omp_serve_fd_out" record; then
+            ((_omp_serve_failures++))
+            _omp_serve_stop
+            return 1
+        fi
+
+        id=${record%%$'\x1f'*}
+        [[ $id == "$_omp_serve_cycle" ]] || continue
+        payload=${record#*$'\x1f'}
+
+        # the transient prompt is rendered by ble.sh itself, see
+        # prompt_ps1_final, and always ends a wait reply
+        if [[ $payload == $'\x1e'* ]]; then
+            break
+        fi
+
+        # "\x1d<mode>\x1d<prompt>", the primary prompt for another vi mode
+        if [[ $payload == $'\x1d'* ]]; then
+            payload=${payload#?}
+            [[ ${payload%%$'\x1d'*} == vicmd ]] && _omp_serve_vicmd=${payload#*$'\x1d'}
+            continue
+        fi
+
+        _omp_serve_primary=$payload
+        [[ $_omp_enable_streaming == 1 ]] && break
+    done
+
+    # An empty primary is the daemon's fallback signal (failed render).
+    if [[ -z $_omp_serve_primary ]]; then
+        ((_omp_serve_failures++))
+        return 1
+    fi
+
+    return 0
+}
+
+# ble.sh idle task consuming a streaming cycle's records: repaints the prompt
+# as pending segments resolve, also while typing. It reschedules itself until
+# the next prompt starts a new cycle.
+function _omp_ble_stream_task() {
+    [[ $1 == "$_omp_serve_cycle" && $_omp_serve_fd_out -ge 0 ]] || return 0
+
+    local record id payload updated=0
+
+    # read -t 0 only checks for input: a read timing out halfway through a
+    # record would lose what it read so far. Records are written at once, so
+    # once the first byte is there, the rest follows right away.
+    while read -t 0 -u "$_omp_serve_fd_out" 2>/dev/null; do
+        if ! IFS= read -r -d '' -u "$_omp_serve_fd_out" record; then
+            # EOF - the daemon died
+            _omp_serve_stop
+            break
+        fi
+
+        id=${record%%$'\x1f'*}
+        [[ $id == "$_omp_serve_cycle" ]] || continue
+        payload=${record#*$'\x1f'}
+        [[ $payload == $'\x1e'* ]] && continue
+
+        _omp_serve_primary=$payload
+        updated=1
+    done
+
+    if ((updated)); then
+        ble/prompt/clear
+        ble/textarea#redraw
+    fi
+
+    [[ $_omp_serve_fd_out -ge 0 ]] || return 0
+
+    ble/util/idle.sleep 50
+    return 148
+}
+
+# === vi mode ===
+
+function _omp_enable_vimode() {
+    _omp_vimode=1
+    export POSH_VI_MODE=viins
+
+    if [[ -n $BLE_SESSION_ID ]]; then
+        # ble.sh evaluates PS1 again on every mode change, the vimode segment
+        # replaces its own mode indicator
+        bleopt keymap_vi_mode_update_prompt=1
+        bleopt keymap_vi_mode_show=
+    fi
+}
+
+# maps the ble.sh keymap to the modes the vimode segment expects
+function _omp_ble_vimode() {
+    case $_ble_decode_keymap in
+    vi_nmap) POSH_VI_MODE=vicmd ;;
+    vi_omap) POSH_VI_MODE=viopp ;;
+    vi_xmap | vi_smap) POSH_VI_MODE=visual ;;
+    vi_imap)
+        POSH_VI_MODE=viins
+        [[ -n $_ble_edit_overwrite_mode ]] && POSH_VI_MODE=replace
+        ;;
+    *) POSH_VI_MODE=emacs ;;
+    esac
+
+    export POSH_VI_MODE
+}
+
+# Readline runs no shell code when the vi editing mode changes, but it does
+# redraw the last line of the prompt with show-mode-in-prompt's mode string.
+# That line is rendered once per mode and handed to readline as the insert and
+# command mode strings, PS1 keeps the lines above it. The vimode segment
+# therefore has to be on the last line of the prompt.
+#
+# Returns nonzero when the prompt isn't split, either because vi mode
+# tracking is off or readline is in emacs mode or too old for mode strings.
+function _omp_vimode_splits() {
+    local split=$_omp_vimode_split
+    _omp_vimode_split=0
+
+    if [[ $_omp_vimode == 0 || -n $BLE_SESSION_ID ]] || shopt -oq posix; then
+        return 1
+    fi
+
+    if ! shopt -oq vi; then
+        export POSH_VI_MODE=emacs
+        # only undo what the previous prompt did when switching from vi mode
+        ((split)) && bind 'set show-mode-in-prompt off'
+        return 1
+    fi
+
+    export POSH_VI_MODE=viins
+
+    # the mode strings need readline 7
+    ((BASH_VERSINFO[0] > 4 || (BASH_VERSINFO[0] == 4 && BASH_VERSINFO[1] >= 4)))
+}
+
+# Splits the prompts the daemon rendered for both modes, see
+# _omp_vimode_splits. Without them a single print primary renders both, the
+# command mode one after the group separator it writes.
+function _omp_vimode_render() {
+    local insert=$_omp_serve_primary command=$_omp_serve_vicmd
+    if [[ -z $insert || -z $command ]]; then
+        local prompts
+        prompts=$(_omp_print_primary --save-cache --vi-mode=vicmd)
+        insert=${prompts%%$'\x1d'*}
+        command=${prompts#*$'\x1d'vicmd$'\x1d'}
+    fi
+
+    # Avoid unexpected expansions when we're expanding the prompts below.
+    local promptvars=0
+    shopt -q promptvars && promptvars=1
+    shopt -u promptvars
+    insert=${insert@P}
+    command=${command@P}
+    ((promptvars)) && shopt -s promptvars
+
+    # a trailing newline doesn't survive the command substitution in PS1,
+    # an empty pair of non-printing markers after it keeps it
+    _omp_vimode_primary=''
+    if [[ $insert == *$'\n'* ]]; then
+        _omp_vimode_primary=${insert%$'\n'*}$'\n\001\002'
+        insert=${insert##*$'\n'}
+        command=${command##*$'\n'}
+    fi
+
+    insert=${insert//\\/\\\\}
+    insert=${insert//\"/\\\"}
+    command=${command//\\/\\\\}
+    command=${command//\"/\\\"}
+
+    bind "set vi-ins-mode-string \"$insert\""
+    bind "set vi-cmd-mode-string \"$command\""
+    bind 'set show-mode-in-prompt on'
+
+    _omp_vimode_split=1
+}
+
 function _omp_hook() {
     _omp_status=$? _omp_pipestatus=("${PIPESTATUS[@]}")
 
@@ -177,6 +577,21 @@ function _omp_hook() {
     set_poshcontext
     _omp_set_cursor_position
 
+    local vimodes=()
+    _omp_vimode_splits && vimodes=(vicmd)
+
+    # After three failures the daemon is left alone for the session and every
+    # prompt is rendered by print primary instead.
+    _omp_serve_primary=''
+    _omp_serve_vicmd=''
+    if [[ $_omp_serve_failures -lt 3 ]] && ! shopt -oq posix && _omp_serve_render "${vimodes[@]}"; then
+        if [[ $_omp_enable_streaming == 1 ]]; then
+            ble/util/idle.push "_omp_ble_stream_task $_omp_serve_cycle"
+        fi
+    fi
+
+    ((${#vimodes[@]})) && _omp_vimode_render
+
     PS1='$(_omp_get_primary)'
     PS2='$(_omp_get_secondary)'
 
@@ -0,0 +1,37 @@
+package shell
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed scripts/omp.tcsh
+var tcshInit string
+
+func quoteTcshStr(str string) string {
+	if str == "" {
+		return "''"
+	}
+
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(str, "'", `'\''`))
+}
+
+func (f Features) Tcsh() Code {
+	switch f {
+	case FTCSMarks:
+		return "set _omp_ftcs_marks = 1"
+	case RPrompt:
+		return "set _omp_rprompt = 1"
+	case Upgrade:
+		return "$_omp_executable:q upgrade --auto"
+	case Notice:
+		return "$_omp_executable:q notice"
+	case Background:
+		return "$_omp_executable:q get background > /dev/null"
+	case Transient, Tooltips, KeyHandlers, CursorPositioning, Async, Streaming, VIMode, LineError, Jobs, Azure, PoshGit, PromptMark:
+		fallthrough
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,34 @@
+package shell
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTcshFeatures(t *testing.T) {
+	got := allFeatures.Lines(TCSH).String("// these are the features")
+
+	want := `// these are the features
+set _omp_ftcs_marks = 1
+$_omp_executable:q upgrade --auto
+$_omp_executable:q notice
+set _omp_rprompt = 1
+$_omp_executable:q get background > /dev/null`
+
+	assert.Equal(t, want, got)
+}
+
+func TestQuoteTcshStr(t *testing.T) {
+	tests := []struct {
+		str      string
+		expected string
+	}{
+		{str: "", expected: "''"},
+		{str: `/tmp/"omp's dir"/oh-my-posh`, expected: `'/tmp/"omp'\''s dir"/oh-my-posh'`},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, quoteTcshStr(tc.str), fmt.Sprintf("quoteTcshStr: %s", tc.str))
+	}
+}
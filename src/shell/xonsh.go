@@ -11,11 +11,21 @@ var xonshInit string
 
 func (f Features) Xonsh() Code {
 	switch f {
+	case Transient:
+		return "_omp_transient_prompt = True"
+	case RPrompt:
+		return "$RIGHT_PROMPT = _omp_get_right"
+	case FTCSMarks:
+		return "_omp_ftcs_marks = True"
+	case Streaming:
+		return "_omp_serve_start()"
 	case Upgrade:
 		return "@(_omp_executable) upgrade --auto"
 	case Notice:
 		return "@(_omp_executable) notice"
-	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, Tooltips, Transient, CursorPositioning, FTCSMarks, Async, Streaming, KeyHandlers, VIMode:
+	case Background:
+		return "$(@(_omp_executable) get background)"
+	case PromptMark, PoshGit, Azure, LineError, Jobs, Tooltips, CursorPositioning, Async, KeyHandlers, VIMode:
 		fallthrough
 	default:
 		return ""
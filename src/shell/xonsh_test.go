@@ -11,8 +11,13 @@ func TestXonshFeatures(t *testing.T) {
 	got := allFeatures.Lines(XONSH).String("// these are the features")
 
 	want := `// these are the features
+_omp_transient_prompt = True
+_omp_ftcs_marks = True
 @(_omp_executable) upgrade --auto
-@(_omp_executable) notice`
+@(_omp_executable) notice
+$RIGHT_PROMPT = _omp_get_right
+_omp_serve_start()
+$(@(_omp_executable) get background)`
 
 	assert.Equal(t, want, got)
 }
@@ -27,6 +27,8 @@ func (f Features) Yash() Code {
 		return unixUpgrade
 	case Notice:
 		return unixNotice
+	case Background:
+		return unixBackground
 	case RPrompt:
 		return `YASH_PS1R='$(
     "$_omp_executable" print right \
@@ -23,7 +23,8 @@ YASH_PS1R='$(
         --execution-time="$_omp_execution_time" \
         --job-count="$_omp_job_count" \
         --terminal-width="${COLUMNS:-0}"
-)'`
+)'
+"$_omp_executable" get background >/dev/null`
 
 	assert.Equal(t, want, got)
 }
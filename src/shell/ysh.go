@@ -0,0 +1,34 @@
+package shell
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed scripts/omp.ysh
+var yshInit string
+
+// YSH single quoted strings are raw, J8 style u'...' strings understand escapes.
+func quoteYshStr(str string) string {
+	if str == "" {
+		return "''"
+	}
+
+	return fmt.Sprintf("u'%s'", strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(str))
+}
+
+func (f Features) Ysh() Code {
+	switch f {
+	case Upgrade:
+		return unixUpgrade
+	case Notice:
+		return unixNotice
+	case Background:
+		return unixBackground
+	case FTCSMarks, Transient, RPrompt, Tooltips, KeyHandlers, CursorPositioning, Async, Streaming, VIMode, LineError, Jobs, Azure, PoshGit, PromptMark:
+		fallthrough
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,33 @@
+package shell
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYshFeatures(t *testing.T) {
+	got := allFeatures.Lines(YSH).String("// these are the features")
+
+	want := `// these are the features
+"$_omp_executable" upgrade --auto
+"$_omp_executable" notice
+"$_omp_executable" get background >/dev/null`
+
+	assert.Equal(t, want, got)
+}
+
+func TestQuoteYshStr(t *testing.T) {
+	tests := []struct {
+		str      string
+		expected string
+	}{
+		{str: "", expected: "''"},
+		{str: `/tmp/"omp's dir"/oh-my-posh`, expected: `u'/tmp/"omp\'s dir"/oh-my-posh'`},
+		{str: `C:/tmp\omp's dir/oh-my-posh.exe`, expected: `u'C:/tmp\\omp\'s dir/oh-my-posh.exe'`},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, quoteYshStr(tc.str), fmt.Sprintf("quoteYshStr: %s", tc.str))
+	}
+}
@@ -21,6 +21,8 @@ func (f Features) Zsh() Code {
 		return unixUpgrade
 	case Notice:
 		return unixNotice
+	case Background:
+		return unixBackground
 	case Streaming:
 		return "_omp_enable_streaming=1"
 	case VIMode:
@@ -21,7 +21,8 @@ _omp_ftcs_marks=1
 "$_omp_executable" notice
 _omp_cursor_positioning=1
 _omp_enable_streaming=1
-_omp_enable_vimode`
+_omp_enable_vimode
+"$_omp_executable" get background >/dev/null`
 
 	assert.Equal(t, want, got)
 }
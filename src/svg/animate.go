@@ -0,0 +1,146 @@
+package svg
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+)
+
+// Frame is one state of an animated prompt: the rows and cursor Encode would
+// render on their own, shown from At until the next frame's At.
+type Frame struct {
+	Rows   [][]terminal.Run
+	Cursor *Cursor
+	At     time.Duration
+}
+
+// EncodeAnimated renders frames into a single SVG that plays them in order and
+// loops, holding the last frame for hold before starting over.
+//
+// Every frame is laid out exactly like Encode lays out a single prompt, into
+// its own <g>, and all of them share one window sized for the tallest frame,
+// so nothing jumps when a frame adds a row. Only one <g> is visible at a time:
+// each carries a CSS animation stepping its opacity from 0 to 1 at its own At
+// and back at the next frame's. CSS animations play inside an <img> the same
+// as inline, unlike anything scripted, so the result animates wherever a
+// static export already displays. Class and keyframe names carry a hash of the
+// frames for the same reason Encode's blink class does.
+//
+//nolint:gocritic
+func EncodeAnimated(frames []Frame, opts Options, hold time.Duration) string {
+	opts = opts.withDefaults()
+
+	if len(frames) == 0 {
+		frames = []Frame{{}}
+	}
+
+	laidOut := make([][][]terminal.Run, len(frames))
+	maxRows := 0
+	blinks := false
+	hasher := fnv.New64a()
+
+	for i, frame := range frames {
+		frameOpts := opts
+		frameOpts.Cursor = frame.Cursor
+		laidOut[i] = layout(frame.Rows, &frameOpts)
+		maxRows = max(maxRows, len(laidOut[i]))
+
+		for _, row := range laidOut[i] {
+			for j := range row {
+				if row[j].Attributes[attrBlink] > 0 {
+					blinks = true
+				}
+
+				hasher.Write([]byte(row[j].Text))
+				hasher.Write(row[j].Attributes[:])
+			}
+		}
+	}
+
+	hash := strconv.FormatUint(hasher.Sum64(), 36)
+
+	var blinkClass string
+	if blinks {
+		blinkClass = "omp-blink-" + hash
+	}
+
+	geo := newWindowGeometry(&opts)
+	size := newCanvasSize(&geo, opts.Columns, opts.CellWidth, maxRows, opts.LineHeight)
+
+	var b strings.Builder
+
+	p := &svgPainter{b: &b, opts: &opts, blinkClass: blinkClass}
+
+	p.Begin(Canvas{
+		Width:     size.width,
+		Height:    size.height,
+		FontSize:  opts.FontSize,
+		CellWidth: opts.CellWidth,
+	})
+
+	frameClass := "omp-frame-" + hash
+	writeFrameStyle(&b, frameClass, frames, hold)
+
+	p.Window(newWindow(size, &geo, *opts.CanvasBackground))
+
+	for i, rows := range laidOut {
+		fmt.Fprintf(&b, `<g class="%s-%d">`+"\n", frameClass, i)
+
+		for rowIndex, row := range rows {
+			paintRow(p, row, rowIndex, size, &opts)
+		}
+
+		b.WriteString("</g>\n")
+	}
+
+	b.WriteString("</svg>")
+
+	return b.String()
+}
+
+// writeFrameStyle writes one class and @keyframes rule per frame. The
+// animation runs for the whole loop with step-end timing, so each keyframe's
+// opacity holds until the next keyframe instead of fading into it.
+func writeFrameStyle(b *strings.Builder, frameClass string, frames []Frame, hold time.Duration) {
+	total := frames[len(frames)-1].At + hold
+	if total <= 0 {
+		total = time.Second
+	}
+
+	percent := func(at time.Duration) string {
+		return formatFloat(float64(at) / float64(total) * 100)
+	}
+
+	b.WriteString("<style>\n")
+
+	for i, frame := range frames {
+		name := fmt.Sprintf("%s-%d", frameClass, i)
+
+		fmt.Fprintf(b, ".%s { opacity: 0; animation: %s %sms step-end infinite; }\n", name, name, strconv.FormatInt(total.Milliseconds(), 10))
+		fmt.Fprintf(b, "@keyframes %s { 0%% { opacity: %d; } ", name, boolToOpacity(i == 0))
+
+		if i > 0 {
+			fmt.Fprintf(b, "%s%% { opacity: 1; } ", percent(frame.At))
+		}
+
+		if i < len(frames)-1 {
+			fmt.Fprintf(b, "%s%% { opacity: 0; } ", percent(frames[i+1].At))
+		}
+
+		fmt.Fprintf(b, "100%% { opacity: %d; } }\n", boolToOpacity(i == len(frames)-1))
+	}
+
+	b.WriteString("</style>\n")
+}
+
+func boolToOpacity(visible bool) int {
+	if visible {
+		return 1
+	}
+
+	return 0
+}
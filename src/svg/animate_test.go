@@ -0,0 +1,100 @@
+package svg
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeAnimated(t *testing.T) {
+	pending := []terminal.Run{{Text: "...", Cells: 3}}
+	resolved := []terminal.Run{{Text: "main", Cells: 4}}
+
+	cases := []struct {
+		Case      string
+		Frames    []Frame
+		Keyframes []string
+		Groups    int
+	}{
+		{
+			Case:   "no frames",
+			Groups: 1,
+			Keyframes: []string{
+				"animation: -0 1000ms step-end infinite;",
+				"{ 0% { opacity: 1; } 100% { opacity: 1; } }",
+			},
+		},
+		{
+			Case: "two frames",
+			Frames: []Frame{
+				{Rows: [][]terminal.Run{pending}},
+				{Rows: [][]terminal.Run{resolved}, At: 500 * time.Millisecond},
+			},
+			Groups: 2,
+			Keyframes: []string{
+				"animation: -0 1500ms step-end infinite;",
+				"-0 { 0% { opacity: 1; } 33.33% { opacity: 0; } 100% { opacity: 0; } }",
+				"-1 { 0% { opacity: 0; } 33.33% { opacity: 1; } 100% { opacity: 1; } }",
+			},
+		},
+		{
+			Case: "frame with an extra row",
+			Frames: []Frame{
+				{Rows: [][]terminal.Run{pending}},
+				{Rows: [][]terminal.Run{resolved, resolved}, At: 500 * time.Millisecond},
+			},
+			Groups: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			got := EncodeAnimated(tc.Frames, testOptions(), time.Second)
+
+			assert.Equal(t, tc.Groups, strings.Count(got, `<g class="omp-frame-`), "one group per frame")
+			assert.True(t, strings.HasSuffix(got, "</svg>"))
+
+			for _, keyframe := range tc.Keyframes {
+				assert.Contains(t, stripFrameHash(got), keyframe)
+			}
+		})
+	}
+}
+
+func TestEncodeAnimatedCanvasFitsTallestFrame(t *testing.T) {
+	one := [][]terminal.Run{{{Text: "a", Cells: 1}}}
+	two := [][]terminal.Run{{{Text: "a", Cells: 1}}, {{Text: "b", Cells: 1}}}
+
+	animated := EncodeAnimated([]Frame{{Rows: one}, {Rows: two, At: time.Second}}, testOptions(), time.Second)
+	static := Encode(two, testOptions())
+
+	assert.Equal(t, svgHeight(static), svgHeight(animated))
+}
+
+// stripFrameHash drops the prefix and hash from the frame classes, leaving "-0",
+// "-1" and so on, so keyframes can be
+// asserted without recomputing it.
+func stripFrameHash(doc string) string {
+	var b strings.Builder
+
+	for {
+		i := strings.Index(doc, "omp-frame-")
+		if i < 0 {
+			b.WriteString(doc)
+			return b.String()
+		}
+
+		b.WriteString(doc[:i])
+		doc = doc[i+len("omp-frame-"):]
+		doc = doc[strings.IndexByte(doc, '-'):]
+	}
+}
+
+func svgHeight(doc string) string {
+	start := strings.Index(doc, `height="`) + len(`height="`)
+	return doc[start : start+strings.IndexByte(doc[start:], '"')]
+}
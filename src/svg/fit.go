@@ -14,7 +14,7 @@ import (
 // own content.
 const minGapRun = 5
 
-// fitRow fits a single captured row to at most maxCells cells, porting the
+// FitRow fits a single captured row to at most maxCells cells, porting the
 // retired PNG renderer's own fitRow policy (image.go, ~:539-562) from pixel
 // measurements to cell counts: this package already lays runs out on a
 // fixed cell grid (see Encode's doc comment), so there is no font metric to
@@ -31,8 +31,9 @@ const minGapRun = 5
 // The engine renders at exactly Columns columns, so in practice almost
 // every row already fits and this is a no-op; it exists for the rows a
 // later change (double-width glyph cell allocation) will push over by a
-// few cells despite that.
-func fitRow(row []terminal.Run, maxCells int) [][]terminal.Run {
+// few cells despite that. It's exported for the screen package, which lays a
+// row onto its virtual terminal the same way Encode lays it onto the canvas.
+func FitRow(row []terminal.Run, maxCells int) [][]terminal.Run {
 	cells := rowCells(row)
 	if cells <= maxCells {
 		return [][]terminal.Run{row}
@@ -138,7 +139,7 @@ func longestGapRegion(row []terminal.Run) (start, count, cells int, ok bool) {
 // collapseGap removes remove cells from the front of the gap region
 // row[start:start+count], mirroring the retired PNG renderer's own
 // row.glyphs[:start] + row.glyphs[start+remove:] splice (image.go's
-// fitRow) at Run granularity: runs entirely inside the removed span are
+// FitRow) at Run granularity: runs entirely inside the removed span are
 // dropped, and the run remove finally lands in has its leading cells (and
 // matching leading spaces of its Text) trimmed rather than dropped whole.
 func collapseGap(row []terminal.Run, start, count, remove int) []terminal.Run {
@@ -179,11 +180,11 @@ func collapseGap(row []terminal.Run, start, count, remove int) []terminal.Run {
 // caller currently produces (see terminal.Run's doc comment) — a run whose
 // Cells already differs from its rune count only does so via zero-width
 // hyperlink text, handled separately below. A future double-width-glyph
-// allocation (the same change this whole fitRow port exists to absorb the
-// impact of — see fitRow's doc comment) would need this split taught actual
+// allocation (the same change this whole FitRow port exists to absorb the
+// impact of — see FitRow's doc comment) would need this split taught actual
 // per-glyph cell widths instead of one-cell-per-rune.
 //
-// maxCells must be positive — fitRow only ever calls this after failing to
+// maxCells must be positive — FitRow only ever calls this after failing to
 // bring a row into budget by collapsing a gap run, which requires a positive
 // budget to have overflowed in the first place; a direct caller must enforce
 // that itself.
@@ -96,12 +96,12 @@ func TestLongestGapRegionNoneQualifies(t *testing.T) {
 	assert.False(t, ok)
 }
 
-// TestFitRowWithinBudgetIsUnchanged pins fitRow's no-op path: nothing about
+// TestFitRowWithinBudgetIsUnchanged pins FitRow's no-op path: nothing about
 // a row within budget is touched, not even a defensive copy.
 func TestFitRowWithinBudgetIsUnchanged(t *testing.T) {
 	row := []terminal.Run{contentRun("hello")}
 
-	got := fitRow(row, 10)
+	got := FitRow(row, 10)
 
 	require.Len(t, got, 1)
 	assert.Equal(t, row, got[0])
@@ -119,7 +119,7 @@ func TestFitRowCollapsesGapRunFirst(t *testing.T) {
 	}
 	// total 19 cells; budget 15 -> must remove 4 padding cells, nothing else.
 
-	got := fitRow(row, 15)
+	got := FitRow(row, 15)
 
 	require.Len(t, got, 1)
 	assert.Equal(t, 15, rowCells(got[0]))
@@ -147,7 +147,7 @@ func TestFitRowCollapsesAcrossAdjacentGapRuns(t *testing.T) {
 	}
 	// total 1+3+3+5+1 = 13 cells; budget 7 -> remove 6 from the 8-cell region.
 
-	got := fitRow(row, 7)
+	got := FitRow(row, 7)
 
 	require.Len(t, got, 1)
 	assert.Equal(t, 7, rowCells(got[0]))
@@ -172,7 +172,7 @@ func TestFitRowWrapsWhenGapAloneIsNotEnough(t *testing.T) {
 	// total 21 cells; budget 10. The whole 6-cell gap collapses (removing
 	// all of it still leaves 15 > 10), so it must also hard-wrap.
 
-	got := fitRow(row, 10)
+	got := FitRow(row, 10)
 
 	require.Len(t, got, 2)
 	assert.LessOrEqual(t, rowCells(got[0]), 10)
@@ -0,0 +1,233 @@
+package svg
+
+import (
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+)
+
+// Painter receives every shape of a laid-out canvas, in paint order. Encode's
+// own Painter writes them as SVG markup; a raster backend (see package
+// raster) fills pixels with the exact same shapes instead, so both agree on
+// where every cell, background and window edge lands rather than each
+// re-deriving the layout from the Run stream on its own.
+type Painter interface {
+	// Begin is called once, before any other shape.
+	Begin(canvas Canvas)
+	Window(window Window)
+	Rect(rect Rect)
+	Text(text Text)
+}
+
+// Canvas is the size of the whole document and the resolved metrics its
+// shapes were laid out with, all in the same absolute unit as Options'.
+type Canvas struct {
+	Width, Height float64
+	FontSize      float64
+	CellWidth     float64
+}
+
+// Window is the terminal window chrome drawn behind the rows: the header bar
+// above TitleOffset and the content pane below it, rounded by Corner, with a
+// StrokeWidth border and the minimize/maximize/close controls. It always
+// fills the canvas, see canvasSize.
+type Window struct {
+	Width, Height float64
+	TitleOffset   float64
+	Corner        float64
+	StrokeWidth   float64
+	Header        color.RGB
+	Content       color.RGB
+	Stroke        color.RGB
+	Controls      [3]Control
+}
+
+// Control is one window-control glyph, horizontally centered on X.
+type Control struct {
+	Label    string
+	X        float64
+	Baseline float64
+	Size     float64
+	Fill     color.RGB
+}
+
+// Rect is a segment background, already clipped to its fill box (see
+// Options.FillAscent/FillDescent).
+type Rect struct {
+	X, Y          float64
+	Width, Height float64
+	Fill          color.RGB
+}
+
+// Text is one run's text, positioned on the cell grid: Cells cells starting
+// at X, Width wide, on the alphabetic Baseline. A nil Fill keeps the
+// renderer's default foreground.
+type Text struct {
+	Text       string
+	X          float64
+	Baseline   float64
+	Width      float64
+	Cells      int
+	Fill       *color.RGB
+	Attributes [8]uint8
+}
+
+func (t *Text) Bold() bool          { return t.Attributes[attrBold] > 0 }
+func (t *Text) Italic() bool        { return t.Attributes[attrItalic] > 0 }
+func (t *Text) Underline() bool     { return t.Attributes[attrUnderline] > 0 }
+func (t *Text) Overline() bool      { return t.Attributes[attrOverline] > 0 }
+func (t *Text) Strikethrough() bool { return t.Attributes[attrStrikethrough] > 0 }
+func (t *Text) Dim() bool           { return t.Attributes[attrDim] > 0 }
+func (t *Text) Blink() bool         { return t.Attributes[attrBlink] > 0 }
+
+// Paint lays rows out exactly like Encode does and hands every resulting
+// shape to p. Encode takes opts by value for the reasons its own doc comment
+// gives; Paint does the same so both entry points share one call shape.
+//
+//nolint:gocritic
+func Paint(rows [][]terminal.Run, opts Options, p Painter) {
+	opts = opts.withDefaults()
+	paint(layout(rows, &opts), &opts, p)
+}
+
+// Span is one laid-out run with the colors it paints in resolved: the Text
+// paintRow would hand a Painter for it, plus the Background a Rect would fill
+// behind it and the run's Hyperlink. The Text carries no position, as the
+// spans of a row follow each other cell by cell.
+type Span struct {
+	Text
+	Background *color.RGB
+	Hyperlink  string
+}
+
+// Layout lays rows out exactly like Encode does and resolves every run's
+// colors, for an encoder that flows the text rather than placing shapes on a
+// canvas (see package html). Each output row holds the spans of one canvas
+// row, in order; a run without cells is left out, as it paints nothing. It
+// also returns opts with every default filled in, so the caller paints the
+// page behind the spans in the same colors Encode fills its window with.
+//
+//nolint:gocritic
+func Layout(rows [][]terminal.Run, opts Options) ([][]Span, Options) {
+	opts = opts.withDefaults()
+	return resolveSpans(layout(rows, &opts), &opts, false), opts
+}
+
+// Resolve resolves every run's colors the way Layout does, but leaves the
+// rows as they are: no cursor, no watermark, no fitting to the columns. The
+// spans keep the runs without cells too, so spans[i][j] is always rows[i][j],
+// for a host laying the runs out on a canvas of its own.
+//
+//nolint:gocritic
+func Resolve(rows [][]terminal.Run, opts Options) ([][]Span, Options) {
+	opts = opts.withDefaults()
+	return resolveSpans(rows, &opts, true), opts
+}
+
+func resolveSpans(rows [][]terminal.Run, opts *Options, empty bool) [][]Span {
+	spans := make([][]Span, 0, len(rows))
+
+	for _, row := range rows {
+		state := paintState{}
+		line := make([]Span, 0, len(row))
+
+		for i := range row {
+			run := &row[i]
+			textRGB, rectRGB := paintRun(run, &state, opts)
+
+			if run.Cells == 0 && !empty {
+				continue
+			}
+
+			line = append(line, Span{
+				Text: Text{
+					Text:       run.Text,
+					Cells:      run.Cells,
+					Fill:       textRGB,
+					Attributes: run.Attributes,
+				},
+				Background: rectRGB,
+				Hyperlink:  run.Hyperlink,
+			})
+		}
+
+		spans = append(spans, line)
+	}
+
+	return spans
+}
+
+// layout decorates rows with the cursor and watermark, then fits them to the
+// canvas - see Encode's doc comment on why in that order.
+func layout(rows [][]terminal.Run, opts *Options) [][]terminal.Run {
+	return fitRows(decorate(rows, opts.Cursor), opts.Columns)
+}
+
+// paint walks already laid-out rows; opts must have been through withDefaults.
+func paint(rows [][]terminal.Run, opts *Options, p Painter) {
+	geo := newWindowGeometry(opts)
+	size := newCanvasSize(&geo, opts.Columns, opts.CellWidth, len(rows), opts.LineHeight)
+
+	p.Begin(Canvas{
+		Width:     size.width,
+		Height:    size.height,
+		FontSize:  opts.FontSize,
+		CellWidth: opts.CellWidth,
+	})
+
+	// The window's own fill is always opts.CanvasBackground: withDefaults
+	// has already folded a known TerminalBackground into it (see Options.
+	// CanvasBackground's doc comment), so there is no separate preference to
+	// apply here.
+	p.Window(newWindow(size, &geo, *opts.CanvasBackground))
+
+	for rowIndex, row := range rows {
+		paintRow(p, row, rowIndex, size, opts)
+	}
+}
+
+// paintRow places one row's runs on the grid. size.contentX/contentY (see
+// canvasSize) is the content grid's top-left corner inside the window.
+func paintRow(p Painter, runs []terminal.Run, rowIndex int, size canvasSize, opts *Options) {
+	state := paintState{}
+	cell := 0
+	rowTop := size.contentY + float64(rowIndex)*opts.LineHeight
+
+	// baseline places the glyphs roughly centered in the row instead of
+	// hugging its top edge (see the "hanging" removal note on Encode).
+	baseline := rowTop + opts.LineHeight*baselineRatio
+
+	// A segment's background fills the box its own separator glyphs ink, not
+	// the row box — see Options.FillAscent/FillDescent.
+	fillTop := baseline - opts.FillAscent
+	fillHeight := opts.FillAscent + opts.FillDescent
+
+	for i := range runs {
+		run := &runs[i]
+		textRGB, rectRGB := paintRun(run, &state, opts)
+
+		if run.Cells == 0 {
+			continue
+		}
+
+		x := size.contentX + float64(cell)*opts.CellWidth
+		runWidth := float64(run.Cells) * opts.CellWidth
+
+		if rectRGB != nil {
+			p.Rect(Rect{X: x, Y: fillTop, Width: runWidth, Height: fillHeight, Fill: *rectRGB})
+		}
+
+		if run.Text != "" {
+			p.Text(Text{
+				Text:       run.Text,
+				X:          x,
+				Baseline:   baseline,
+				Width:      runWidth,
+				Cells:      run.Cells,
+				Fill:       textRGB,
+				Attributes: run.Attributes,
+			})
+		}
+
+		cell += run.Cells
+	}
+}
@@ -276,7 +276,7 @@ func (o *Options) withDefaults() Options {
 // <style> block fighting its own page styles. Whitespace is the exception —
 // it is declared per <text> instead, see writeText. Each <text>
 // element carries no dominant-baseline/alignment-baseline attribute at all,
-// so it renders on SVG's default alphabetic baseline; encodeRow computes
+// so it renders on SVG's default alphabetic baseline; paintRow computes
 // that baseline's y itself (rowTop + 0.75*LineHeight, see writeText) rather
 // than anchoring to a rect edge via "hanging", because this package has no
 // server-side font metrics and "hanging" baseline placement is implemented
@@ -301,12 +301,12 @@ func (o *Options) withDefaults() Options {
 func Encode(rows [][]terminal.Run, opts Options) string {
 	opts = opts.withDefaults()
 	// Decorate first, fit second: Options.Cursor indexes the captured rows,
-	// and fitRow is free to collapse gaps and wrap a row into several, which
+	// and FitRow is free to collapse gaps and wrap a row into several, which
 	// would invalidate that index. Doing it in this order also means the
 	// cursor is fitted like any other content — though insertCursor takes its
 	// cell out of the following alignment gap where there is one, so in
 	// practice a row that fitted before still fits.
-	rows = fitRows(decorate(rows, opts.Cursor), opts.Columns)
+	rows = layout(rows, &opts)
 
 	blinks := false
 	hasher := fnv.New64a()
@@ -327,39 +327,16 @@ func Encode(rows [][]terminal.Run, opts Options) string {
 		blinkClass = "omp-blink-" + strconv.FormatUint(hasher.Sum64(), 36)
 	}
 
-	geo := newWindowGeometry(&opts)
-	size := newCanvasSize(&geo, opts.Columns, opts.CellWidth, len(rows), opts.LineHeight)
-
 	var b strings.Builder
 
-	// A single format string in place of the interleaved WriteString calls
-	// this root tag used to be built from — see writeRect's doc comment on
-	// why: Encode runs once per prompt, so there is no allocation budget
-	// this would need to respect.
-	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="%s" font-size="%spx">`+"\n",
-		formatFloat(size.width), formatFloat(size.height), formatFloat(size.width), formatFloat(size.height),
-		escapeAttr(opts.FontFamily), formatFloat(opts.FontSize))
-
-	if blinkClass != "" {
-		writeBlinkStyle(&b, blinkClass)
-	}
-
-	// The window's own fill is always opts.CanvasBackground: withDefaults
-	// has already folded a known TerminalBackground into it (see Options.
-	// CanvasBackground's doc comment), so there is no separate preference to
-	// apply here.
-	writeWindowChrome(&b, size, &geo, *opts.CanvasBackground)
-
-	for rowIndex, row := range rows {
-		encodeRow(&b, row, rowIndex, size, opts, blinkClass)
-	}
+	paint(rows, &opts, &svgPainter{b: &b, opts: &opts, blinkClass: blinkClass})
 
 	b.WriteString("</svg>")
 
 	return b.String()
 }
 
-// fitRows applies fitRow (see fit.go) to every captured row, flattening any
+// fitRows applies FitRow (see fit.go) to every captured row, flattening any
 // row that had to be collapsed/wrapped into several output rows. columns
 // must be positive — Encode's own call site always passes a post-
 // withDefaults Columns of at least 1 (see its doc comment) — a caller
@@ -368,7 +345,7 @@ func fitRows(rows [][]terminal.Run, columns int) [][]terminal.Run {
 	fitted := make([][]terminal.Run, 0, len(rows))
 
 	for _, row := range rows {
-		fitted = append(fitted, fitRow(row, columns)...)
+		fitted = append(fitted, FitRow(row, columns)...)
 	}
 
 	return fitted
@@ -394,49 +371,37 @@ type paintState struct {
 	bg *color.RGB
 }
 
-// encodeRow takes opts by value for the same reason Encode does (see its
-// comment): it runs once per row, not once per Run, so the 80-byte copy
-// gocritic flags is immaterial. size.contentX/contentY (see canvasSize) is
-// the content grid's top-left corner inside the window, replacing what used
-// to be a flat opts.Padding offset from the canvas edge before the window
-// chrome existed.
-//
-//nolint:gocritic
-func encodeRow(b *strings.Builder, runs []terminal.Run, rowIndex int, size canvasSize, opts Options, blinkClass string) {
-	state := paintState{}
-	cell := 0
-	rowTop := size.contentY + float64(rowIndex)*opts.LineHeight
-
-	// baseline places the glyphs roughly centered in the row instead of
-	// hugging its top edge (see the "hanging" removal note on Encode).
-	baseline := rowTop + opts.LineHeight*baselineRatio
-
-	// A segment's background fills the box its own separator glyphs ink, not
-	// the row box — see Options.FillAscent/FillDescent.
-	fillTop := baseline - opts.FillAscent
-	fillHeight := opts.FillAscent + opts.FillDescent
-
-	for i := range runs {
-		run := &runs[i]
-		textRGB, rectRGB := paintRun(run, &state, &opts)
-
-		if run.Cells == 0 {
-			continue
-		}
+// svgPainter is Encode's own Painter: it writes every shape as markup into b.
+type svgPainter struct {
+	b          *strings.Builder
+	opts       *Options
+	blinkClass string
+}
 
-		x := size.contentX + float64(cell)*opts.CellWidth
-		runWidth := float64(run.Cells) * opts.CellWidth
+// Begin writes the root <svg> element, as a single format string in place of
+// the interleaved WriteString calls it used to be built from — see
+// writeRect's doc comment on why: Encode runs once per prompt, so there is no
+// allocation budget this would need to respect.
+func (p *svgPainter) Begin(canvas Canvas) {
+	fmt.Fprintf(p.b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="%s" font-size="%spx">`+"\n",
+		formatFloat(canvas.Width), formatFloat(canvas.Height), formatFloat(canvas.Width), formatFloat(canvas.Height),
+		escapeAttr(p.opts.FontFamily), formatFloat(canvas.FontSize))
+
+	if p.blinkClass != "" {
+		writeBlinkStyle(p.b, p.blinkClass)
+	}
+}
 
-		if rectRGB != nil {
-			writeRect(b, x, fillTop, runWidth, fillHeight, *rectRGB)
-		}
+func (p *svgPainter) Window(window Window) {
+	writeWindowChrome(p.b, &window)
+}
 
-		if run.Text != "" {
-			writeText(b, run.Text, x, baseline, runWidth, textRGB, run.Attributes, blinkClass)
-		}
+func (p *svgPainter) Rect(rect Rect) {
+	writeRect(p.b, rect.X, rect.Y, rect.Width, rect.Height, rect.Fill)
+}
 
-		cell += run.Cells
-	}
+func (p *svgPainter) Text(text Text) {
+	writeText(p.b, text.Text, text.X, text.Baseline, text.Width, text.Fill, text.Attributes, p.blinkClass)
 }
 
 // writeRect writes a self-closing <rect> element via a single format string
@@ -450,7 +415,7 @@ func writeRect(b *strings.Builder, x, y, width, height float64, rgb color.RGB) {
 }
 
 // writeText writes a <text> element whose y is already the baseline
-// coordinate (see encodeRow's baseline), not a rect's top edge: the element
+// coordinate (see paintRow's baseline), not a rect's top edge: the element
 // deliberately carries no dominant-baseline attribute, so it renders on
 // SVG's default alphabetic baseline (see Encode's doc comment).
 //
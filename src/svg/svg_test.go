@@ -16,7 +16,7 @@ import (
 
 // testOptions fixes every metric so pixel math in assertions is exact and
 // readable: a 10px cell, a 20px row, and a fixed Columns wide enough that no
-// existing test's row gets wrapped by fitRow (see fit.go) — tests that
+// existing test's row gets wrapped by FitRow (see fit.go) — tests that
 // specifically exercise wrapping set their own, narrower Columns. The window
 // padding these assertions are computed against isn't set here at all: it
 // comes from windowGeometry, scaled from FontSize (see newWindowGeometry) —
@@ -600,3 +600,48 @@ func TestEncodeCursorWithoutAnchor(t *testing.T) {
 
 	assert.Contains(t, doc, `x="`+formatFloat(originX+6*testOptions().CellWidth)+`" y="`+y+`" textLength="10" lengthAdjust="spacingAndGlyphs" fill="#ffffff">_</text>`)
 }
+
+// TestLayoutResolvesSpans pins Layout against Encode's own paint order: a zero
+// cell run is left out, every other run's colors are resolved the way paintRun
+// resolves them, and its hyperlink travels along.
+func TestLayoutResolvesSpans(t *testing.T) {
+	rows := [][]terminal.Run{{
+		{Text: "a", Cells: 1, ForegroundSource: "#ff0000", BackgroundSource: "#0000ff"},
+		{Text: "", Cells: 0, ForegroundSource: "#00ff00"},
+		{Text: "docs", Cells: 4, Hyperlink: "https://ohmyposh.dev"},
+	}}
+
+	spans, opts := Layout(rows, testOptions())
+
+	require.NotEmpty(t, spans)
+	require.Len(t, spans[0], 3, "a, docs and the cursor")
+	assert.Equal(t, "a", spans[0][0].Text.Text)
+	assert.Equal(t, &color.RGB{R: 255}, spans[0][0].Fill)
+	assert.Equal(t, &color.RGB{B: 255}, spans[0][0].Background)
+	assert.Equal(t, "docs", spans[0][1].Text.Text)
+	assert.Equal(t, &color.RGB{G: 255}, spans[0][1].Fill, "the zero cell run still sets the foreground it carries forward")
+	assert.Nil(t, spans[0][1].Background)
+	assert.Equal(t, "https://ohmyposh.dev", spans[0][1].Hyperlink)
+	assert.NotNil(t, opts.CanvasBackground, "the returned options carry their defaults")
+}
+
+func TestResolveKeepsRows(t *testing.T) {
+	rows := [][]terminal.Run{
+		{
+			{Text: "a", Cells: 1, ForegroundSource: "#ff0000"},
+			{Text: "", Cells: 0, ForegroundSource: "#00ff00"},
+		},
+		{{Text: strings.Repeat("b", 40), Cells: 40}},
+	}
+
+	opts := testOptions()
+	opts.Columns = 10
+
+	spans, _ := Resolve(rows, opts)
+
+	require.Len(t, spans, 2, "no row is fitted to the columns")
+	require.Len(t, spans[0], 2, "no cursor, and the run without cells stays")
+	assert.Equal(t, &color.RGB{G: 255}, spans[0][1].Fill)
+	assert.Equal(t, 40, spans[1][0].Cells)
+	assert.Nil(t, spans[1][0].Fill, "a color doesn't carry over into the next row")
+}
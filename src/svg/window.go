@@ -72,7 +72,7 @@ func newWindowGeometry(opts *Options) windowGeometry {
 // with the title bar and content inside it) and the content grid's own
 // top-left corner inside it. The window fills the canvas exactly, so the two
 // share an origin; windowX/windowY are kept rather than folded away because
-// every chrome coordinate is written relative to them.
+// newWindow places the controls relative to them.
 type canvasSize struct {
 	width, height             float64 // the full <svg> canvas
 	windowX, windowY          float64
@@ -127,26 +127,23 @@ func newCanvasSize(geo *windowGeometry, columns int, cellWidth float64, rows int
 // interleaved WriteString calls — see writeRect's doc comment (svg.go) on
 // why: this package renders once per prompt, so the allocation-averse style
 // the interleaved calls used to be written in buys nothing here.
-func writeWindowChrome(b *strings.Builder, size canvasSize, geo *windowGeometry, contentFill color.RGB) {
-	x0, y0 := size.windowX, size.windowY
-	w, h, r := size.windowWidth, size.windowHeight, geo.corner
-
-	header := headerColor(contentFill)
+func writeWindowChrome(b *strings.Builder, window *Window) {
+	w, h, r := window.Width, window.Height, window.Corner
 
 	fmt.Fprintf(b, `<path class="omp-window-header" fill="%s" d="M %s,%s H %s A %s,%s 0 0 1 %s,%s `+
 		`V %s H %s V %s A %s,%s 0 0 1 %s,%s Z"/>`+"\n",
-		hexString(header),
-		formatFloat(x0+r), formatFloat(y0), formatFloat(x0+w-r),
-		formatFloat(r), formatFloat(r), formatFloat(x0+w), formatFloat(y0+r),
-		formatFloat(y0+geo.titleOffset), formatFloat(x0), formatFloat(y0+r),
-		formatFloat(r), formatFloat(r), formatFloat(x0+r), formatFloat(y0))
+		hexString(window.Header),
+		formatFloat(r), formatFloat(0), formatFloat(w-r),
+		formatFloat(r), formatFloat(r), formatFloat(w), formatFloat(r),
+		formatFloat(window.TitleOffset), formatFloat(0), formatFloat(r),
+		formatFloat(r), formatFloat(r), formatFloat(r), formatFloat(0))
 
 	fmt.Fprintf(b, `<path class="omp-window-content" fill="%s" d="M %s,%s H %s V %s A %s,%s 0 0 1 %s,%s `+
 		`H %s A %s,%s 0 0 1 %s,%s Z"/>`+"\n",
-		hexString(contentFill),
-		formatFloat(x0), formatFloat(y0+geo.titleOffset), formatFloat(x0+w), formatFloat(y0+h-r),
-		formatFloat(r), formatFloat(r), formatFloat(x0+w-r), formatFloat(y0+h),
-		formatFloat(x0+r), formatFloat(r), formatFloat(r), formatFloat(x0), formatFloat(y0+h-r))
+		hexString(window.Content),
+		formatFloat(0), formatFloat(window.TitleOffset), formatFloat(w), formatFloat(h-r),
+		formatFloat(r), formatFloat(r), formatFloat(w-r), formatFloat(h),
+		formatFloat(r), formatFloat(r), formatFloat(r), formatFloat(0), formatFloat(h-r))
 
 	// Inset by half the stroke width on every side: a rect stroked exactly on
 	// the canvas edge (x=0,y=0,width=w,height=h) has its outer half clipped
@@ -156,19 +153,54 @@ func writeWindowChrome(b *strings.Builder, size canvasSize, geo *windowGeometry,
 	// page background behind it and there is nothing else to read as a
 	// border. Insetting keeps the full stroke width inside the viewBox on
 	// every edge instead of only the top/left ever reading as intended.
-	half := geo.strokeWidth / 2
+	half := window.StrokeWidth / 2
 
 	fmt.Fprintf(b, `<rect class="omp-window" x="%s" y="%s" width="%s" height="%s" rx="%s" fill="none" `+
-		`stroke="#404040" stroke-width="%s"/>`+"\n",
-		formatFloat(x0+half), formatFloat(y0+half), formatFloat(w-geo.strokeWidth), formatFloat(h-geo.strokeWidth),
-		formatFloat(r), formatFloat(geo.strokeWidth))
+		`stroke="%s" stroke-width="%s"/>`+"\n",
+		formatFloat(half), formatFloat(half), formatFloat(w-window.StrokeWidth), formatFloat(h-window.StrokeWidth),
+		formatFloat(r), hexString(window.Stroke), formatFloat(window.StrokeWidth))
+
+	writeWindowControls(b, &window.Controls)
+}
+
+// newWindow resolves the chrome writeWindowChrome draws - and any other
+// Painter fills - for a canvas of the given size.
+func newWindow(size canvasSize, geo *windowGeometry, contentFill color.RGB) Window {
+	header := headerColor(contentFill)
+
+	window := Window{
+		Width:       size.windowWidth,
+		Height:      size.windowHeight,
+		TitleOffset: geo.titleOffset,
+		Corner:      geo.corner,
+		StrokeWidth: geo.strokeWidth,
+		Header:      header,
+		Content:     contentFill,
+		Stroke:      windowStroke,
+	}
 
-	barMidY := y0 + geo.titleOffset/2
-	rightEdge := x0 + w
+	barMidY := size.windowY + geo.titleOffset/2
+	rightEdge := size.windowX + size.windowWidth
+	labels := [3]string{"−", "▢", "×"}
+
+	// Left to right in both position and paint order, so close always lands
+	// flush against the right edge regardless of how many controls precede it.
+	for i, label := range labels {
+		window.Controls[i] = Control{
+			Label:    label,
+			X:        rightEdge - geo.padding*1.0 - float64(len(labels)-1-i)*geo.controlGap,
+			Baseline: controlBaselineY(barMidY, geo.controlSize),
+			Size:     geo.controlSize,
+			Fill:     controlColor(header),
+		}
+	}
 
-	writeWindowControls(b, geo, barMidY, rightEdge, controlColor(header))
+	return window
 }
 
+// windowStroke is the window border's fixed color.
+var windowStroke = color.RGB{R: 0x40, G: 0x40, B: 0x40}
+
 // controlBaselineY centers a glyph of the given font-size on midY: a fixed
 // fraction of font-size (rather than a per-caller ratio) below the visual
 // center line, close enough to the actual cap-height midpoint for the thin
@@ -178,17 +210,10 @@ func controlBaselineY(midY, fontSize float64) float64 {
 	return midY + fontSize*0.35
 }
 
-// writeWindowControls draws the minimize/maximize/close glyphs left to
-// right in both position and document order, so close always lands flush
-// against the right edge regardless of how many controls precede it.
-func writeWindowControls(b *strings.Builder, geo *windowGeometry, barMidY, rightEdge float64, fill color.RGB) {
-	labels := [3]string{"−", "▢", "×"}
-	fontSize := geo.controlSize
-
-	for i, label := range labels {
-		cx := rightEdge - geo.padding*1.0 - float64(len(labels)-1-i)*geo.controlGap
+func writeWindowControls(b *strings.Builder, controls *[3]Control) {
+	for _, control := range controls {
 		fmt.Fprintf(b, `<text xml:space="preserve" class="omp-window-control" x="%s" y="%s" font-size="%spx" fill="%s" text-anchor="middle">%s</text>`+"\n",
-			formatFloat(cx), formatFloat(controlBaselineY(barMidY, fontSize)), formatFloat(fontSize), hexString(fill), label)
+			formatFloat(control.X), formatFloat(control.Baseline), formatFloat(control.Size), hexString(control.Fill), control.Label)
 	}
 }
 
@@ -94,8 +94,13 @@ func loadCache(vars maps.Simple[any], aliases *maps.Config) {
 		tmpl.SHLVL = shlvl
 	}
 
+	tmpl.TerminalBackground = env.TerminalBackground()
+
 	overlayEnvData(tmpl)
 
+	// derived after the overlay, so a data file's background decides it
+	tmpl.IsDarkBackground = isDarkBackground(tmpl.TerminalBackground)
+
 	// Alias mapping must apply to a data-provided value exactly as it does to
 	// a live one, so it runs after the overlay, on whichever value won.
 	tmpl.Shell = aliases.GetShellName(tmpl.Shell)
@@ -144,6 +149,24 @@ func overlayEnvData(tmpl *cache.Template) {
 	}
 }
 
+// isDarkBackground weighs the channels of a #rrggbb color by how bright they
+// look. An unknown or unreadable color counts as dark.
+func isDarkBackground(background string) bool {
+	hex, found := strings.CutPrefix(background, "#")
+	if !found || len(hex) != 6 {
+		return true
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return true
+	}
+
+	r, g, b := value>>16&0xff, value>>8&0xff, value&0xff
+
+	return 299*r+587*g+114*b < 128*1000
+}
+
 func restoreCache() bool {
 	defer log.Trace(time.Now())
 
@@ -29,6 +29,7 @@ func newLoadCacheMockEnv(flags *runtime.Flags) *mock.Environment {
 	env.On("GOOS").Return(runtime.DARWIN)
 	env.On("Platform").Return("darwin")
 	env.On("Getenv", "SHLVL").Return("1")
+	env.On("TerminalBackground").Return("")
 
 	return env
 }
@@ -151,3 +152,38 @@ func TestLoadCacheInvalidEnvData(t *testing.T) {
 	assert.Equal(t, "live-host", Cache.HostName)
 	assert.Equal(t, "pwsh", Cache.Shell)
 }
+
+func TestLoadCacheTerminalBackground(t *testing.T) {
+	cases := []struct {
+		Case       string
+		Live       string
+		EnvData    string
+		Background string
+		IsDark     bool
+	}{
+		{Case: "unknown", IsDark: true},
+		{Case: "dark", Live: "#1e1e2e", Background: "#1e1e2e", IsDark: true},
+		{Case: "light", Live: "#fdf6e3", Background: "#fdf6e3"},
+		{Case: "mid gray", Live: "#808080", Background: "#808080"},
+		{Case: "data overrides live", Live: "#1e1e2e", EnvData: `{"TerminalBackground":"#ffffff"}`, Background: "#ffffff"},
+		{Case: "data can't set the derived value", EnvData: `{"IsDarkBackground":false}`, IsDark: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			flags := &runtime.Flags{}
+			if tc.EnvData != "" {
+				flags.EnvData = json.RawMessage(tc.EnvData)
+			}
+
+			env = newLoadCacheMockEnv(flags)
+			env.(*mock.Environment).Unset("TerminalBackground")
+			env.(*mock.Environment).On("TerminalBackground").Return(tc.Live)
+
+			loadCache(nil, &maps.Config{})
+
+			assert.Equal(t, tc.Background, Cache.TerminalBackground)
+			assert.Equal(t, tc.IsDark, Cache.IsDarkBackground)
+		})
+	}
+}
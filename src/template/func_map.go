@@ -197,6 +197,14 @@ var restrictedFuncMap = sync.OnceValue(func() template.FuncMap {
 })
 
 func funcMap(trusted bool) template.FuncMap {
+	if functionMaps != nil {
+		return functionMaps[trusted]
+	}
+
+	return builtinFuncMap(trusted)
+}
+
+func builtinFuncMap(trusted bool) template.FuncMap {
 	if trusted {
 		return sharedFuncMap()
 	}
@@ -0,0 +1,342 @@
+package template
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+	"unicode"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+)
+
+// Function is a template function declared in the config: a template
+// rendered against its parameters, callable from any template like a
+// built-in one.
+type Function struct {
+	Template string   `json:"template" toml:"template" yaml:"template"`
+	Params   []string `json:"params,omitempty" toml:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// functionMaps holds the func maps including the config's functions, one per
+// trust level. Nil when the config declares none, in which case funcMap
+// falls back to the built-in maps.
+var functionMaps map[bool]template.FuncMap
+
+// setFunctions registers the config's functions, dropping every parsed
+// template so none of them keeps a stale func map.
+//
+// A function's body only ever sees its parameters and the func map of the
+// template calling it: from RenderUntrusted that is the restricted one, so a
+// function using cmd, readFile and friends (directly or through another
+// function) simply isn't available there. Invalid functions, those
+// shadowing a built-in and recursive ones are logged and left out.
+func setFunctions(functions map[string]*Function) {
+	functionMaps = nil
+	parsedTemplates = sync.Map{}
+	partialSets = sync.Map{}
+
+	declared := declareFunctions(functions)
+	if len(declared) == 0 {
+		return
+	}
+
+	functionMaps = map[bool]template.FuncMap{
+		true:  newFunctionMap(true, declared),
+		false: newFunctionMap(false, untrustedFunctions(declared)),
+	}
+}
+
+// newFunctionMap extends the built-in func map of the given trust level with
+// the declared functions, their bodies parsed against that same map.
+func newFunctionMap(trusted bool, declared map[string]*declaredFunction) template.FuncMap {
+	fm := maps.Clone(builtinFuncMap(trusted))
+	bodies := make(map[string]*template.Template, len(declared))
+
+	for name, function := range declared {
+		fm[name] = function.call(name, bodies)
+	}
+
+	for name, function := range declared {
+		body, err := template.New(name).Funcs(fm).Parse(function.Template)
+		if err != nil {
+			log.Errorf("invalid function %s: %v", name, err)
+			continue
+		}
+
+		bodies[name] = body
+	}
+
+	return fm
+}
+
+// declaredFunction is a function that parsed, together with the names of the
+// other declared functions its body calls.
+type declaredFunction struct {
+	*Function
+	calls []string
+	// builtins are the built-in functions its body calls
+	builtins []string
+}
+
+func (f *declaredFunction) call(name string, bodies map[string]*template.Template) func(...any) (string, error) {
+	return func(args ...any) (string, error) {
+		if len(args) != len(f.Params) {
+			return "", fmt.Errorf("function %s expects %d arguments, got %d", name, len(f.Params), len(args))
+		}
+
+		body, ok := bodies[name]
+		if !ok {
+			return "", fmt.Errorf("function %s is not available", name)
+		}
+
+		params := make(map[string]any, len(args))
+		for i, param := range f.Params {
+			params[param] = args[i]
+		}
+
+		var text strings.Builder
+		if err := body.Execute(&text, params); err != nil {
+			return "", err
+		}
+
+		return strings.ReplaceAll(text.String(), "<no value>", ""), nil
+	}
+}
+
+// templateBuiltins are the functions text/template itself defines, which a
+// func map would silently override.
+var templateBuiltins = []string{
+	"and", "call", "html", "index", "slice", "js", "len", "not", "or", "print", "printf", "println", "urlquery",
+	"eq", "ge", "gt", "le", "lt", "ne",
+}
+
+// declareFunctions parses every function against the trusted func map and
+// drops the ones that can't be used: a bad name, a parse error, a call to a
+// dropped function or a call cycle, which would otherwise recurse until the
+// stack runs out.
+func declareFunctions(functions map[string]*Function) map[string]*declaredFunction {
+	builtins := builtinFuncMap(true)
+	stubs := maps.Clone(builtins)
+	names := map[string]bool{}
+
+	for name, function := range functions {
+		if function == nil || !isIdentifier(name) {
+			log.Errorf("invalid function name %s", name)
+			continue
+		}
+
+		if _, ok := builtins[name]; ok || slices.Contains(templateBuiltins, name) {
+			log.Errorf("function %s shadows a built-in function", name)
+			continue
+		}
+
+		stubs[name] = func(...any) string { return "" }
+		names[name] = true
+	}
+
+	declared := make(map[string]*declaredFunction, len(functions))
+
+	for name, function := range functions {
+		if !names[name] {
+			continue
+		}
+
+		tmpl, err := template.New(name).Funcs(stubs).Parse(function.Template)
+		if err != nil {
+			log.Errorf("invalid function %s: %v", name, err)
+			continue
+		}
+
+		identifiers := map[string]bool{}
+		for _, tree := range tmpl.Templates() {
+			collectIdentifiers(tree.Root, identifiers)
+		}
+
+		fn := &declaredFunction{Function: function}
+
+		for _, identifier := range slices.Sorted(maps.Keys(identifiers)) {
+			if names[identifier] {
+				fn.calls = append(fn.calls, identifier)
+				continue
+			}
+
+			fn.builtins = append(fn.builtins, identifier)
+		}
+
+		declared[name] = fn
+	}
+
+	var recursive []string
+
+	for name := range declared {
+		if calls(declared, name, name, map[string]bool{}) {
+			recursive = append(recursive, name)
+		}
+	}
+
+	for _, name := range recursive {
+		log.Errorf("function %s is recursive", name)
+		delete(declared, name)
+	}
+
+	// a call to a dropped function still parsed against its stub, drop the
+	// caller too until nothing changes
+	for dropped := true; dropped; {
+		dropped = false
+
+		for name, function := range declared {
+			for _, call := range function.calls {
+				if _, ok := declared[call]; ok {
+					continue
+				}
+
+				log.Errorf("function %s calls unavailable function %s", name, call)
+				delete(declared, name)
+				dropped = true
+
+				break
+			}
+		}
+	}
+
+	return declared
+}
+
+// calls reports whether from reaches target through the declared functions.
+func calls(declared map[string]*declaredFunction, from, target string, visited map[string]bool) bool {
+	function, ok := declared[from]
+	if !ok {
+		return false
+	}
+
+	for _, call := range function.calls {
+		if call == target {
+			return true
+		}
+
+		if visited[call] {
+			continue
+		}
+
+		visited[call] = true
+
+		if calls(declared, call, target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// untrustedFunctions returns the functions whose bodies only call what the
+// restricted func map offers, directly or through other such functions.
+func untrustedFunctions(declared map[string]*declaredFunction) map[string]*declaredFunction {
+	restricted := builtinFuncMap(false)
+	checked := map[string]bool{}
+
+	var isAvailable func(name string) bool
+	isAvailable = func(name string) bool {
+		if available, ok := checked[name]; ok {
+			return available
+		}
+
+		function := declared[name]
+		available := true
+
+		for _, builtin := range function.builtins {
+			if _, ok := restricted[builtin]; !ok && !slices.Contains(templateBuiltins, builtin) {
+				available = false
+				break
+			}
+		}
+
+		// declared functions are acyclic by now, so this ends
+		for _, call := range function.calls {
+			if !available {
+				break
+			}
+
+			available = isAvailable(call)
+		}
+
+		checked[name] = available
+
+		return available
+	}
+
+	untrusted := map[string]*declaredFunction{}
+
+	for name, function := range declared {
+		if isAvailable(name) {
+			untrusted[name] = function
+		}
+	}
+
+	return untrusted
+}
+
+// collectIdentifiers adds the name of every function called in the tree.
+func collectIdentifiers(node parse.Node, identifiers map[string]bool) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+
+		for _, child := range node.Nodes {
+			collectIdentifiers(child, identifiers)
+		}
+	case *parse.ActionNode:
+		collectIdentifiers(node.Pipe, identifiers)
+	case *parse.PipeNode:
+		if node == nil {
+			return
+		}
+
+		for _, cmd := range node.Cmds {
+			collectIdentifiers(cmd, identifiers)
+		}
+	case *parse.CommandNode:
+		for _, arg := range node.Args {
+			collectIdentifiers(arg, identifiers)
+		}
+	case *parse.IdentifierNode:
+		identifiers[node.Ident] = true
+	case *parse.ChainNode:
+		collectIdentifiers(node.Node, identifiers)
+	case *parse.IfNode:
+		collectBranch(&node.BranchNode, identifiers)
+	case *parse.RangeNode:
+		collectBranch(&node.BranchNode, identifiers)
+	case *parse.WithNode:
+		collectBranch(&node.BranchNode, identifiers)
+	case *parse.TemplateNode:
+		collectIdentifiers(node.Pipe, identifiers)
+	}
+}
+
+func collectBranch(node *parse.BranchNode, identifiers map[string]bool) {
+	collectIdentifiers(node.Pipe, identifiers)
+	collectIdentifiers(node.List, identifiers)
+	collectIdentifiers(node.ElseList, identifiers)
+}
+
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,167 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderFunctions(t *testing.T) {
+	cases := []struct {
+		Functions   map[string]*Function
+		Context     any
+		Case        string
+		Template    string
+		Expected    string
+		ShouldError bool
+		Untrusted   bool
+	}{
+		{
+			Case:      "no parameters",
+			Functions: map[string]*Function{"greet": {Template: "hello world"}},
+			Template:  "{{ greet }}",
+			Expected:  "hello world",
+		},
+		{
+			Case: "parameters",
+			Functions: map[string]*Function{
+				"short": {Template: "{{ trunc .max .text }}", Params: []string{"text", "max"}},
+			},
+			Template: "{{ short .Branch 4 }}",
+			Context:  struct{ Branch string }{Branch: "feature/functions"},
+			Expected: "feat",
+		},
+		{
+			Case: "pipeline",
+			Functions: map[string]*Function{
+				"shout": {Template: "{{ upper .text }}!", Params: []string{"text"}},
+			},
+			Template: `{{ "hi" | shout }}`,
+			Expected: "HI!",
+		},
+		{
+			Case: "function calling a function",
+			Functions: map[string]*Function{
+				"brackets": {Template: "[{{ .text }}]", Params: []string{"text"}},
+				"label":    {Template: `{{ brackets (lower .text) }}`, Params: []string{"text"}},
+			},
+			Template: `{{ label "MAIN" }}`,
+			Expected: "[main]",
+		},
+		{
+			Case: "wrong argument count",
+			Functions: map[string]*Function{
+				"short": {Template: "{{ trunc .max .text }}", Params: []string{"text", "max"}},
+			},
+			Template:    `{{ short "main" }}`,
+			ShouldError: true,
+		},
+		{
+			Case:        "shadowing a built-in",
+			Functions:   map[string]*Function{"trunc": {Template: "nope"}},
+			Template:    `{{ trunc 2 "main" }}`,
+			Expected:    "ma",
+			ShouldError: false,
+		},
+		{
+			Case:        "shadowing a text/template built-in",
+			Functions:   map[string]*Function{"len": {Template: "nope"}},
+			Template:    `{{ len "main" }}`,
+			Expected:    "4",
+			ShouldError: false,
+		},
+		{
+			Case:        "invalid body",
+			Functions:   map[string]*Function{"broken": {Template: "{{ .text "}},
+			Template:    `{{ broken }}`,
+			ShouldError: true,
+		},
+		{
+			Case: "calling an invalid function",
+			Functions: map[string]*Function{
+				"broken": {Template: "{{ .text "},
+				"caller": {Template: "{{ broken }}"},
+			},
+			Template:    `{{ caller }}`,
+			ShouldError: true,
+		},
+		{
+			Case: "recursive",
+			Functions: map[string]*Function{
+				"ping": {Template: "{{ pong }}"},
+				"pong": {Template: "{{ ping }}"},
+			},
+			Template:    `{{ ping }}`,
+			ShouldError: true,
+		},
+		{
+			Case: "untrusted",
+			Functions: map[string]*Function{
+				"shout": {Template: "{{ upper .text }}!", Params: []string{"text"}},
+			},
+			Template:  `{{ shout "hi" }}`,
+			Expected:  "HI!",
+			Untrusted: true,
+		},
+		{
+			Case: "untrusted using cmd",
+			Functions: map[string]*Function{
+				"whoami": {Template: `{{ cmd "whoami" }}`},
+			},
+			Template:    `{{ whoami }}`,
+			ShouldError: true,
+			Untrusted:   true,
+		},
+		{
+			Case: "untrusted using cmd through another function",
+			Functions: map[string]*Function{
+				"whoami": {Template: `{{ cmd "whoami" }}`},
+				"user":   {Template: `{{ whoami }}`},
+			},
+			Template:    `{{ user }}`,
+			ShouldError: true,
+			Untrusted:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Shell").Return("foo")
+
+		Cache = new(cache.Template)
+		Init(env, nil, nil)
+		Extend(&Extensions{Functions: tc.Functions})
+
+		render := RenderTrusted
+		if tc.Untrusted {
+			render = RenderUntrusted
+		}
+
+		text, err := render(tc.Template, tc.Context)
+		if tc.ShouldError {
+			assert.Error(t, err, tc.Case)
+			continue
+		}
+
+		assert.NoError(t, err, tc.Case)
+		assert.Equal(t, tc.Expected, text, tc.Case)
+	}
+}
+
+func TestFunctionsInPartials(t *testing.T) {
+	env := new(mock.Environment)
+	env.On("Shell").Return("foo")
+
+	Cache = new(cache.Template)
+	partials := map[string]string{"branch": "{{ shout .Branch }}"}
+	functions := map[string]*Function{"shout": {Template: "{{ upper .text }}", Params: []string{"text"}}}
+	Init(env, nil, nil)
+	Extend(&Extensions{Partials: partials, Functions: functions})
+
+	text, err := RenderTrusted(`{{ template "branch" . }}`, struct{ Branch string }{Branch: "main"})
+	assert.NoError(t, err)
+	assert.Equal(t, "MAIN", text)
+}
@@ -30,6 +30,9 @@ var (
 	parsedTemplates sync.Map
 )
 
+// Init prepares the template engine for the given environment and the
+// config's variables and aliases. It clears the config's extensions, see
+// Extend.
 func Init(environment runtime.Environment, vars maps.Simple[any], aliases *maps.Config) {
 	env = environment
 	shell = env.Shell()
@@ -37,6 +40,8 @@ func Init(environment runtime.Environment, vars maps.Simple[any], aliases *maps.
 	// Reset per-process caches so tests that call Init multiple times stay isolated.
 	knownFields = sync.Map{}
 	parsedTemplates = sync.Map{}
+	setPartials(nil)
+	setFunctions(nil)
 
 	renderPool = generics.NewPool(func() *renderer {
 		return &renderer{
@@ -56,6 +61,19 @@ func Init(environment runtime.Environment, vars maps.Simple[any], aliases *maps.
 	loadCache(vars, aliases)
 }
 
+// Extensions are what a config adds to the template language.
+type Extensions struct {
+	Partials  map[string]string
+	Functions map[string]*Function
+}
+
+// Extend registers the config's extensions. Call it right after Init, which
+// clears them.
+func Extend(extensions *Extensions) {
+	setPartials(extensions.Partials)
+	setFunctions(extensions.Functions)
+}
+
 var refreshCache bool
 
 // One-shot commands never need this - the cache is per-process by design
@@ -0,0 +1,124 @@
+package template
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+)
+
+// rootTemplate is the name every rendered template is parsed under, so a
+// partial can never take it.
+const rootTemplate = "cache"
+
+var (
+	// partials are the config's named template definitions, available to
+	// every trusted template through {{ template "name" . }}.
+	partials map[string]string
+
+	// Keyed like parsedTemplates but without the template text: a partial is
+	// patched against the context of the template invoking it, so each
+	// context type gets its own parsed set, cloned into every template that
+	// uses it.
+	partialSets sync.Map
+)
+
+// setPartials registers the config's partials, dropping every parsed
+// template so none of them keeps a stale definition.
+func setPartials(definitions map[string]string) {
+	partials = definitions
+	partialSets = sync.Map{}
+	parsedTemplates = sync.Map{}
+}
+
+// newTemplate parses an already patched template. Partials are only ever
+// attached to trusted templates: they are config text, free to use cmd and
+// friends, which RenderUntrusted must never reach.
+func newTemplate(text *Text) (*template.Template, error) {
+	if !text.trusted || len(partials) == 0 || !strings.Contains(text.template, "template") {
+		return template.New(rootTemplate).Funcs(funcMap(text.trusted)).Parse(text.template)
+	}
+
+	set, err := partialSet(text.context).Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	return set.New(rootTemplate).Parse(text.template)
+}
+
+// partialSet parses all partials, patched for the given context, into one
+// template set. A partial that fails to parse is logged and left out, so it
+// only breaks the templates that actually invoke it.
+func partialSet(context any) *template.Template {
+	key := templateCacheKey("", true, context)
+
+	if cached, ok := partialSets.Load(key); ok {
+		return cached.(*template.Template)
+	}
+
+	set := template.New("partials").Funcs(funcMap(true))
+
+	names := make([]string, 0, len(partials))
+	for name := range partials {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		if name == rootTemplate {
+			log.Errorf("partial name %s is reserved", name)
+			continue
+		}
+
+		partial := &Text{
+			template: partials[name],
+			context:  context,
+			trusted:  true,
+		}
+
+		partial.patchTemplate()
+
+		// parse into a scratch clone first: a failed Parse can leave a half
+		// defined template behind in the set it was parsed into
+		scratch, err := set.Clone()
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+
+		if _, err := scratch.New(name).Parse(partial.template); err != nil {
+			log.Errorf("invalid partial %s: %v", name, err)
+			continue
+		}
+
+		set = scratch
+	}
+
+	actual, _ := partialSets.LoadOrStore(key, set)
+	return actual.(*template.Template)
+}
+
+// Check parses text the way a trusted template is parsed, without rendering
+// it, and returns the parse error, like "line 1: unclosed action". functions
+// are the names of the config's own template functions, which a template may
+// call besides the built-in ones.
+func Check(text string, functions ...string) error {
+	fm := maps.Clone(builtinFuncMap(true))
+
+	for _, name := range functions {
+		fm[name] = func(...any) string { return "" }
+	}
+
+	_, err := template.New(rootTemplate).Funcs(fm).Parse(text)
+	if err != nil {
+		return fmt.Errorf("line %s", strings.TrimPrefix(err.Error(), "template: "+rootTemplate+":"))
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPartials(t *testing.T) {
+	cases := []struct {
+		Context     any
+		Partials    map[string]string
+		Case        string
+		Template    string
+		Expected    string
+		ShouldError bool
+	}{
+		{
+			Case:     "simple partial",
+			Partials: map[string]string{"hello": "hello world"},
+			Template: `{{ template "hello" }}`,
+			Expected: "hello world",
+		},
+		{
+			Case:     "partial using the segment context",
+			Partials: map[string]string{"branch": "{{ .Branch }}"},
+			Template: `[{{ template "branch" . }}]`,
+			Context:  struct{ Branch string }{Branch: "main"},
+			Expected: "[main]",
+		},
+		{
+			Case:     "partial using the cache",
+			Partials: map[string]string{"user": "{{ .UserName }}"},
+			Template: `{{ template "user" . }}`,
+			Expected: "jan",
+		},
+		{
+			Case:     "partial calling a partial",
+			Partials: map[string]string{"inner": "{{ .Branch }}", "outer": `<{{ template "inner" . }}>`},
+			Template: `{{ template "outer" . }}`,
+			Context:  struct{ Branch string }{Branch: "dev"},
+			Expected: "<dev>",
+		},
+		{
+			Case:     "invalid partial is left out",
+			Partials: map[string]string{"broken": "{{ .Branch ", "ok": "ok"},
+			Template: `{{ template "ok" }}`,
+			Expected: "ok",
+		},
+		{
+			Case:        "calling an invalid partial",
+			Partials:    map[string]string{"broken": "{{ .Branch "},
+			Template:    `{{ template "broken" . }}`,
+			ShouldError: true,
+		},
+		{
+			Case:        "reserved name",
+			Partials:    map[string]string{rootTemplate: "oops"},
+			Template:    `{{ template "cache" }}`,
+			ShouldError: true,
+		},
+		{
+			Case:        "unknown partial",
+			Template:    `{{ template "missing" }}`,
+			ShouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Shell").Return("foo")
+
+		Cache = new(cache.Template)
+		Cache.UserName = "jan"
+		Init(env, nil, nil)
+		Extend(&Extensions{Partials: tc.Partials})
+
+		text, err := RenderTrusted(tc.Template, tc.Context)
+		if tc.ShouldError {
+			assert.Error(t, err, tc.Case)
+			continue
+		}
+
+		assert.NoError(t, err, tc.Case)
+		assert.Equal(t, tc.Expected, text, tc.Case)
+	}
+}
+
+func TestRenderUntrustedIgnoresPartials(t *testing.T) {
+	env := new(mock.Environment)
+	env.On("Shell").Return("foo")
+
+	Cache = new(cache.Template)
+	Init(env, nil, nil)
+	Extend(&Extensions{Partials: map[string]string{"secret": `{{ cmd "whoami" }}`}})
+
+	_, err := RenderUntrusted(`{{ template "secret" }}`, nil)
+	assert.Error(t, err)
+}
+
+func TestInitClearsPartials(t *testing.T) {
+	env := new(mock.Environment)
+	env.On("Shell").Return("foo")
+
+	Cache = new(cache.Template)
+	Init(env, nil, nil)
+	Extend(&Extensions{Partials: map[string]string{"hello": "hello"}})
+
+	text, err := RenderTrusted(`{{ template "hello" }}`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", text)
+
+	Init(env, nil, nil)
+
+	_, err = RenderTrusted(`{{ template "hello" }}`, nil)
+	assert.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	cases := []struct {
+		Case      string
+		Template  string
+		Expected  string
+		Functions []string
+	}{
+		{Case: "valid", Template: `{{ .Path | trunc 10 }}`},
+		{Case: "unclosed action", Template: "{{ .Path", Expected: "line 1: unclosed action"},
+		{Case: "unknown function", Template: "\n{{ greet }}", Expected: `line 2: function "greet" not defined`},
+		{Case: "config function", Template: "{{ greet .UserName }}", Functions: []string{"greet"}},
+		{Case: "undefined partial only fails rendering", Template: `{{ template "nope" . }}`},
+	}
+
+	for _, tc := range cases {
+		err := Check(tc.Template, tc.Functions...)
+		if tc.Expected == "" {
+			assert.NoError(t, err, tc.Case)
+			continue
+		}
+
+		assert.EqualError(t, err, tc.Expected, tc.Case)
+	}
+}
@@ -106,7 +106,7 @@ func parsedTemplate(text *Text) (*template.Template, error) {
 	text.patchTemplate()
 
 	// Parse into a fresh template with the func map matching this render's trust level.
-	tmpl, err := template.New("cache").Funcs(funcMap(text.trusted)).Parse(text.template)
+	tmpl, err := newTemplate(text)
 	if err != nil {
 		return nil, err
 	}
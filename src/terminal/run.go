@@ -58,10 +58,15 @@ const runAttributeSlots = len(knownStyles)
 // Cells is the run's rendered width, a length delta matching what write() counts toward
 // String()'s returned length; it can be zero (e.g. a hyperlink's URL text, which Text
 // includes but write() never counts toward length) even when Text is non-empty.
+//
+// Hyperlink is the OSC 8 target of the link a run is (part of) the label of, empty
+// outside a link. A link's label is always cut into runs of its own, so an encoder can
+// wrap exactly those runs in an anchor; the target itself is never part of Text.
 type Run struct {
 	BackgroundRGB    *color.RGB
 	ForegroundRGB    *color.RGB
 	Text             string
+	Hyperlink        string
 	Background       color.Ansi
 	Foreground       color.Ansi
 	BackgroundSource color.Ansi
@@ -86,7 +91,9 @@ func Runs() []Run {
 // alongside the writer's own end-of-segment SGR reset and in String()'s defer; attributes
 // is depth's last snapshot, copied into a Run at flushRun. background/foreground/
 // backgroundSource/foregroundSource/mode/backgroundRGB/foregroundRGB are the pending
-// run's style, refreshed by syncPendingStyle after every event that can change it.
+// run's style, refreshed by syncPendingStyle after every event that can change it. url
+// accumulates a link's target between <LINK> and <TEXT>, and hyperlink holds it for
+// every run of the label after that, see startRunHyperlink.
 type runState struct {
 	backgroundRGB    *color.RGB
 	foregroundRGB    *color.RGB
@@ -95,6 +102,8 @@ type runState struct {
 	backgroundSource color.Ansi
 	foregroundSource color.Ansi
 	text             strings.Builder
+	url              strings.Builder
+	hyperlink        string
 	Runs             []Run
 	mode             RunMode
 	cellsAtFlush     int
@@ -117,6 +126,7 @@ func flushRun() {
 	if len(text) != 0 || cells != 0 {
 		runsState.Runs = append(runsState.Runs, Run{
 			Text:             text,
+			Hyperlink:        runsState.hyperlink,
 			Background:       runsState.background,
 			Foreground:       runsState.foreground,
 			BackgroundSource: runsState.backgroundSource,
@@ -133,6 +143,41 @@ func flushRun() {
 	runsState.cellsAtFlush = length
 }
 
+// startRunHyperlink cuts the pending run at a <LINK> anchor, so the label that follows
+// starts a run of its own, and starts collecting the link's target: write() appends the
+// target's runes to url instead of Text. Plain output drops the OSC 8 escape entirely,
+// so there is no link to capture either.
+func startRunHyperlink() {
+	if !CaptureRuns || Plain {
+		return
+	}
+
+	flushRun()
+	runsState.url.Reset()
+}
+
+// labelRunHyperlink completes the target at a <TEXT> anchor: every run of the label
+// after it carries the target as its Hyperlink.
+func labelRunHyperlink() {
+	if !CaptureRuns || Plain {
+		return
+	}
+
+	runsState.hyperlink = runsState.url.String()
+	runsState.url.Reset()
+}
+
+// endRunHyperlink cuts the label at a </TEXT> or </LINK> anchor, so the text after it is
+// no longer part of the link.
+func endRunHyperlink() {
+	if !CaptureRuns || len(runsState.hyperlink) == 0 {
+		return
+	}
+
+	flushRun()
+	runsState.hyperlink = ""
+}
+
 // syncPendingStyle snapshots cs's current active style — background/foreground/source,
 // the render-mode discriminator, and the attribute nesting depth — as what governs
 // whatever text comes next, and clears the gradient RGB (stampGradient sets it again,
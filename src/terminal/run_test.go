@@ -257,9 +257,9 @@ func TestRunModeDiscriminatesTransparentRenderings(t *testing.T) {
 }
 
 // TestRunHyperlinkFlush covers a hyperlink transition inside a captured segment: no color
-// change happens across <LINK>/<TEXT>/</LINK>, so the anchor text and the surrounding text
-// stay in ONE run — and the URL runes appear in neither Text nor Cells, because a terminal
-// consumes them as part of the OSC 8 escape rather than painting them.
+// change happens across <LINK>/<TEXT>/</LINK>, yet the label is cut into a run of its own
+// carrying the URL as its Hyperlink - and the URL runes appear in neither Text nor Cells,
+// because a terminal consumes them as part of the OSC 8 escape rather than painting them.
 func TestRunHyperlinkFlush(t *testing.T) {
 	saveRunTestGlobals(t)
 
@@ -274,15 +274,15 @@ func TestRunHyperlinkFlush(t *testing.T) {
 	runs := Runs()
 	_, length := String()
 
-	require.Len(t, runs, 1, "no color change occurs across the hyperlink boundary")
-	assert.Equal(t, "abcd", runs[0].Text, "Text is what gets painted: the URL is not")
-	assert.Equal(t, 4, runs[0].Cells, "the URL must not inflate Cells: only a, b, c, d are counted")
+	require.Len(t, runs, 3, "the label is cut from the text around it")
+	assert.Equal(t, []string{"a", "bc", "d"}, []string{runs[0].Text, runs[1].Text, runs[2].Text}, "Text is what gets painted: the URL is not")
+	assert.Equal(t, []string{"", "http://x", ""}, []string{runs[0].Hyperlink, runs[1].Hyperlink, runs[2].Hyperlink})
+	assert.Equal(t, 2, runs[1].Cells, "the URL must not inflate Cells: only b and c are counted")
 	assert.Equal(t, 4, length)
 }
 
 // TestRunHyperlinkNoTextFallback covers writeBody's "link" no-text fallback under
-// capture: per the design, this is an emit of 4 cells, not a flush, so it stays part of
-// whatever run is already accumulating.
+// capture: the 4 cells it emits are the link's label, so they form the linked run.
 func TestRunHyperlinkNoTextFallback(t *testing.T) {
 	saveRunTestGlobals(t)
 
@@ -297,9 +297,11 @@ func TestRunHyperlinkNoTextFallback(t *testing.T) {
 	runs := Runs()
 	_, length := String()
 
-	require.Len(t, runs, 1)
-	assert.Equal(t, "ablinkcd", runs[0].Text, "the 4-cell \"link\" fallback is painted, the URL is not")
-	assert.Equal(t, 8, runs[0].Cells, "ab (2) + the 4-cell 'link' fallback + cd (2) = 8")
+	require.Len(t, runs, 3)
+	assert.Equal(t, "link", runs[1].Text, "the 4-cell \"link\" fallback is painted, the URL is not")
+	assert.Equal(t, "http://x", runs[1].Hyperlink)
+	assert.Equal(t, 4, runs[1].Cells)
+	assert.Empty(t, runs[2].Hyperlink, "the link ends with its label")
 	assert.Equal(t, 8, length)
 }
 
@@ -344,6 +346,7 @@ func TestRunGradientHyperlinkNoTextFallback(t *testing.T) {
 	require.NotNil(t, linkRun, "the 4-cell fallback must appear as its own run's text")
 	assert.Equal(t, 4, linkRun.Cells)
 	assert.NotNil(t, linkRun.BackgroundRGB, "stampGradient runs once for the fallback, stamping one cell for all 4 characters")
+	assert.Equal(t, "http://x", linkRun.Hyperlink)
 }
 
 // TestRunInvisibleSpanIsSticky pins the isInvisible-transition case: a
@@ -402,6 +405,7 @@ func TestRunPlainMode(t *testing.T) {
 	assert.Equal(t, 6, length)
 	assert.Equal(t, 6, runs[0].Cells)
 	assert.NotContains(t, runs[0].Text, "google.be")
+	assert.Empty(t, runs[0].Hyperlink, "Plain output has no link to follow")
 }
 
 // TestRunShellEscapingMirrorsBuilder covers the shell-rewrite paths write() applies
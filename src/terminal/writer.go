@@ -431,7 +431,7 @@ func FormatTitle(title string) string {
 	// These shells don't support setting the console title.
 	case shell.ELVISH, shell.XONSH:
 		return ""
-	case shell.BASH, shell.ZSH, shell.YASH:
+	case shell.BASH, shell.ZSH, shell.YASH, shell.OSH, shell.YSH, shell.TCSH:
 		title = trimAnsi(title)
 
 		sb := text.NewBuilder()
@@ -466,21 +466,27 @@ func RestoreCursorPosition() string {
 }
 
 func PromptStart() string {
-	return fmt.Sprintf(formats.Escape, "\x1b]133;A\007")
+	return fmt.Sprintf(formats.Mark, shell.PromptStartMark)
 }
 
 func CommandStart() string {
-	return fmt.Sprintf(formats.Escape, "\x1b]133;B\007")
+	return fmt.Sprintf(formats.Mark, shell.CommandStartMark)
 }
 
 func CommandFinished(code int, ignore bool) string {
 	if ignore {
-		return fmt.Sprintf(formats.Escape, "\x1b]133;D\007")
+		return fmt.Sprintf(formats.Mark, fmt.Sprintf(shell.CommandFinishedMark, ""))
 	}
 
-	mark := fmt.Sprintf("\x1b]133;D;%d\007", code)
+	mark := fmt.Sprintf(shell.CommandFinishedMark, fmt.Sprintf(";%d", code))
 
-	return fmt.Sprintf(formats.Escape, mark)
+	return fmt.Sprintf(formats.Mark, mark)
+}
+
+// RPromptStart marks the start of a right prompt, the k=r kind of the
+// semantic prompt proposal's explicit prompt start.
+func RPromptStart() string {
+	return fmt.Sprintf(formats.Mark, shell.RPromptStartMark)
 }
 
 func LineBreak() string {
@@ -645,6 +651,7 @@ func Write(background, foreground color.Ansi, txt string) {
 	// print the hyperlink part AFTER the coloring
 	if match.ok && match.Anchor == hyperLinkStart {
 		isHyperlink = true
+		startRunHyperlink()
 		writeHyperlinkEscape(formats.HyperlinkStart)
 	}
 
@@ -664,6 +671,7 @@ func Write(background, foreground color.Ansi, txt string) {
 		// Write call's runs.
 		flushRun()
 		runsState.depth = [runAttributeSlots]uint8{}
+		runsState.hyperlink = ""
 	}
 
 	// reset colors
@@ -699,12 +707,14 @@ func writeBody(txt string, background color.Ansi, cs *colorState) {
 		case anchorHyperlinkStart:
 			isHyperlink = true
 			i += len(match.Anchor)
+			startRunHyperlink()
 			writeHyperlinkEscape(formats.HyperlinkStart)
 			continue
 		case anchorHyperlinkText:
 			isHyperlink = false
 			i += len(match.Anchor)
 			hyperlinkTextPosition = i
+			labelRunHyperlink()
 			writeHyperlinkEscape(formats.HyperlinkCenter)
 			continue
 		case anchorHyperlinkTextEnd:
@@ -718,9 +728,11 @@ func writeBody(txt string, background color.Ansi, cs *colorState) {
 				}
 			}
 			i += len(match.Anchor)
+			endRunHyperlink()
 			continue
 		case anchorHyperlinkEnd:
 			i += len(match.Anchor)
+			endRunHyperlink()
 			writeHyperlinkEscape(formats.HyperlinkEnd)
 			continue
 		case anchorEmpty:
@@ -762,12 +774,14 @@ func writeBodyGradient(txt string, background color.Ansi, cs *colorState) {
 		case anchorHyperlinkStart:
 			isHyperlink = true
 			i += len(match.Anchor)
+			startRunHyperlink()
 			writeHyperlinkEscape(formats.HyperlinkStart)
 			continue
 		case anchorHyperlinkText:
 			isHyperlink = false
 			i += len(match.Anchor)
 			hyperlinkTextPosition = i
+			labelRunHyperlink()
 			writeHyperlinkEscape(formats.HyperlinkCenter)
 			continue
 		case anchorHyperlinkTextEnd:
@@ -783,9 +797,11 @@ func writeBodyGradient(txt string, background color.Ansi, cs *colorState) {
 				}
 			}
 			i += len(match.Anchor)
+			endRunHyperlink()
 			continue
 		case anchorHyperlinkEnd:
 			i += len(match.Anchor)
+			endRunHyperlink()
 			writeHyperlinkEscape(formats.HyperlinkEnd)
 			continue
 		case anchorEmpty:
@@ -829,6 +845,8 @@ func String() (string, int) {
 			// not survive into the next block/rprompt/transient render either.
 			runsState.Runs = runsState.Runs[:0]
 			runsState.text.Reset()
+			runsState.url.Reset()
+			runsState.hyperlink = ""
 			runsState.background, runsState.foreground = "", ""
 			runsState.backgroundSource, runsState.foregroundSource = "", ""
 			runsState.backgroundRGB, runsState.foregroundRGB = nil, nil
@@ -944,13 +962,16 @@ func write(s rune, isInvisible bool) {
 
 		builder.WriteRune(s)
 
-		// Deliberately not captured. These runes are the OSC 8 target, which a terminal
+		// Deliberately kept out of Text. These runes are the OSC 8 target, which a terminal
 		// consumes as part of the escape and never paints; length does not count them, and
-		// neither does Run.Cells. Capturing them anyway put a run's Text out of step with its
+		// neither does Run.Cells. Capturing them as text put a run's Text out of step with its
 		// own Cells, and an encoder that draws Text (see svg.Encode) then printed the URL
 		// alongside the label - the built-in default config renders its path segment as a
 		// hyperlink, so its export read "file:~/dev~/dev". The Run stream describes what is
-		// painted; the URL travels in the ANSI bytes above.
+		// painted; the URL travels as the label runs' Hyperlink instead (see startRunHyperlink).
+		if CaptureRuns {
+			runsState.url.WriteRune(s)
+		}
 
 		return
 	}
@@ -636,6 +636,26 @@ func TestSetCursorStyle(t *testing.T) {
 	Plain = false
 }
 
+func TestShellIntegrationMarks(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Shell    string
+		Expected string
+	}{
+		{Case: "generic", Shell: shell.GENERIC, Expected: "\x1b]133;D;1\a|\x1b]133;D\a|\x1b]133;P;k=r\a"},
+		{Case: "bash", Shell: shell.BASH, Expected: "\\[\x1b]133;D;1\a\\]|\\[\x1b]133;D\a\\]|\\[\x1b]133;P;k=r\a\\]"},
+		{Case: "xonsh", Shell: shell.XONSH, Expected: "\x01\x1b]133;D;1\a\x02|\x01\x1b]133;D\a\x02|\x01\x1b]133;P;k=r\a\x02"},
+	}
+
+	for _, tc := range cases {
+		Init(tc.Shell)
+
+		got := CommandFinished(1, false) + "|" + CommandFinished(1, true) + "|" + RPromptStart()
+
+		assert.Equal(t, tc.Expected, got, tc.Case)
+	}
+}
+
 func TestStripControlRunes(t *testing.T) {
 	cases := []struct {
 		Case     string
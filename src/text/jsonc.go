@@ -6,6 +6,17 @@ import "strings"
 // input so it can be fed to encoding/json. String contents are left
 // untouched, comment markers included.
 func StripJSONComments(src string) string {
+	return strings.TrimSpace(scanJSONComments(src, false))
+}
+
+// BlankJSONComments replaces the comments StripJSONComments removes with
+// spaces, keeping their line breaks, so an offset into the result is the
+// same offset into src - the one a decode error reports.
+func BlankJSONComments(src string) string {
+	return scanJSONComments(src, true)
+}
+
+func scanJSONComments(src string, blank bool) string {
 	var sb strings.Builder
 	sb.Grow(len(src))
 
@@ -37,10 +48,14 @@ func StripJSONComments(src string) string {
 
 			sb.WriteString(src[start:i])
 		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			start := i
 			for i < len(src) && src[i] != '\n' {
 				i++
 			}
+
+			writeBlank(&sb, src[start:i], blank)
 		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			start := i
 			i += 2
 			for i+1 < len(src) && (src[i] != '*' || src[i+1] != '/') {
 				i++
@@ -50,11 +65,30 @@ func StripJSONComments(src string) string {
 			if i > len(src) {
 				i = len(src)
 			}
+
+			writeBlank(&sb, src[start:i], blank)
 		default:
 			sb.WriteByte(c)
 			i++
 		}
 	}
 
-	return strings.TrimSpace(sb.String())
+	return sb.String()
+}
+
+// writeBlank writes a space for every byte of comment but its line breaks,
+// when blank is set.
+func writeBlank(sb *strings.Builder, comment string, blank bool) {
+	if !blank {
+		return
+	}
+
+	for i := range len(comment) {
+		if comment[i] == '\n' {
+			sb.WriteByte('\n')
+			continue
+		}
+
+		sb.WriteByte(' ')
+	}
 }
@@ -61,3 +61,33 @@ func TestStripJSONComments(t *testing.T) {
 	assert.Equal(t, "dark", parsed["theme"])
 	assert.Equal(t, float64(3), parsed["count"])
 }
+
+func TestBlankJSONComments(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Input    string
+		Expected string
+	}{
+		{
+			Case:     "line comment",
+			Input:    "{\n  // comment\n  \"a\": 1\n}",
+			Expected: "{\n            \n  \"a\": 1\n}",
+		},
+		{
+			Case:     "block comment across lines",
+			Input:    "{\"a\": /* é\n */ 1}",
+			Expected: "{\"a\":      \n    1}",
+		},
+		{
+			Case:     "comment markers inside strings survive",
+			Input:    `{"glob": "/*"} // done`,
+			Expected: `{"glob": "/*"}        `,
+		},
+	}
+
+	for _, tc := range cases {
+		blanked := BlankJSONComments(tc.Input)
+		assert.Equal(t, tc.Expected, blanked, tc.Case)
+		assert.Len(t, blanked, len(tc.Input), tc.Case)
+	}
+}
@@ -1,7 +1,12 @@
 //go:build js && wasm
 
 // Package main is the js/wasm entrypoint that lets a browser render a config
-// straight to an SVG string, without shelling out to the CLI: the studio
+// straight to an SVG string, without shelling out to the CLI, and gives an
+// in-browser config editor the rest of what it needs from the same module:
+// validate(configText, format) for structured diagnostics, segments() for every
+// segment type with its options and template fields, convert(configText, from,
+// to) between config formats, and runs(configText, format, dataJSON, options)
+// for the captured runs of each row, so the host can draw the prompt itself. The studio
 // (see the website-redesign-direction note) hands over a config's own text
 // and a recorded --data file's JSON, rather than a file path or a URL,
 // because a browser has neither a filesystem to read nor permission to
@@ -20,6 +25,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"syscall/js"
@@ -27,6 +33,7 @@ import (
 
 	"github.com/jandedobbeleer/oh-my-posh/src/color"
 	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
 	"github.com/jandedobbeleer/oh-my-posh/src/render"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 	"github.com/jandedobbeleer/oh-my-posh/src/svg"
@@ -43,50 +50,65 @@ func main() {
 	// UTC to match (website/segment_data.json).
 	time.Local = time.UTC
 
-	js.Global().Set("render", js.FuncOf(renderJS))
+	js.Global().Set("render", export("svg", renderSVG))
+	js.Global().Set("runs", export("prompt", renderRuns))
+	js.Global().Set("validate", export("diagnostics", validate))
+	js.Global().Set("segments", export("segments", segments))
+	js.Global().Set("convert", export("config", convert))
 
 	// A wasm_exec.js-hosted module's main must never return: the host glue
 	// tears down the Go scheduler as soon as it does, and every function
 	// registered above (js.FuncOf) stops working on the very next call. This
-	// package exists only to expose renderJS, never to run a program of its
-	// own, so blocking forever is exactly what it should do.
+	// package exists only to expose those functions, never to run a program of
+	// its own, so blocking forever is exactly what it should do.
 	select {}
 }
 
-// renderJS is the JS-callable render(configText, format, dataJSON,
-// optionsObject) function described in the package doc comment. It returns
-// {svg: string} on success or {error: string} on failure - a returned value,
-// never a panic: an uncaught panic unwinding out of a js.Func call kills the
-// whole wasm instance, not just the one call, which would take down every
-// future render() call in the same browser tab along with it. The recover
-// below is the backstop for that; renderSVG itself is expected to return an
-// error instead of panicking for every input this function's own callers
-// (JS, so already untrusted) can produce.
-func renderJS(_ js.Value, args []js.Value) (result any) {
-	defer func() {
-		if r := recover(); r != nil {
-			result = map[string]any{"error": fmt.Sprintf("panic: %v", r)}
+// export wraps fn as a JS-callable function returning {key: value} on
+// success or {error: string} on failure - a returned value, never a panic: an
+// uncaught panic unwinding out of a js.Func call kills the whole wasm
+// instance, not just the one call, which would take down every future call
+// in the same browser tab along with it. The recover below is the backstop
+// for that; every fn is expected to return an error instead of panicking for
+// every input its callers (JS, so already untrusted) can produce.
+func export(key string, fn func(args []js.Value) (any, error)) js.Func {
+	return js.FuncOf(func(_ js.Value, args []js.Value) (result any) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = map[string]any{"error": fmt.Sprintf("panic: %v", r)}
+			}
+		}()
+
+		value, err := fn(args)
+		if err != nil {
+			return map[string]any{"error": err.Error()}
 		}
-	}()
 
-	doc, err := renderSVG(args)
+		return map[string]any{key: value}
+	})
+}
+
+// renderSVG is render(configText, format, dataJSON, optionsObject): the
+// config rendered by renderEngine, encoded as an SVG document.
+func renderSVG(args []js.Value) (any, error) {
+	eng, opts, err := renderEngine("render", args)
 	if err != nil {
-		return map[string]any{"error": err.Error()}
+		return nil, err
 	}
 
-	return map[string]any{"svg": doc}
+	return render.SVG(eng, opts), nil
 }
 
-// renderSVG does the actual work: parse the config and optional data from
-// memory, render them, and encode the result - all through config's memory
+// renderEngine does the actual work: parse the config and optional data from
+// memory, render them, and resolve the SVG options - all through config's memory
 // parsers and the render package, never through config.Load or anything
 // else that would touch a file or the network (config.ParseBytes/
 // config.ParseData are exactly the memory-only counterparts to config.Load's
 // file-and-URL-reading Parse; see their own doc comments for why a config
 // parsed this way can never pull in a remote "extends").
-func renderSVG(args []js.Value) (string, error) {
+func renderEngine(name string, args []js.Value) (*prompt.Engine, svg.Options, error) {
 	if len(args) < 4 {
-		return "", fmt.Errorf("render expects 4 arguments (configText, format, dataJSON, options), got %d", len(args))
+		return nil, svg.Options{}, fmt.Errorf("%s expects 4 arguments (configText, format, dataJSON, options), got %d", name, len(args))
 	}
 
 	configText := jsString(args[0])
@@ -107,7 +129,7 @@ func renderSVG(args []js.Value) (string, error) {
 	} else {
 		cfg, err = config.ParseBytes(format, []byte(configText))
 		if err != nil {
-			return "", fmt.Errorf("failed to parse config: %w", err)
+			return nil, svg.Options{}, fmt.Errorf("failed to parse config: %w", err)
 		}
 	}
 
@@ -116,7 +138,12 @@ func renderSVG(args []js.Value) (string, error) {
 	if dataJSON != "" {
 		data, err = config.ParseData([]byte(dataJSON))
 		if err != nil {
-			return "", fmt.Errorf("failed to parse data: %w", err)
+			return nil, svg.Options{}, fmt.Errorf("failed to parse data: %w", err)
+		}
+
+		// a recorded session renders the frame it ended on, like the CLI image command's default
+		if data, err = data.Frame(len(data.Timeline()) - 1); err != nil {
+			return nil, svg.Options{}, err
 		}
 	}
 
@@ -160,7 +187,7 @@ func renderSVG(args []js.Value) (string, error) {
 	// same reasoning applied to recordThemeSanitized's per-theme loop.
 	eng, err := render.Config(cfg, columns, true, applyData)
 	if err != nil {
-		return "", fmt.Errorf("failed to render config: %w", err)
+		return nil, svg.Options{}, fmt.Errorf("failed to render config: %w", err)
 	}
 
 	metrics := render.FontMetrics{
@@ -184,7 +211,159 @@ func renderSVG(args []js.Value) (string, error) {
 		}
 	}
 
-	return render.SVG(eng, opts), nil
+	return eng, opts, nil
+}
+
+// runJSON is a terminal.Run as runs() hands it to JS: its text, width and
+// link, its colors as the config wrote them, and those colors resolved to
+// the hex the SVG would paint, the run's mode and reverse video applied.
+type runJSON struct {
+	Text             string     `json:"text"`
+	Hyperlink        string     `json:"hyperlink,omitempty"`
+	Foreground       string     `json:"foreground,omitempty"`
+	Background       string     `json:"background,omitempty"`
+	ForegroundSource color.Ansi `json:"foregroundSource,omitempty"`
+	BackgroundSource color.Ansi `json:"backgroundSource,omitempty"`
+	Cells            int        `json:"cells"`
+	Bold             bool       `json:"bold,omitempty"`
+	Italic           bool       `json:"italic,omitempty"`
+	Underline        bool       `json:"underline,omitempty"`
+	Overline         bool       `json:"overline,omitempty"`
+	Strikethrough    bool       `json:"strikethrough,omitempty"`
+	Dim              bool       `json:"dim,omitempty"`
+	Blink            bool       `json:"blink,omitempty"`
+}
+
+// cursorJSON is an svg.Cursor: the row, and the run in it the cursor sits
+// in front of.
+type cursorJSON struct {
+	Row int `json:"row"`
+	Run int `json:"run"`
+}
+
+// renderRuns is runs(configText, format, dataJSON, optionsObject): the same
+// render as render(), returned as {prompt: {rows, cursor, background,
+// foreground}} instead of an SVG. rows holds every row's runs, cursor the {row, run} the
+// cursor sits in front of (null at the end of the prompt), and background
+// and foreground the terminal's default colors behind them.
+func renderRuns(args []js.Value) (any, error) {
+	eng, opts, err := renderEngine("runs", args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, spans, cursor, resolved := render.Runs(eng, opts)
+
+	result := struct {
+		Cursor     *cursorJSON `json:"cursor"`
+		Background string      `json:"background,omitempty"`
+		Foreground string      `json:"foreground,omitempty"`
+		Rows       [][]runJSON `json:"rows"`
+	}{
+		Background: hex(resolved.CanvasBackground),
+		Rows:       make([][]runJSON, 0, len(rows)),
+	}
+
+	if cursor != nil {
+		result.Cursor = &cursorJSON{Row: cursor.Row, Run: cursor.Run}
+	}
+
+	if foreground, ok := svg.ResolveStaticRGB(color.Ansi("default"), false, &resolved); ok {
+		result.Foreground = hex(foreground)
+	}
+
+	for i, row := range rows {
+		runs := make([]runJSON, 0, len(row))
+
+		for j := range row {
+			run, span := &row[j], &spans[i][j]
+
+			runs = append(runs, runJSON{
+				Text:             run.Text,
+				Hyperlink:        run.Hyperlink,
+				Foreground:       hex(span.Fill),
+				Background:       hex(span.Background),
+				ForegroundSource: run.ForegroundSource,
+				BackgroundSource: run.BackgroundSource,
+				Cells:            run.Cells,
+				Bold:             span.Bold(),
+				Italic:           span.Italic(),
+				Underline:        span.Underline(),
+				Overline:         span.Overline(),
+				Strikethrough:    span.Strikethrough(),
+				Dim:              span.Dim(),
+				Blink:            span.Blink(),
+			})
+		}
+
+		result.Rows = append(result.Rows, runs)
+	}
+
+	return jsJSON(result)
+}
+
+// validate is validate(configText, format): config.Validate's diagnostics,
+// an empty list for a valid config. An empty config is valid, as render()
+// renders the built-in default for it.
+func validate(args []js.Value) (any, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("validate expects 2 arguments (configText, format), got %d", len(args))
+	}
+
+	configText := jsString(args[0])
+
+	diagnostics := []config.Diagnostic{}
+	if strings.TrimSpace(configText) != "" {
+		diagnostics = append(diagnostics, config.Validate(jsString(args[1]), []byte(configText))...)
+	}
+
+	return jsJSON(diagnostics)
+}
+
+// segments is segments(): every segment type with the options it accepts and
+// the fields its template reads, see config.SegmentMetadata. This build has
+// no writers, the catalog is the copy embedded in it.
+func segments(_ []js.Value) (any, error) {
+	return jsJSON(config.SegmentCatalog())
+}
+
+// convert is convert(configText, from, to): the config in another format,
+// keeping its comments and key order where the target has room for them
+// (see config.Document). A config that fails to parse only reports that it
+// did; validate() tells where.
+func convert(args []js.Value) (any, error) {
+	if len(args) < 3 {
+		return nil, fmt.Errorf("convert expects 3 arguments (configText, from, to), got %d", len(args))
+	}
+
+	converted, err := config.ConvertDocument([]byte(jsString(args[0])), jsString(args[1]), jsString(args[2]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert config: %s", strings.TrimSpace(err.Error()))
+	}
+
+	return string(converted), nil
+}
+
+// jsJSON hands v to JS as the value its JSON encoding parses to. js.ValueOf
+// only takes maps, slices and primitives, this reuses the json tags the
+// config types already carry instead of building a map for each of them.
+func jsJSON(v any) (js.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return js.Undefined(), err
+	}
+
+	return js.Global().Get("JSON").Call("parse", string(data)), nil
+}
+
+// hex formats rgb as #rrggbb, and nil as "", so an unset color drops out of
+// the JSON.
+func hex(rgb *color.RGB) string {
+	if rgb == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", rgb.R, rgb.G, rgb.B)
 }
 
 // optionsGet reads key off options, tolerating every shape a JS caller might
@@ -192,7 +371,7 @@ func renderSVG(args []js.Value) (string, error) {
 // explicit null, or simply not an object at all. js.Value.Get panics if
 // called on a value that isn't a JavaScript object, so this guards that
 // instead of letting a loosely-typed JS caller crash the whole render() call
-// (see renderJS's own doc comment on why nothing here should ever panic).
+// (see export's own doc comment on why nothing here should ever panic).
 // A real object with the key simply absent needs no such guard - Get already
 // answers that with js.Undefined(), same as a missing map key would in Go.
 func optionsGet(options js.Value, key string) js.Value {
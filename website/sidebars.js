@@ -30,7 +30,9 @@ export default {
         "installation/fonts",
         "installation/prompt",
         "installation/customize",
+        "installation/completion",
         "installation/upgrade",
+        "installation/protocol",
       ],
     },
     {